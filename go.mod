@@ -2,7 +2,10 @@ module github.com/tbxark/gh-stars
 
 go 1.25
 
-require fyne.io/fyne/v2 v2.7.2
+require (
+	fyne.io/fyne/v2 v2.7.2
+	golang.org/x/crypto v0.36.0
+)
 
 require (
 	fyne.io/systray v1.12.0 // indirect
@@ -0,0 +1,131 @@
+package compare
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// UserStars is the starred list of one user.
+type UserStars struct {
+	Username string
+	Repos    []domain.Repo
+}
+
+// Entry is a repo together with the compared users who starred it, in the
+// order the users were given.
+type Entry struct {
+	Repo      domain.Repo
+	StarredBy []string
+}
+
+// Pair is the overlap between the stars of two users.
+type Pair struct {
+	A       string
+	B       string
+	Shared  int
+	Jaccard float64
+}
+
+// Result holds every repo starred by at least one of the users plus the
+// pairwise similarity scores.
+type Result struct {
+	Users []string
+	Union []Entry
+	Pairs []Pair
+}
+
+// PublicOnly returns a copy of repos without the private ones; loaders can
+// share the slice they return.
+func PublicOnly(repos []domain.Repo) []domain.Repo {
+	public := make([]domain.Repo, 0, len(repos))
+	for _, repo := range repos {
+		if !repo.Private {
+			public = append(public, repo)
+		}
+	}
+	return public
+}
+
+// Compare matches repos across users by Repo.Key. Union is sorted by how many
+// users starred a repo, most shared first, then by name.
+func Compare(users []UserStars) Result {
+	result := Result{Users: make([]string, len(users))}
+	index := map[string]int{}
+	sets := make([]map[string]bool, len(users))
+
+	for i, user := range users {
+		result.Users[i] = user.Username
+		sets[i] = make(map[string]bool, len(user.Repos))
+		for _, repo := range user.Repos {
+			key := repo.Key()
+			if sets[i][key] {
+				continue
+			}
+			sets[i][key] = true
+			pos, ok := index[key]
+			if !ok {
+				pos = len(result.Union)
+				index[key] = pos
+				result.Union = append(result.Union, Entry{Repo: repo})
+			}
+			result.Union[pos].StarredBy = append(result.Union[pos].StarredBy, user.Username)
+		}
+	}
+	sort.SliceStable(result.Union, func(i, j int) bool {
+		a, b := result.Union[i], result.Union[j]
+		if len(a.StarredBy) != len(b.StarredBy) {
+			return len(a.StarredBy) > len(b.StarredBy)
+		}
+		return strings.ToLower(a.Repo.FullName) < strings.ToLower(b.Repo.FullName)
+	})
+
+	for i := range users {
+		for j := i + 1; j < len(users); j++ {
+			shared := 0
+			for key := range sets[i] {
+				if sets[j][key] {
+					shared++
+				}
+			}
+			result.Pairs = append(result.Pairs, Pair{
+				A:       users[i].Username,
+				B:       users[j].Username,
+				Shared:  shared,
+				Jaccard: jaccard(shared, len(sets[i]), len(sets[j])),
+			})
+		}
+	}
+	return result
+}
+
+// Intersection returns the repos starred by every compared user.
+func (r Result) Intersection() []Entry {
+	var out []Entry
+	for _, entry := range r.Union {
+		if len(entry.StarredBy) == len(r.Users) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+// Only returns the repos starred by username and by none of the others.
+func (r Result) Only(username string) []Entry {
+	var out []Entry
+	for _, entry := range r.Union {
+		if len(entry.StarredBy) == 1 && strings.EqualFold(entry.StarredBy[0], username) {
+			out = append(out, entry)
+		}
+	}
+	return out
+}
+
+func jaccard(shared, a, b int) float64 {
+	union := a + b - shared
+	if union == 0 {
+		return 0
+	}
+	return float64(shared) / float64(union)
+}
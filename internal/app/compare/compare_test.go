@@ -0,0 +1,102 @@
+package compare_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func repos(names ...string) []domain.Repo {
+	out := make([]domain.Repo, len(names))
+	for i, name := range names {
+		out[i] = domain.Repo{FullName: name}
+	}
+	return out
+}
+
+func TestCompare_SetsAndJaccard(t *testing.T) {
+	result := compare.Compare([]compare.UserStars{
+		{Username: "alice", Repos: repos("golang/go", "fyne-io/fyne", "spf13/cobra")},
+		{Username: "bob", Repos: repos("golang/go", "Spf13/Cobra", "charmbracelet/bubbletea")},
+		{Username: "carol", Repos: repos("golang/go")},
+	})
+
+	testutil.AssertEqual(t, 4, len(result.Union))
+	testutil.AssertEqual(t, "golang/go", result.Union[0].Repo.FullName)
+	testutil.AssertEqual(t, 3, len(result.Union[0].StarredBy))
+
+	everyone := result.Intersection()
+	testutil.AssertEqual(t, 1, len(everyone))
+	testutil.AssertEqual(t, "golang/go", everyone[0].Repo.FullName)
+
+	onlyAlice := result.Only("Alice")
+	testutil.AssertEqual(t, 1, len(onlyAlice))
+	testutil.AssertEqual(t, "fyne-io/fyne", onlyAlice[0].Repo.FullName)
+
+	testutil.AssertEqual(t, 3, len(result.Pairs))
+	testutil.AssertEqual(t, "alice", result.Pairs[0].A)
+	testutil.AssertEqual(t, "bob", result.Pairs[0].B)
+	testutil.AssertEqual(t, 2, result.Pairs[0].Shared)
+	testutil.AssertEqual(t, 0.5, result.Pairs[0].Jaccard)
+}
+
+func TestCompare_EmptyListsHaveZeroSimilarity(t *testing.T) {
+	result := compare.Compare([]compare.UserStars{{Username: "a"}, {Username: "b"}})
+
+	testutil.AssertEqual(t, 0, len(result.Union))
+	testutil.AssertEqual(t, 0.0, result.Pairs[0].Jaccard)
+}
+
+func TestService_Load(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return repos(username + "/repo"), nil
+	}
+
+	users, err := compare.Service{Stars: mockSvc}.Load(context.Background(), []string{"alice", " bob ", "ALICE", ""}, "", 100)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(users))
+	testutil.AssertEqual(t, "bob", users[1].Username)
+	testutil.AssertEqual(t, "bob/repo", users[1].Repos[0].FullName)
+	testutil.AssertEqual(t, 2, mockSvc.GetLoadStarredCount())
+}
+
+func TestService_Load_DropsPrivateRepos(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return []domain.Repo{{FullName: username + "/public"}, {FullName: username + "/secret", Private: true}}, nil
+	}
+
+	users, err := compare.Service{Stars: mockSvc}.Load(context.Background(), []string{"alice", "bob"}, "", 100)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(users[0].Repos))
+	testutil.AssertEqual(t, "alice/public", users[0].Repos[0].FullName)
+
+	users, err = compare.Service{Stars: mockSvc, IncludePrivate: true}.Load(context.Background(), []string{"alice", "bob"}, "", 100)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(users[0].Repos))
+}
+
+func TestService_Load_Errors(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		if username == "ghost" {
+			return nil, errors.New("github api error: 404 Not Found: Not Found")
+		}
+		return nil, nil
+	}
+	svc := compare.Service{Stars: mockSvc}
+
+	_, err := svc.Load(context.Background(), []string{"alice"}, "", 100)
+	testutil.AssertError(t, err)
+
+	_, err = svc.Load(context.Background(), []string{"alice", "ghost"}, "", 100)
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, "ghost: github api error: 404 Not Found: Not Found", err.Error())
+}
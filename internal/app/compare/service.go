@@ -0,0 +1,78 @@
+package compare
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+)
+
+// Service loads the stars of several users at once.
+type Service struct {
+	Stars stars.Loader
+	// IncludePrivate keeps private repos, which the token's own account
+	// lists, in the results. They are dropped by default.
+	IncludePrivate bool
+}
+
+// Load fetches every user's stars in parallel. Usernames are trimmed and
+// de-duplicated case-insensitively; at least two are required.
+func (s Service) Load(ctx context.Context, usernames []string, token string, perPage int) ([]UserStars, error) {
+	names := UniqueNames(usernames)
+	if len(names) < 2 {
+		return nil, errors.New("enter at least two usernames to compare")
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		once     sync.Once
+		firstErr error
+		out      = make([]UserStars, len(names))
+	)
+	for i, name := range names {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos, err := s.Stars.LoadStarred(ctx, name, token, perPage)
+			if err != nil {
+				once.Do(func() {
+					firstErr = fmt.Errorf("%s: %w", name, err)
+					cancel()
+				})
+				return
+			}
+			if !s.IncludePrivate {
+				repos = PublicOnly(repos)
+			}
+			out[i] = UserStars{Username: name, Repos: repos}
+		}()
+	}
+	wg.Wait()
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return out, nil
+}
+
+// UniqueNames trims usernames and drops blanks and case-insensitive
+// duplicates, keeping the first spelling.
+func UniqueNames(usernames []string) []string {
+	seen := map[string]bool{}
+	var names []string
+	for _, name := range usernames {
+		name = strings.TrimSpace(name)
+		key := strings.ToLower(name)
+		if name == "" || seen[key] {
+			continue
+		}
+		seen[key] = true
+		names = append(names, name)
+	}
+	return names
+}
@@ -0,0 +1,317 @@
+package credstore
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"sync"
+
+	"golang.org/x/crypto/scrypt"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+)
+
+var (
+	// ErrLocked is returned when tokens are written before Unlock.
+	ErrLocked = errors.New("credential store is locked")
+	// ErrWrongPassphrase is returned by Unlock when the passphrase does not
+	// open the existing store.
+	ErrWrongPassphrase = errors.New("wrong passphrase")
+	// ErrCorrupt is returned by Unlock when the file is damaged.
+	ErrCorrupt = errors.New("credential store is corrupt")
+)
+
+const (
+	fileVersion = 1
+	keyLen      = 32
+	saltLen     = 16
+
+	// verifierPlaintext is sealed with the key so a passphrase can be checked
+	// even when no tokens are saved.
+	verifierPlaintext = "gh-stars"
+	verifierAAD       = "verifier"
+)
+
+// KDFParams are the scrypt cost parameters, saved with the file so they can be
+// raised later without breaking existing stores.
+type KDFParams struct {
+	N int `json:"n"`
+	R int `json:"r"`
+	P int `json:"p"`
+}
+
+// DefaultKDF follows the scrypt recommendation for interactive logins.
+var DefaultKDF = KDFParams{N: 1 << 15, R: 8, P: 1}
+
+// Bounds on KDF parameters read from a file, so a damaged file cannot make
+// Unlock use gigabytes of memory or run for minutes.
+const (
+	minN      = 1 << 10
+	maxN      = 1 << 20
+	maxR      = 32
+	maxP      = 16
+	maxMemory = 256 << 20
+)
+
+func (p KDFParams) validate() error {
+	if p.N < minN || p.N > maxN || p.N&(p.N-1) != 0 {
+		return fmt.Errorf("scrypt N must be a power of two from %d to %d", minN, maxN)
+	}
+	if p.R < 1 || p.R > maxR || p.P < 1 || p.P > maxP {
+		return errors.New("scrypt r or p is out of range")
+	}
+	if 128*p.N*p.R > maxMemory {
+		return errors.New("scrypt parameters need too much memory")
+	}
+	return nil
+}
+
+type sealed struct {
+	Nonce []byte `json:"nonce"`
+	Data  []byte `json:"data"`
+}
+
+type storeFile struct {
+	Version  int               `json:"version"`
+	KDF      KDFParams         `json:"kdf"`
+	Salt     []byte            `json:"salt"`
+	Verifier sealed            `json:"verifier"`
+	Tokens   map[string]sealed `json:"tokens"`
+}
+
+// Store keeps tokens encrypted with AES-GCM under a key derived from a
+// passphrase with scrypt. It needs no keychain daemon. Tokens are readable
+// only after Unlock, which is meant to happen once per session.
+type Store struct {
+	path string
+	// KDF is used when Unlock creates a new store. Zero means DefaultKDF.
+	KDF KDFParams
+
+	mu     sync.Mutex
+	aead   cipher.AEAD
+	file   storeFile
+	tokens map[string]string
+}
+
+var _ profile.Tokens = (*Store)(nil)
+
+// NewStore creates a store encrypted to path. With an empty path tokens
+// only last until the app quits.
+func NewStore(path string) *Store {
+	return &Store{path: path}
+}
+
+// Exists reports whether a store was created before, in which case Unlock
+// needs the passphrase it was created with.
+func (s *Store) Exists() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aead != nil {
+		return true
+	}
+	if s.path == "" {
+		return false
+	}
+	_, err := os.Stat(s.path)
+	return err == nil
+}
+
+func (s *Store) Unlocked() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.aead != nil
+}
+
+// Unlock derives the key from passphrase and decrypts the saved tokens. When
+// there is no store yet, it creates one protected by passphrase.
+func (s *Store) Unlock(passphrase string) error {
+	if passphrase == "" {
+		return errors.New("passphrase is required")
+	}
+	file, exists, err := s.read()
+	if err != nil {
+		return err
+	}
+	if !exists {
+		return s.create(passphrase)
+	}
+	if file.Version != fileVersion {
+		return fmt.Errorf("unsupported credential store version %d", file.Version)
+	}
+	if err := file.KDF.validate(); err != nil {
+		return fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if len(file.Salt) < saltLen {
+		return fmt.Errorf("%w: salt is too short", ErrCorrupt)
+	}
+
+	aead, err := newAEAD(passphrase, file.Salt, file.KDF)
+	if err != nil {
+		return err
+	}
+	if len(file.Verifier.Nonce) != aead.NonceSize() {
+		return fmt.Errorf("%w: bad verifier", ErrCorrupt)
+	}
+	if _, err := aead.Open(nil, file.Verifier.Nonce, file.Verifier.Data, []byte(verifierAAD)); err != nil {
+		return ErrWrongPassphrase
+	}
+	tokens := make(map[string]string, len(file.Tokens))
+	for ref, box := range file.Tokens {
+		// Open panics on a nonce of the wrong size.
+		if len(box.Nonce) != aead.NonceSize() {
+			return fmt.Errorf("%w: %s", ErrCorrupt, ref)
+		}
+		plain, err := aead.Open(nil, box.Nonce, box.Data, []byte(ref))
+		if err != nil {
+			return fmt.Errorf("%w: %s", ErrCorrupt, ref)
+		}
+		tokens[ref] = string(plain)
+	}
+
+	s.mu.Lock()
+	s.aead = aead
+	s.file = file
+	s.tokens = tokens
+	s.mu.Unlock()
+	return nil
+}
+
+// Lock drops the key and the decrypted tokens from memory.
+func (s *Store) Lock() {
+	s.mu.Lock()
+	s.aead = nil
+	s.tokens = nil
+	s.mu.Unlock()
+}
+
+// Token returns the token saved under ref. A locked store has none.
+func (s *Store) Token(ref string) (string, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	token, ok := s.tokens[ref]
+	return token, ok
+}
+
+func (s *Store) SetToken(ref, token string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aead == nil {
+		return ErrLocked
+	}
+	box, err := seal(s.aead, []byte(token), []byte(ref))
+	if err != nil {
+		return err
+	}
+	s.file.Tokens[ref] = box
+	s.tokens[ref] = token
+	return s.writeLocked()
+}
+
+func (s *Store) ForgetToken(ref string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.aead == nil {
+		return ErrLocked
+	}
+	if _, ok := s.file.Tokens[ref]; !ok {
+		return nil
+	}
+	delete(s.file.Tokens, ref)
+	delete(s.tokens, ref)
+	return s.writeLocked()
+}
+
+func (s *Store) create(passphrase string) error {
+	params := s.KDF
+	if params == (KDFParams{}) {
+		params = DefaultKDF
+	}
+	salt := make([]byte, saltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	aead, err := newAEAD(passphrase, salt, params)
+	if err != nil {
+		return err
+	}
+	verifier, err := seal(aead, []byte(verifierPlaintext), []byte(verifierAAD))
+	if err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.aead = aead
+	s.file = storeFile{
+		Version:  fileVersion,
+		KDF:      params,
+		Salt:     salt,
+		Verifier: verifier,
+		Tokens:   map[string]sealed{},
+	}
+	s.tokens = map[string]string{}
+	return s.writeLocked()
+}
+
+func (s *Store) read() (storeFile, bool, error) {
+	s.mu.Lock()
+	if s.aead != nil {
+		file := s.file
+		s.mu.Unlock()
+		return file, true, nil
+	}
+	s.mu.Unlock()
+
+	if s.path == "" {
+		return storeFile{}, false, nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return storeFile{}, false, nil
+	}
+	if err != nil {
+		return storeFile{}, false, err
+	}
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return storeFile{}, false, fmt.Errorf("%w: %v", ErrCorrupt, err)
+	}
+	if file.Tokens == nil {
+		file.Tokens = map[string]sealed{}
+	}
+	return file, true, nil
+}
+
+// writeLocked saves the encrypted file. Callers hold s.mu.
+func (s *Store) writeLocked() error {
+	if s.path == "" {
+		return nil
+	}
+	return filestore.WriteJSON(s.path, s.file, 0o600)
+}
+
+func newAEAD(passphrase string, salt []byte, params KDFParams) (cipher.AEAD, error) {
+	key, err := scrypt.Key([]byte(passphrase), salt, params.N, params.R, params.P, keyLen)
+	if err != nil {
+		return nil, err
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+func seal(aead cipher.AEAD, plaintext, aad []byte) (sealed, error) {
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return sealed{}, err
+	}
+	return sealed{Nonce: nonce, Data: aead.Seal(nil, nonce, plaintext, aad)}, nil
+}
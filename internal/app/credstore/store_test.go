@@ -0,0 +1,120 @@
+package credstore_test
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/credstore"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+// fastKDF keeps tests quick; real stores use credstore.DefaultKDF.
+var fastKDF = credstore.KDFParams{N: 1 << 10, R: 8, P: 1}
+
+func newStore(path string) *credstore.Store {
+	store := credstore.NewStore(path)
+	store.KDF = fastKDF
+	return store
+}
+
+func TestStore_RoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+
+	store := newStore(path)
+	testutil.AssertFalse(t, store.Exists(), "store should not exist yet")
+	testutil.AssertNoError(t, store.Unlock("correct horse"))
+	testutil.AssertNoError(t, store.SetToken("work@github.com", "ghp_secret"))
+	testutil.AssertNoError(t, store.SetToken("home@github.com", "ghp_other"))
+	testutil.AssertNoError(t, store.ForgetToken("home@github.com"))
+
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertFalse(t, strings.Contains(string(data), "ghp_secret"), "token must not be stored in plain text")
+
+	reopened := credstore.NewStore(path)
+	testutil.AssertTrue(t, reopened.Exists(), "store should exist after saving")
+	_, ok := reopened.Token("work@github.com")
+	testutil.AssertFalse(t, ok, "locked store should not return tokens")
+	testutil.AssertTrue(t, errors.Is(reopened.SetToken("x", "y"), credstore.ErrLocked), "writes need an unlocked store")
+
+	testutil.AssertNoError(t, reopened.Unlock("correct horse"))
+	token, ok := reopened.Token("work@github.com")
+	testutil.AssertTrue(t, ok, "token should survive a reopen")
+	testutil.AssertEqual(t, "ghp_secret", token)
+	_, ok = reopened.Token("home@github.com")
+	testutil.AssertFalse(t, ok, "forgotten token should be gone")
+
+	reopened.Lock()
+	_, ok = reopened.Token("work@github.com")
+	testutil.AssertFalse(t, ok, "Lock should drop decrypted tokens")
+}
+
+func TestStore_WrongPassphrase(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	store := newStore(path)
+	testutil.AssertNoError(t, store.Unlock("correct horse"))
+	testutil.AssertNoError(t, store.SetToken("work@github.com", "ghp_secret"))
+
+	reopened := credstore.NewStore(path)
+	err := reopened.Unlock("battery staple")
+	testutil.AssertTrue(t, errors.Is(err, credstore.ErrWrongPassphrase), "expected ErrWrongPassphrase")
+	testutil.AssertFalse(t, reopened.Unlocked(), "store should stay locked")
+	_, ok := reopened.Token("work@github.com")
+	testutil.AssertFalse(t, ok, "no tokens after a failed unlock")
+}
+
+func TestStore_InMemory(t *testing.T) {
+	store := newStore("")
+	testutil.AssertError(t, store.Unlock(""))
+	testutil.AssertNoError(t, store.Unlock("pass"))
+	testutil.AssertNoError(t, store.SetToken("work@github.com", "ghp_secret"))
+
+	token, ok := store.Token("work@github.com")
+	testutil.AssertTrue(t, ok, "token should be kept in memory")
+	testutil.AssertEqual(t, "ghp_secret", token)
+}
+
+func TestStore_CorruptFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	store := newStore(path)
+	testutil.AssertNoError(t, store.Unlock("correct horse"))
+	testutil.AssertNoError(t, store.SetToken("work@github.com", "ghp_secret"))
+	valid, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+
+	corrupt := func(edit func(file map[string]any)) {
+		var file map[string]any
+		testutil.AssertNoError(t, json.Unmarshal(valid, &file))
+		edit(file)
+		data, err := json.Marshal(file)
+		testutil.AssertNoError(t, err)
+		testutil.AssertNoError(t, os.WriteFile(path, data, 0o600))
+	}
+	assertCorrupt := func(message string) {
+		t.Helper()
+		err := credstore.NewStore(path).Unlock("correct horse")
+		testutil.AssertTrue(t, errors.Is(err, credstore.ErrCorrupt), message)
+	}
+
+	corrupt(func(file map[string]any) {
+		file["tokens"].(map[string]any)["work@github.com"].(map[string]any)["nonce"] = "AAAA"
+	})
+	assertCorrupt("a short token nonce should be reported, not panic")
+
+	corrupt(func(file map[string]any) {
+		file["verifier"].(map[string]any)["nonce"] = "AAAA"
+	})
+	assertCorrupt("a short verifier nonce should be reported, not panic")
+
+	corrupt(func(file map[string]any) {
+		file["kdf"] = map[string]any{"n": 1 << 30, "r": 8, "p": 1}
+	})
+	assertCorrupt("an unbounded KDF cost should be refused")
+
+	testutil.AssertNoError(t, os.WriteFile(path, valid[:len(valid)/2], 0o600))
+	assertCorrupt("a truncated file should be reported")
+}
@@ -0,0 +1,193 @@
+package deviceflow
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultBaseURL is where GitHub serves the device flow endpoints. They live
+// on the web host, not on the API host.
+const DefaultBaseURL = "https://github.com"
+
+const (
+	grantType       = "urn:ietf:params:oauth:grant-type:device_code"
+	defaultInterval = 5 * time.Second
+	slowDownStep    = 5 * time.Second
+)
+
+var (
+	// ErrAccessDenied is returned when the user cancels the authorization.
+	ErrAccessDenied = errors.New("sign-in was cancelled on GitHub")
+	// ErrExpired is returned when the user code expired before it was
+	// entered.
+	ErrExpired = errors.New("sign-in code expired, start again")
+)
+
+// Config describes the OAuth app to sign in with. BaseURL can point at a
+// GitHub Enterprise server or a local stand-in for tests.
+type Config struct {
+	ClientID string
+	Scopes   []string
+	BaseURL  string
+	HTTP     *http.Client
+}
+
+// Code is what the user has to enter at VerificationURI.
+type Code struct {
+	DeviceCode      string
+	UserCode        string
+	VerificationURI string
+	// ExpiresAt is zero when the server sets no expiry.
+	ExpiresAt time.Time
+	Interval  time.Duration
+}
+
+type Token struct {
+	AccessToken string
+	TokenType   string
+	Scope       string
+}
+
+type codeResponse struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+	Error           string `json:"error"`
+	ErrorDesc       string `json:"error_description"`
+}
+
+type tokenResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	Scope       string `json:"scope"`
+	Interval    int    `json:"interval"`
+	Error       string `json:"error"`
+	ErrorDesc   string `json:"error_description"`
+}
+
+// RequestCode starts a sign-in and returns the code to show the user.
+func (c Config) RequestCode(ctx context.Context) (Code, error) {
+	if strings.TrimSpace(c.ClientID) == "" {
+		return Code{}, errors.New("oauth client id is not configured")
+	}
+	form := url.Values{"client_id": {c.ClientID}}
+	if len(c.Scopes) > 0 {
+		form.Set("scope", strings.Join(c.Scopes, " "))
+	}
+
+	var resp codeResponse
+	if err := c.post(ctx, "/login/device/code", form, &resp); err != nil {
+		return Code{}, err
+	}
+	if resp.Error != "" {
+		return Code{}, oauthError(resp.Error, resp.ErrorDesc)
+	}
+	interval := time.Duration(resp.Interval) * time.Second
+	if resp.Interval == 0 {
+		interval = defaultInterval
+	}
+	code := Code{
+		DeviceCode:      resp.DeviceCode,
+		UserCode:        resp.UserCode,
+		VerificationURI: resp.VerificationURI,
+		Interval:        interval,
+	}
+	// A missing expires_in means the code does not expire; a zero
+	// ExpiresAt tells PollToken so.
+	if resp.ExpiresIn > 0 {
+		code.ExpiresAt = time.Now().Add(time.Duration(resp.ExpiresIn) * time.Second)
+	}
+	return code, nil
+}
+
+// PollToken waits until the user has entered code, then returns the token.
+// It honours the interval GitHub asks for and stops when ctx is done or the
+// code expires.
+func (c Config) PollToken(ctx context.Context, code Code) (Token, error) {
+	interval := code.Interval
+	form := url.Values{
+		"client_id":   {c.ClientID},
+		"device_code": {code.DeviceCode},
+		"grant_type":  {grantType},
+	}
+	for {
+		if !code.ExpiresAt.IsZero() && time.Now().After(code.ExpiresAt) {
+			return Token{}, ErrExpired
+		}
+		select {
+		case <-ctx.Done():
+			return Token{}, ctx.Err()
+		case <-time.After(interval):
+		}
+
+		var resp tokenResponse
+		if err := c.post(ctx, "/login/oauth/access_token", form, &resp); err != nil {
+			return Token{}, err
+		}
+		switch resp.Error {
+		case "":
+			return Token{AccessToken: resp.AccessToken, TokenType: resp.TokenType, Scope: resp.Scope}, nil
+		case "authorization_pending":
+		case "slow_down":
+			if resp.Interval > 0 {
+				interval = time.Duration(resp.Interval) * time.Second
+			} else {
+				interval += slowDownStep
+			}
+		case "expired_token":
+			return Token{}, ErrExpired
+		case "access_denied":
+			return Token{}, ErrAccessDenied
+		default:
+			return Token{}, oauthError(resp.Error, resp.ErrorDesc)
+		}
+	}
+}
+
+func (c Config) post(ctx context.Context, path string, form url.Values, target any) error {
+	base := strings.TrimRight(c.BaseURL, "/")
+	if base == "" {
+		base = DefaultBaseURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, base+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	httpClient := c.HTTP
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 20 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode >= http.StatusMultipleChoices {
+		return fmt.Errorf("github sign-in error: %s: %s", resp.Status, strings.TrimSpace(string(body)))
+	}
+	return json.Unmarshal(body, target)
+}
+
+func oauthError(code, desc string) error {
+	if desc == "" {
+		return fmt.Errorf("github sign-in error: %s", code)
+	}
+	return fmt.Errorf("github sign-in error: %s: %s", code, desc)
+}
@@ -0,0 +1,95 @@
+package deviceflow_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/deviceflow"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+// newStandIn serves the two device flow endpoints. The token endpoint
+// answers with the given errors in turn and then issues a token.
+func newStandIn(t *testing.T, pending ...string) (*httptest.Server, *atomic.Int32) {
+	t.Helper()
+	var polls atomic.Int32
+	mux := http.NewServeMux()
+	mux.HandleFunc("/login/device/code", func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, http.MethodPost, r.Method)
+		testutil.AssertNoError(t, r.ParseForm())
+		testutil.AssertEqual(t, "client-123", r.PostForm.Get("client_id"))
+		testutil.AssertEqual(t, "repo read:user", r.PostForm.Get("scope"))
+		_, _ = w.Write([]byte(`{"device_code":"dev-1","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device","expires_in":900,"interval":0}`))
+	})
+	mux.HandleFunc("/login/oauth/access_token", func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertNoError(t, r.ParseForm())
+		testutil.AssertEqual(t, "dev-1", r.PostForm.Get("device_code"))
+		n := int(polls.Add(1))
+		if n <= len(pending) {
+			_, _ = w.Write([]byte(`{"error":"` + pending[n-1] + `","interval":0}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"access_token":"gho_signed_in","token_type":"bearer","scope":"repo,read:user"}`))
+	})
+	srv := httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv, &polls
+}
+
+func newConfig(srv *httptest.Server) deviceflow.Config {
+	return deviceflow.Config{
+		ClientID: "client-123",
+		Scopes:   []string{"repo", "read:user"},
+		BaseURL:  srv.URL,
+		HTTP:     srv.Client(),
+	}
+}
+
+func TestDeviceFlow_SignsIn(t *testing.T) {
+	srv, polls := newStandIn(t, "authorization_pending", "authorization_pending")
+	cfg := newConfig(srv)
+
+	code, err := cfg.RequestCode(context.Background())
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "ABCD-1234", code.UserCode)
+	testutil.AssertEqual(t, "https://github.com/login/device", code.VerificationURI)
+	testutil.AssertFalse(t, code.ExpiresAt.IsZero(), "expires_in should set an expiry")
+
+	code.Interval = 0
+	token, err := cfg.PollToken(context.Background(), code)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "gho_signed_in", token.AccessToken)
+	testutil.AssertEqual(t, "repo,read:user", token.Scope)
+	testutil.AssertEqual(t, int32(3), polls.Load())
+}
+
+func TestDeviceFlow_AccessDenied(t *testing.T) {
+	srv, _ := newStandIn(t, "access_denied")
+	cfg := newConfig(srv)
+
+	code, err := cfg.RequestCode(context.Background())
+	testutil.AssertNoError(t, err)
+	code.Interval = 0
+	_, err = cfg.PollToken(context.Background(), code)
+	testutil.AssertTrue(t, errors.Is(err, deviceflow.ErrAccessDenied), "expected ErrAccessDenied")
+}
+
+func TestDeviceFlow_RequiresClientID(t *testing.T) {
+	_, err := deviceflow.Config{}.RequestCode(context.Background())
+	testutil.AssertError(t, err)
+}
+
+func TestDeviceFlow_NoExpiresInMeansNoExpiry(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"device_code":"dev-1","user_code":"ABCD-1234","verification_uri":"https://github.com/login/device"}`))
+	}))
+	t.Cleanup(srv.Close)
+
+	code, err := newConfig(srv).RequestCode(context.Background())
+	testutil.AssertNoError(t, err)
+	testutil.AssertTrue(t, code.ExpiresAt.IsZero(), "a code without expires_in should not expire")
+}
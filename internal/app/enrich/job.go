@@ -0,0 +1,90 @@
+package enrich
+
+import (
+	"context"
+	"sync"
+
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+const saveEvery = 25
+
+// Runner enriches a catalog in the background and answers lookups for
+// repos it has already enriched.
+type Runner interface {
+	Run(ctx context.Context, catalog []domain.Repo, token string, onProgress func(Progress)) error
+	Lookup(fullName string) (domain.RepoDetails, bool)
+}
+
+// Progress describes how far a run has come. Done includes repos enriched by
+// earlier runs, so a resumed job starts part way through.
+type Progress struct {
+	Done   int
+	Failed int
+	Total  int
+}
+
+// Job walks a starred catalog and fetches full details for every repo through
+// a bounded worker pool, pausing whenever the rate-limit gate reports a low
+// budget. Repos already in the store are skipped.
+type Job struct {
+	Details repos.Loader
+	Store   *Store
+	Gate    *ratelimit.Gate
+	Workers int
+}
+
+var _ Runner = (*Job)(nil)
+
+func (j *Job) Lookup(fullName string) (domain.RepoDetails, bool) {
+	return j.Store.Get(fullName)
+}
+
+// Run enriches catalog and reports progress after every repo. Individual
+// failures are counted and skipped; only cancellation stops the run early.
+func (j *Job) Run(ctx context.Context, catalog []domain.Repo, token string, onProgress func(Progress)) error {
+	progress := Progress{Total: len(catalog)}
+	pending := make([]string, 0, len(catalog))
+	for _, repo := range catalog {
+		if _, ok := j.Store.Get(repo.FullName); ok {
+			progress.Done++
+			continue
+		}
+		pending = append(pending, repo.FullName)
+	}
+	report(onProgress, progress)
+
+	var mu sync.Mutex
+	runErr := ratelimit.ForEach(ctx, j.Gate, j.Workers, pending, func(ctx context.Context, fullName string) {
+		details, err := j.Details.LoadDetails(ctx, fullName, token)
+		if ctx.Err() != nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			progress.Failed++
+		} else {
+			j.Store.Put(fullName, details)
+			progress.Done++
+			if progress.Done%saveEvery == 0 {
+				_ = j.Store.Save()
+			}
+		}
+		report(onProgress, progress)
+	})
+
+	if err := j.Store.Save(); err != nil {
+		return err
+	}
+	return runErr
+}
+
+func report(onProgress func(Progress), progress Progress) {
+	if onProgress != nil {
+		onProgress(progress)
+	}
+}
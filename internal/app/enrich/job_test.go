@@ -0,0 +1,149 @@
+package enrich_test
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/enrich"
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func detailsFor(fullName string) domain.RepoDetails {
+	details := testdata.SampleRepoDetails()
+	details.FullName = fullName
+	return details
+}
+
+func TestJob_Run_EnrichesEveryRepo(t *testing.T) {
+	mockSvc := repos.NewMockService()
+	mockSvc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return detailsFor(fullName), nil
+	}
+	job := &enrich.Job{Details: mockSvc, Store: enrich.NewStore(""), Workers: 2}
+
+	var last enrich.Progress
+	var mu sync.Mutex
+	err := job.Run(context.Background(), testdata.SampleRepoList(), "token123", func(p enrich.Progress) {
+		mu.Lock()
+		last = p
+		mu.Unlock()
+	})
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, enrich.Progress{Done: 3, Total: 3}, last)
+	details, ok := job.Lookup("Microsoft/VSCode")
+	testutil.AssertTrue(t, ok, "lookup should be case-insensitive")
+	testutil.AssertEqual(t, "BSD 3-Clause", details.License)
+}
+
+func TestJob_Run_BoundsConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int32
+	mockSvc := repos.NewMockService()
+	mockSvc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		n := inFlight.Add(1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+		inFlight.Add(-1)
+		return detailsFor(fullName), nil
+	}
+
+	catalog := make([]domain.Repo, 20)
+	for i := range catalog {
+		catalog[i] = testdata.SampleRepo()
+		catalog[i].FullName = "owner/repo-" + string(rune('a'+i))
+	}
+	job := &enrich.Job{Details: mockSvc, Store: enrich.NewStore(""), Workers: 3}
+
+	err := job.Run(context.Background(), catalog, "", nil)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertTrue(t, peak.Load() <= 3, "no more than 3 requests should run at once")
+	testutil.AssertEqual(t, 20, mockSvc.GetLoadDetailsCount())
+}
+
+func TestJob_Run_ResumesFromSavedStore(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enriched.json")
+	catalog := testdata.SampleRepoList()
+
+	first := repos.NewMockService()
+	first.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		if fullName == "microsoft/vscode" {
+			return domain.RepoDetails{}, errors.New("502 bad gateway")
+		}
+		return detailsFor(fullName), nil
+	}
+	var progress enrich.Progress
+	err := (&enrich.Job{Details: first, Store: enrich.NewStore(path)}).Run(context.Background(), catalog, "", func(p enrich.Progress) {
+		progress = p
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, progress.Failed)
+
+	// Simulate a restart: a fresh store loaded from the same file.
+	store := enrich.NewStore(path)
+	testutil.AssertNoError(t, store.Load())
+	testutil.AssertEqual(t, 2, store.Len())
+
+	second := repos.NewMockService()
+	second.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return detailsFor(fullName), nil
+	}
+	err = (&enrich.Job{Details: second, Store: store}).Run(context.Background(), catalog, "", nil)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, second.GetLoadDetailsCount())
+	testutil.AssertEqual(t, 3, store.Len())
+}
+
+func TestStore_KeepsPrivateReposOffDisk(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "enriched.json")
+	store := enrich.NewStore(path)
+	secret := detailsFor("alice/secret")
+	secret.Private = true
+	store.Put("alice/secret", secret)
+	store.Put("golang/go", detailsFor("golang/go"))
+	testutil.AssertNoError(t, store.Save())
+
+	_, ok := store.Get("alice/secret")
+	testutil.AssertTrue(t, ok, "private details should stay in memory")
+	reloaded := enrich.NewStore(path)
+	testutil.AssertNoError(t, reloaded.Load())
+	testutil.AssertEqual(t, 1, reloaded.Len())
+	_, ok = reloaded.Get("alice/secret")
+	testutil.AssertFalse(t, ok, "private details should not be saved")
+}
+
+func TestJob_Run_WaitsOnLowBudget(t *testing.T) {
+	mockSvc := repos.NewMockService()
+	mockSvc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return detailsFor(fullName), nil
+	}
+	gate := &ratelimit.Gate{
+		Status: func() domain.RateLimit {
+			return domain.RateLimit{Limit: 60, Remaining: 2, Reset: time.Now().Add(time.Hour)}
+		},
+		Reserve: 10,
+	}
+	job := &enrich.Job{Details: mockSvc, Store: enrich.NewStore(""), Gate: gate}
+	ctx, cancel := testutil.WithTimeout(t, 50*time.Millisecond)
+	defer cancel()
+
+	err := job.Run(ctx, testdata.SampleRepoList(), "", nil)
+
+	testutil.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "run should stop when ctx expires while waiting")
+	testutil.AssertEqual(t, 0, mockSvc.GetLoadDetailsCount())
+}
@@ -0,0 +1,112 @@
+package enrich
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Store keeps enriched details keyed by repo full name and persists them as a
+// single JSON file, which is what makes an interrupted job resumable. The
+// file is shared by every profile, so private repos only live in memory, as
+// in repos.CachedLoader.
+type Store struct {
+	path string
+
+	mu      sync.RWMutex
+	details map[string]domain.RepoDetails
+}
+
+// NewStore creates a store saved to path. With an empty path an interrupted
+// job starts over.
+func NewStore(path string) *Store {
+	return &Store{path: path, details: map[string]domain.RepoDetails{}}
+}
+
+// Load reads previously saved details. A missing file is not an error.
+func (s *Store) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	details := map[string]domain.RepoDetails{}
+	if err := json.Unmarshal(data, &details); err != nil {
+		return err
+	}
+	// Files written by older versions can hold private repos.
+	for key, d := range details {
+		if d.Private {
+			delete(details, key)
+		}
+	}
+
+	s.mu.Lock()
+	s.details = details
+	s.mu.Unlock()
+	return nil
+}
+
+// Save writes the details of the public repos enriched so far.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	public := make(map[string]domain.RepoDetails, len(s.details))
+	for key, d := range s.details {
+		if !d.Private {
+			public[key] = d
+		}
+	}
+	return filestore.WriteJSON(s.path, public, 0o600)
+}
+
+// Clear drops every entry and removes the file.
+func (s *Store) Clear() error {
+	s.mu.Lock()
+	s.details = map[string]domain.RepoDetails{}
+	s.mu.Unlock()
+	if s.path == "" {
+		return nil
+	}
+	if err := os.Remove(s.path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) Get(fullName string) (domain.RepoDetails, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	details, ok := s.details[storeKey(fullName)]
+	return details, ok
+}
+
+func (s *Store) Put(fullName string, details domain.RepoDetails) {
+	s.mu.Lock()
+	s.details[storeKey(fullName)] = details
+	s.mu.Unlock()
+}
+
+func (s *Store) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.details)
+}
+
+func storeKey(fullName string) string {
+	return strings.ToLower(strings.TrimSpace(fullName))
+}
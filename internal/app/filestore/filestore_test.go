@@ -0,0 +1,48 @@
+package filestore_test
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestWriteJSON_CreatesDirsAndReplacesFile(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "nested")
+	path := filepath.Join(dir, "state.json")
+
+	testutil.AssertNoError(t, filestore.WriteJSON(path, map[string]int{"a": 1}, 0o600))
+	testutil.AssertNoError(t, filestore.WriteJSON(path, map[string]int{"b": 2}, 0o600))
+
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	var got map[string]int
+	testutil.AssertNoError(t, json.Unmarshal(data, &got))
+	testutil.AssertEqual(t, 2, got["b"])
+	testutil.AssertEqual(t, 0, got["a"])
+
+	info, err := os.Stat(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o600), info.Mode().Perm())
+	dirInfo, err := os.Stat(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o700), dirInfo.Mode().Perm())
+
+	entries, err := os.ReadDir(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(entries))
+}
+
+func TestWriteJSON_UnencodableValueLeavesFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "state.json")
+	testutil.AssertNoError(t, filestore.WriteJSON(path, []int{1}, 0o600))
+
+	testutil.AssertError(t, filestore.WriteJSON(path, make(chan int), 0o600))
+
+	data, err := os.ReadFile(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "[1]", string(data))
+}
@@ -0,0 +1,99 @@
+package flight
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+)
+
+// Group merges concurrent calls that share a key into a single underlying call.
+// Unlike x/sync/singleflight, every caller waits on its own context: a caller
+// that gives up returns right away, and the shared call is only cancelled
+// once the last caller waiting on it has left.
+type Group[T any] struct {
+	mu    sync.Mutex
+	calls map[string]*call[T]
+}
+
+type call[T any] struct {
+	done    chan struct{}
+	val     T
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// Do runs fn once for all concurrent callers with the same key. fn receives a
+// context that outlives any single caller but not all of them.
+func (g *Group[T]) Do(ctx context.Context, key string, fn func(ctx context.Context) (T, error)) (T, error) {
+	g.mu.Lock()
+	if g.calls == nil {
+		g.calls = map[string]*call[T]{}
+	}
+	c, ok := g.calls[key]
+	if !ok {
+		callCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		c = &call[T]{done: make(chan struct{}), cancel: cancel}
+		g.calls[key] = c
+		go g.run(callCtx, key, c, fn)
+	}
+	c.waiters++
+	g.mu.Unlock()
+
+	select {
+	case <-c.done:
+		return c.val, c.err
+	case <-ctx.Done():
+		g.leave(key, c)
+		var zero T
+		return zero, ctx.Err()
+	}
+}
+
+// Waiting reports how many callers wait on the call in flight for key.
+func (g *Group[T]) Waiting(key string) int {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if c, ok := g.calls[key]; ok {
+		return c.waiters
+	}
+	return 0
+}
+
+func (g *Group[T]) run(ctx context.Context, key string, c *call[T], fn func(ctx context.Context) (T, error)) {
+	c.val, c.err = fn(ctx)
+
+	g.mu.Lock()
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	c.cancel()
+	close(c.done)
+}
+
+func (g *Group[T]) leave(key string, c *call[T]) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	c.waiters--
+	if c.waiters > 0 {
+		return
+	}
+	// Nobody is waiting anymore; forget the call so the next caller starts fresh.
+	if g.calls[key] == c {
+		delete(g.calls, key)
+	}
+	c.cancel()
+}
+
+// Key builds a coalescing key from an endpoint and the identity of the token
+// used to call it. The token is hashed so it never sits in memory as a map key.
+func Key(endpoint, token string) string {
+	if token == "" {
+		return endpoint + "|anonymous"
+	}
+	sum := sha256.Sum256([]byte(token))
+	return endpoint + "|" + hex.EncodeToString(sum[:8])
+}
@@ -0,0 +1,185 @@
+package flight_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/flight"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+// blockingCall returns a call that hands its context to started and blocks
+// until release is closed or that context ends, counting how often it ran.
+func blockingCall(calls *atomic.Int32) (func(context.Context) (string, error), chan context.Context, chan struct{}) {
+	started := make(chan context.Context, 10)
+	release := make(chan struct{})
+	fn := func(ctx context.Context) (string, error) {
+		calls.Add(1)
+		started <- ctx
+		select {
+		case <-release:
+			return "result", nil
+		case <-ctx.Done():
+			return "", ctx.Err()
+		}
+	}
+	return fn, started, release
+}
+
+// awaitWaiters is the barrier between callers joining a call and the test
+// going on: it returns once n callers wait on key.
+func awaitWaiters[T any](t *testing.T, g *flight.Group[T], key string, n int) {
+	t.Helper()
+	testutil.Eventually(t, func() bool { return g.Waiting(key) == n }, "callers to join")
+}
+
+func TestGroup_MergesConcurrentCalls(t *testing.T) {
+	var g flight.Group[string]
+	var calls atomic.Int32
+	fn, started, release := blockingCall(&calls)
+
+	var wg sync.WaitGroup
+	results := make(chan string, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			val, err := g.Do(context.Background(), "key", fn)
+			if err == nil {
+				results <- val
+			}
+		}()
+	}
+	<-started
+	awaitWaiters(t, &g, "key", 5)
+	close(release)
+	wg.Wait()
+	close(results)
+
+	count := 0
+	for val := range results {
+		testutil.AssertEqual(t, "result", val)
+		count++
+	}
+	testutil.AssertEqual(t, 5, count)
+	testutil.AssertEqual(t, int32(1), calls.Load())
+	testutil.AssertEqual(t, 0, g.Waiting("key"))
+}
+
+func TestGroup_DifferentKeysRunSeparately(t *testing.T) {
+	var g flight.Group[string]
+	var calls atomic.Int32
+	fn, started, release := blockingCall(&calls)
+
+	var wg sync.WaitGroup
+	for _, key := range []string{"a", "b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = g.Do(context.Background(), key, fn)
+		}()
+	}
+	<-started
+	<-started
+	close(release)
+	wg.Wait()
+
+	testutil.AssertEqual(t, int32(2), calls.Load())
+}
+
+func TestGroup_WaiterCancellingLeavesOthersRunning(t *testing.T) {
+	var g flight.Group[string]
+	var calls atomic.Int32
+	fn, started, release := blockingCall(&calls)
+
+	patient := make(chan error, 1)
+	go func() {
+		_, err := g.Do(context.Background(), "key", fn)
+		patient <- err
+	}()
+	callCtx := <-started
+
+	ctx, cancel := testutil.WithCancel()
+	impatient := make(chan error, 1)
+	go func() {
+		_, err := g.Do(ctx, "key", fn)
+		impatient <- err
+	}()
+	awaitWaiters(t, &g, "key", 2)
+	cancel()
+
+	testutil.AssertTrue(t, errors.Is(<-impatient, context.Canceled), "cancelled waiter should return its own ctx error")
+	testutil.AssertEqual(t, 1, g.Waiting("key"))
+	testutil.AssertContextNotCancelled(t, callCtx)
+
+	close(release)
+	testutil.AssertNoError(t, <-patient)
+	testutil.AssertEqual(t, int32(1), calls.Load())
+}
+
+func TestGroup_LastWaiterLeavingCancelsCall(t *testing.T) {
+	var g flight.Group[string]
+	var calls atomic.Int32
+	fn, started, release := blockingCall(&calls)
+
+	ctx, cancel := testutil.WithCancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := g.Do(ctx, "key", fn)
+		done <- err
+	}()
+	callCtx := <-started
+	cancel()
+
+	testutil.AssertTrue(t, errors.Is(<-done, context.Canceled), "waiter should see cancellation")
+	testutil.WaitOrTimeout(t, callCtx.Done(), time.Second, "shared call should be cancelled")
+	testutil.AssertEqual(t, 0, g.Waiting("key"))
+
+	// The abandoned call was dropped, so the next caller starts a fresh one.
+	result := make(chan string, 1)
+	go func() {
+		val, _ := g.Do(context.Background(), "key", fn)
+		result <- val
+	}()
+	<-started
+	close(release)
+	testutil.AssertEqual(t, "result", <-result)
+	testutil.AssertEqual(t, int32(2), calls.Load())
+}
+
+func TestGroup_ErrorsAreShared(t *testing.T) {
+	var g flight.Group[string]
+	release := make(chan struct{})
+	boom := errors.New("boom")
+	fn := func(context.Context) (string, error) {
+		<-release
+		return "", boom
+	}
+
+	errs := make(chan error, 2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			_, err := g.Do(context.Background(), "key", fn)
+			errs <- err
+		}()
+	}
+	awaitWaiters(t, &g, "key", 2)
+	close(release)
+
+	testutil.AssertTrue(t, errors.Is(<-errs, boom), "first waiter should get the error")
+	testutil.AssertTrue(t, errors.Is(<-errs, boom), "second waiter should get the error")
+}
+
+func TestKey_HashesToken(t *testing.T) {
+	a := flight.Key("repos/golang/go", "secret-token")
+	b := flight.Key("repos/golang/go", "other-token")
+
+	testutil.AssertFalse(t, strings.Contains(a, "secret-token"), "key should not hold the raw token")
+	testutil.AssertTrue(t, a != b, "different tokens should give different keys")
+	testutil.AssertEqual(t, "repos/golang/go|anonymous", flight.Key("repos/golang/go", ""))
+}
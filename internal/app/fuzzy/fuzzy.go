@@ -0,0 +1,87 @@
+package fuzzy
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	matchScore       = 1
+	consecutiveBonus = 4
+	boundaryBonus    = 6
+	prefixBonus      = 8
+)
+
+// Score reports whether every rune of pattern appears in text, in order and
+// ignoring case, and how good the match is. Runs of consecutive runes and
+// matches at the start of words (after '/', '-', '_', '.', ' ' or a case
+// change) score higher, so "gg" prefers "golang/go" over "kubernetes/ingress".
+func Score(pattern, text string) (int, bool) {
+	pattern = strings.ToLower(strings.TrimSpace(pattern))
+	if pattern == "" {
+		return 0, true
+	}
+
+	score := 0
+	prev := rune(-1)
+	lastMatch := -2
+	p, _ := utf8.DecodeRuneInString(pattern)
+	pi := 0
+	for ti, r := range text {
+		lower := unicode.ToLower(r)
+		if lower == p {
+			score += matchScore
+			switch {
+			case ti == 0:
+				score += prefixBonus
+			case isBoundary(prev, r):
+				score += boundaryBonus
+			}
+			if lastMatch == ti-utf8.RuneLen(prev) {
+				score += consecutiveBonus
+			}
+			lastMatch = ti
+
+			pi += utf8.RuneLen(p)
+			if pi == len(pattern) {
+				// Shorter texts win ties.
+				return score*1000 - len(text), true
+			}
+			p, _ = utf8.DecodeRuneInString(pattern[pi:])
+		}
+		prev = r
+	}
+	return 0, false
+}
+
+func isBoundary(prev, r rune) bool {
+	switch prev {
+	case '/', '-', '_', '.', ' ', ':':
+		return true
+	}
+	return unicode.IsLower(prev) && unicode.IsUpper(r)
+}
+
+// Match is a candidate that matched, with its position in the input.
+type Match struct {
+	Index int
+	Score int
+}
+
+// Rank returns the candidates matching pattern, best first; ties keep the
+// input order. A limit of zero or less returns every match.
+func Rank(pattern string, candidates []string, limit int) []Match {
+	matches := make([]Match, 0, len(candidates))
+	for i, candidate := range candidates {
+		if score, ok := Score(pattern, candidate); ok {
+			matches = append(matches, Match{Index: i, Score: score})
+		}
+	}
+	sort.SliceStable(matches, func(a, b int) bool { return matches[a].Score > matches[b].Score })
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return matches
+}
@@ -0,0 +1,41 @@
+package fuzzy_test
+
+import (
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/fuzzy"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestScore_SubsequenceIgnoringCase(t *testing.T) {
+	_, ok := fuzzy.Score("GoGo", "golang/go")
+	testutil.AssertTrue(t, ok, "pattern runes in order should match")
+
+	_, ok = fuzzy.Score("ogl", "golang/go")
+	testutil.AssertFalse(t, ok, "out of order runes should not match")
+
+	_, ok = fuzzy.Score("  ", "anything")
+	testutil.AssertTrue(t, ok, "empty pattern matches everything")
+}
+
+func TestScore_PrefersWordStartsAndRuns(t *testing.T) {
+	boundary, _ := fuzzy.Score("fy", "fyne-io/fyne")
+	scattered, _ := fuzzy.Score("fy", "foo/dummy")
+	testutil.AssertTrue(t, boundary > scattered, "prefix run should beat scattered runes")
+
+	wordStart, _ := fuzzy.Score("or", "Open Repo")
+	inside, _ := fuzzy.Score("or", "Copy Error")
+	testutil.AssertTrue(t, wordStart > inside, "word starts should beat mid-word runes")
+}
+
+func TestRank_BestFirstWithLimit(t *testing.T) {
+	candidates := []string{"Copy URL", "Reload", "Open in Browser", "Open Selected"}
+
+	matches := fuzzy.Rank("open", candidates, 0)
+	testutil.AssertEqual(t, 2, len(matches))
+	testutil.AssertEqual(t, 3, matches[0].Index)
+	testutil.AssertEqual(t, 2, matches[1].Index)
+
+	matches = fuzzy.Rank("o", candidates, 1)
+	testutil.AssertEqual(t, 1, len(matches))
+}
@@ -0,0 +1,90 @@
+package history
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Rename records a repo whose full name changed between two snapshots.
+type Rename struct {
+	From domain.Repo
+	To   domain.Repo
+}
+
+// Changes is the difference between two snapshots.
+type Changes struct {
+	Starred   []domain.Repo
+	Unstarred []domain.Repo
+	Renamed   []Rename
+	Archived  []domain.Repo
+}
+
+func (c Changes) IsEmpty() bool {
+	return len(c.Starred) == 0 && len(c.Unstarred) == 0 && len(c.Renamed) == 0 && len(c.Archived) == 0
+}
+
+// Diff compares two snapshots. Repos are matched by ID so renames and
+// transfers are not mistaken for an unstar plus a new star; repos without an
+// ID fall back to their lower-cased full name.
+func Diff(from, to Snapshot) Changes {
+	before := make(map[string]domain.Repo, len(from.Repos))
+	for _, repo := range from.Repos {
+		before[repo.Key()] = repo
+	}
+
+	var changes Changes
+	seen := make(map[string]bool, len(to.Repos))
+	for _, repo := range to.Repos {
+		key := repo.Key()
+		seen[key] = true
+		old, ok := before[key]
+		if !ok {
+			changes.Starred = append(changes.Starred, repo)
+			continue
+		}
+		if !strings.EqualFold(old.FullName, repo.FullName) {
+			changes.Renamed = append(changes.Renamed, Rename{From: old, To: repo})
+		}
+		if repo.Archived && !old.Archived {
+			changes.Archived = append(changes.Archived, repo)
+		}
+	}
+	for _, repo := range from.Repos {
+		if !seen[repo.Key()] {
+			changes.Unstarred = append(changes.Unstarred, repo)
+		}
+	}
+	return changes
+}
+
+// Summary renders changes as a short plain-text report, suitable for pasting
+// into a chat message.
+func (c Changes) Summary(from, to Snapshot) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Stars of %s from %s to %s\n", to.Username, from.TakenAt.Format("2006-01-02"), to.TakenAt.Format("2006-01-02"))
+	if c.IsEmpty() {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+	writeRepos := func(title string, repos []domain.Repo) {
+		if len(repos) == 0 {
+			return
+		}
+		fmt.Fprintf(&b, "\n%s (%d):\n", title, len(repos))
+		for _, repo := range repos {
+			fmt.Fprintf(&b, "- %s %s\n", repo.FullName, repo.HTMLURL)
+		}
+	}
+	writeRepos("Newly starred", c.Starred)
+	writeRepos("Unstarred", c.Unstarred)
+	if len(c.Renamed) > 0 {
+		fmt.Fprintf(&b, "\nRenamed (%d):\n", len(c.Renamed))
+		for _, r := range c.Renamed {
+			fmt.Fprintf(&b, "- %s -> %s\n", r.From.FullName, r.To.FullName)
+		}
+	}
+	writeRepos("Archived", c.Archived)
+	return b.String()
+}
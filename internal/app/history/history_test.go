@@ -0,0 +1,162 @@
+package history_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+var (
+	monday  = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+	tuesday = monday.AddDate(0, 0, 1)
+)
+
+func TestDiff_DetectsEveryKindOfChange(t *testing.T) {
+	list := testdata.SampleRepoList()
+	from := history.Snapshot{Username: "testuser", TakenAt: monday, Repos: list}
+
+	renamed := list[1]
+	renamed.FullName = "k8s/kubernetes"
+	archived := list[2]
+	archived.Archived = true
+	to := history.Snapshot{
+		Username: "testuser",
+		TakenAt:  tuesday,
+		Repos:    []domain.Repo{renamed, archived, testdata.SampleRepoPrivate()},
+	}
+
+	changes := history.Diff(from, to)
+
+	testutil.AssertEqual(t, 1, len(changes.Starred))
+	testutil.AssertEqual(t, "user/private-repo", changes.Starred[0].FullName)
+	testutil.AssertEqual(t, 1, len(changes.Unstarred))
+	testutil.AssertEqual(t, "golang/go", changes.Unstarred[0].FullName)
+	testutil.AssertEqual(t, 1, len(changes.Renamed))
+	testutil.AssertEqual(t, "kubernetes/kubernetes", changes.Renamed[0].From.FullName)
+	testutil.AssertEqual(t, "k8s/kubernetes", changes.Renamed[0].To.FullName)
+	testutil.AssertEqual(t, 1, len(changes.Archived))
+	testutil.AssertEqual(t, "microsoft/vscode", changes.Archived[0].FullName)
+
+	summary := changes.Summary(from, to)
+	testutil.AssertTrue(t, strings.Contains(summary, "Newly starred (1)"), "summary should list new stars")
+	testutil.AssertTrue(t, strings.Contains(summary, "kubernetes/kubernetes -> k8s/kubernetes"), "summary should list renames")
+}
+
+func TestDiff_NoChanges(t *testing.T) {
+	snapshot := history.Snapshot{Username: "testuser", TakenAt: monday, Repos: testdata.SampleRepoList()}
+
+	changes := history.Diff(snapshot, snapshot)
+
+	testutil.AssertTrue(t, changes.IsEmpty(), "identical snapshots should have no changes")
+}
+
+func TestStore_SaveListLoad(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+
+	testutil.AssertNoError(t, store.Save(history.Snapshot{Username: "TestUser", TakenAt: tuesday, Repos: testdata.SampleRepoList()}))
+	testutil.AssertNoError(t, store.Save(history.Snapshot{Username: "testuser", TakenAt: monday}))
+
+	times, err := store.List("testuser")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(times))
+	testutil.AssertEqual(t, monday, times[0])
+	testutil.AssertEqual(t, tuesday, times[1])
+
+	latest, ok, err := store.Latest("testuser")
+	testutil.AssertNoError(t, err)
+	testutil.AssertTrue(t, ok, "latest snapshot should exist")
+	testutil.AssertEqual(t, 3, len(latest.Repos))
+	testutil.AssertEqual(t, int64(23096959), latest.Repos[0].ID)
+}
+
+func TestStore_SavesEverySync(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+	list := testdata.SampleRepoList()
+
+	testutil.AssertNoError(t, store.Save(history.Snapshot{Username: "testuser", TakenAt: monday, Repos: list}))
+	// Nothing was added or removed, but the latest snapshot still has to
+	// carry the new star count.
+	bumped := append([]domain.Repo(nil), list...)
+	bumped[0].Stars++
+	testutil.AssertNoError(t, store.Save(history.Snapshot{Username: "testuser", TakenAt: tuesday, Repos: bumped}))
+
+	times, err := store.List("testuser")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(times))
+	latest, _, err := store.Latest("testuser")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, list[0].Stars+1, latest.Repos[0].Stars)
+}
+
+func TestStore_PrunesOldestSnapshots(t *testing.T) {
+	dir := t.TempDir()
+	store := history.NewStore(dir)
+	store.Keep = 2
+	list := testdata.SampleRepoList()
+
+	for i := range list {
+		at := monday.AddDate(0, 0, i)
+		testutil.AssertNoError(t, store.Save(history.Snapshot{Username: "testuser", TakenAt: at, Repos: list[:i+1]}))
+	}
+
+	times, err := store.List("testuser")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(times))
+	testutil.AssertEqual(t, monday.AddDate(0, 0, 1), times[0])
+
+	entries, err := os.ReadDir(filepath.Join(dir, "testuser"))
+	testutil.AssertNoError(t, err)
+	info, err := entries[0].Info()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o600), info.Mode().Perm())
+}
+
+func TestStore_UnknownUserHasNoSnapshots(t *testing.T) {
+	store := history.NewStore(t.TempDir())
+
+	times, err := store.List("nobody")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(times))
+
+	_, ok, err := store.Latest("nobody")
+	testutil.AssertNoError(t, err)
+	testutil.AssertFalse(t, ok, "no snapshot expected")
+}
+
+func TestRecorder_SavesSnapshotOnSuccess(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	store := history.NewStore(t.TempDir())
+	recorder := &history.Recorder{Next: mockSvc, Store: store, Now: func() time.Time { return monday }}
+
+	repos, err := recorder.LoadStarred(context.Background(), "testuser", "token123", 100)
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 3, len(repos))
+	times, _ := store.List("testuser")
+	testutil.AssertEqual(t, 1, len(times))
+	testutil.AssertEqual(t, monday, times[0])
+}
+
+func TestRecorder_SkipsFailedLoads(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	store := history.NewStore(t.TempDir())
+	recorder := &history.Recorder{Next: mockSvc, Store: store}
+
+	_, err := recorder.LoadStarred(context.Background(), "testuser", "token123", 100)
+
+	testutil.AssertError(t, err)
+	times, _ := store.List("testuser")
+	testutil.AssertEqual(t, 0, len(times))
+}
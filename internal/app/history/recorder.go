@@ -0,0 +1,34 @@
+package history
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Recorder decorates a stars.Loader and saves every successful sync as a
+// snapshot. Saving is best effort and never fails the load itself.
+type Recorder struct {
+	Next  stars.Loader
+	Store *Store
+	// Now returns the current time; tests override it.
+	Now func() time.Time
+}
+
+var _ stars.Loader = (*Recorder)(nil)
+
+func (r *Recorder) LoadStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+	repos, err := r.Next.LoadStarred(ctx, username, token, perPage)
+	if err != nil || strings.TrimSpace(username) == "" {
+		return repos, err
+	}
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	_ = r.Store.Save(Snapshot{Username: username, TakenAt: now().UTC().Truncate(time.Second), Repos: repos})
+	return repos, nil
+}
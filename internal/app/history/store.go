@@ -0,0 +1,131 @@
+package history
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+const fileTimeLayout = "20060102T150405Z"
+
+// DefaultKeep is how many snapshots per user a Store keeps unless Keep is
+// changed.
+const DefaultKeep = 100
+
+// Snapshot is the full starred list of one user at one sync.
+type Snapshot struct {
+	Username string        `json:"username"`
+	TakenAt  time.Time     `json:"taken_at"`
+	Repos    []domain.Repo `json:"repos"`
+}
+
+// Store keeps snapshots as one JSON file per sync under a directory per user.
+// Snapshots can list private repos, so they are readable by the user only.
+type Store struct {
+	// Keep is how many snapshots are kept per user; older ones are deleted.
+	// Zero keeps them all.
+	Keep int
+
+	dir string
+}
+
+func NewStore(dir string) *Store {
+	return &Store{Keep: DefaultKeep, dir: dir}
+}
+
+// Save stores snapshot, then prunes the oldest ones beyond Keep.
+func (s *Store) Save(snapshot Snapshot) error {
+	if s.dir == "" {
+		return nil
+	}
+	name := snapshot.TakenAt.UTC().Format(fileTimeLayout) + ".json"
+	if err := filestore.WriteJSON(filepath.Join(s.userDir(snapshot.Username), name), snapshot, 0o600); err != nil {
+		return err
+	}
+	return s.prune(snapshot.Username)
+}
+
+// prune deletes the oldest snapshots of username beyond Keep.
+func (s *Store) prune(username string) error {
+	if s.Keep <= 0 {
+		return nil
+	}
+	times, err := s.List(username)
+	if err != nil || len(times) <= s.Keep {
+		return err
+	}
+	for _, t := range times[:len(times)-s.Keep] {
+		name := t.UTC().Format(fileTimeLayout) + ".json"
+		if err := os.Remove(filepath.Join(s.userDir(username), name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+			return err
+		}
+	}
+	return nil
+}
+
+// List returns the times of all snapshots for username, oldest first.
+func (s *Store) List(username string) ([]time.Time, error) {
+	if s.dir == "" {
+		return nil, nil
+	}
+	entries, err := os.ReadDir(s.userDir(username))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var times []time.Time
+	for _, entry := range entries {
+		stamp, ok := strings.CutSuffix(entry.Name(), ".json")
+		if !ok {
+			continue
+		}
+		t, err := time.Parse(fileTimeLayout, stamp)
+		if err != nil {
+			continue
+		}
+		times = append(times, t)
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+	return times, nil
+}
+
+func (s *Store) Load(username string, takenAt time.Time) (Snapshot, error) {
+	name := takenAt.UTC().Format(fileTimeLayout) + ".json"
+	data, err := os.ReadFile(filepath.Join(s.userDir(username), name))
+	if err != nil {
+		return Snapshot{}, err
+	}
+	var snapshot Snapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return Snapshot{}, err
+	}
+	return snapshot, nil
+}
+
+// Latest returns the most recent snapshot for username, if any.
+func (s *Store) Latest(username string) (Snapshot, bool, error) {
+	times, err := s.List(username)
+	if err != nil || len(times) == 0 {
+		return Snapshot{}, false, err
+	}
+	snapshot, err := s.Load(username, times[len(times)-1])
+	if err != nil {
+		return Snapshot{}, false, err
+	}
+	return snapshot, true, nil
+}
+
+func (s *Store) userDir(username string) string {
+	return filepath.Join(s.dir, url.PathEscape(strings.ToLower(strings.TrimSpace(username))))
+}
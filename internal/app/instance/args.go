@@ -0,0 +1,57 @@
+package instance
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// Usage describes the command line.
+const Usage = `usage:
+  gh-stars                  open the stars window
+  gh-stars open OWNER/NAME  open a repo's details
+  gh-stars search QUERY     filter the stars list, e.g. gh-stars search lang:go`
+
+// Action is what a launch asks the app to do.
+type Action string
+
+const (
+	// Show brings up the stars window.
+	Show Action = "show"
+	// Open shows the details of the repo in Request.Value.
+	Open Action = "open"
+	// Search filters the stars list by the query in Request.Value.
+	Search Action = "search"
+)
+
+// Request is a parsed command line, as sent to the running instance.
+type Request struct {
+	Action Action `json:"action"`
+	Value  string `json:"value,omitempty"`
+}
+
+// ParseArgs reads the arguments after the program name.
+func ParseArgs(args []string) (Request, error) {
+	if len(args) == 0 {
+		return Request{Action: Show}, nil
+	}
+	switch Action(args[0]) {
+	case Open:
+		if len(args) != 2 {
+			return Request{}, errors.New("open takes one OWNER/NAME")
+		}
+		owner, name, ok := strings.Cut(strings.TrimSpace(args[1]), "/")
+		if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+			return Request{}, fmt.Errorf("%q is not OWNER/NAME", args[1])
+		}
+		return Request{Action: Open, Value: owner + "/" + name}, nil
+	case Search:
+		query := strings.TrimSpace(strings.Join(args[1:], " "))
+		if query == "" {
+			return Request{}, errors.New("search needs a query")
+		}
+		return Request{Action: Search, Value: query}, nil
+	default:
+		return Request{}, fmt.Errorf("unknown command %q", args[0])
+	}
+}
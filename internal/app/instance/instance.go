@@ -0,0 +1,131 @@
+package instance
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// ErrRunning means another instance already listens on the socket.
+var ErrRunning = errors.New("gh-stars is already running")
+
+// ioTimeout bounds forwarding a request, so a hung instance does not hang
+// the launch.
+const ioTimeout = 3 * time.Second
+
+// SocketPath is where the running instance listens: in a gh-stars dir in
+// $XDG_RUNTIME_DIR, or else in the temp dir under a name with the user id.
+// Listen makes sure only the user can enter that dir.
+func SocketPath() string {
+	if dir := os.Getenv("XDG_RUNTIME_DIR"); dir != "" {
+		return filepath.Join(dir, "gh-stars", "gh-stars.sock")
+	}
+	return filepath.Join(os.TempDir(), fmt.Sprintf("gh-stars-%d", os.Getuid()), "gh-stars.sock")
+}
+
+// Server receives the requests of later launches on a unix socket.
+type Server struct {
+	l net.Listener
+}
+
+// Listen claims path for this process. It returns ErrRunning when another
+// instance answers there, and replaces a socket left by one that crashed.
+// The dir of path has to belong to the user and be closed to everyone else.
+func Listen(path string) (*Server, error) {
+	if err := privateDir(filepath.Dir(path)); err != nil {
+		return nil, err
+	}
+	l, err := listenUnix(path)
+	if err != nil {
+		if conn, dialErr := net.DialTimeout("unix", path, ioTimeout); dialErr == nil {
+			_ = conn.Close()
+			return nil, ErrRunning
+		}
+		if rmErr := os.Remove(path); rmErr != nil && !errors.Is(rmErr, fs.ErrNotExist) {
+			return nil, err
+		}
+		if l, err = listenUnix(path); err != nil {
+			return nil, err
+		}
+	}
+	return &Server{l: l}, nil
+}
+
+// privateDir creates dir for the socket. An existing one is only used when
+// it is the user's own and others cannot enter it; otherwise whoever can
+// could swap the socket for their own and receive the requests.
+func privateDir(dir string) error {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return err
+	}
+	info, err := os.Lstat(dir)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", dir)
+	}
+	return checkPrivate(dir, info)
+}
+
+// Serve calls handle with every request until Close. handle runs on the
+// connection's goroutine.
+func (s *Server) Serve(handle func(Request)) error {
+	for {
+		conn, err := s.l.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+		go serveConn(conn, handle)
+	}
+}
+
+func serveConn(conn net.Conn, handle func(Request)) {
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(ioTimeout))
+	var req Request
+	// Listen probes with connections that send nothing.
+	if err := json.NewDecoder(conn).Decode(&req); err != nil {
+		return
+	}
+	handle(req)
+	_, _ = io.WriteString(conn, "ok\n")
+}
+
+// Close stops listening and removes the socket.
+func (s *Server) Close() error {
+	return s.l.Close()
+}
+
+// Forward hands req to the instance listening on path and waits until it
+// was received.
+func Forward(path string, req Request) error {
+	conn, err := net.DialTimeout("unix", path, ioTimeout)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+	_ = conn.SetDeadline(time.Now().Add(ioTimeout))
+
+	if err := json.NewEncoder(conn).Encode(req); err != nil {
+		return err
+	}
+	reply, err := bufio.NewReader(conn).ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if reply != "ok\n" {
+		return fmt.Errorf("unexpected reply %q from the running instance", reply)
+	}
+	return nil
+}
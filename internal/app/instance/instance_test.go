@@ -0,0 +1,100 @@
+package instance_test
+
+import (
+	"io/fs"
+	"net"
+	"os"
+	"path/filepath"
+	"runtime"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/instance"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestParseArgs(t *testing.T) {
+	req, err := instance.ParseArgs(nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, instance.Request{Action: instance.Show}, req)
+
+	req, err = instance.ParseArgs([]string{"open", "golang/go"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, instance.Request{Action: instance.Open, Value: "golang/go"}, req)
+
+	req, err = instance.ParseArgs([]string{"search", "lang:go", "cli"})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, instance.Request{Action: instance.Search, Value: "lang:go cli"}, req)
+
+	for _, args := range [][]string{
+		{"open"},
+		{"open", "golang"},
+		{"open", "a/b/c"},
+		{"open", "/go"},
+		{"search"},
+		{"star", "golang/go"},
+	} {
+		_, err := instance.ParseArgs(args)
+		testutil.AssertError(t, err)
+	}
+}
+
+func TestListen_ForwardsToRunningInstance(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "gh-stars.sock")
+	server, err := instance.Listen(path)
+	testutil.AssertNoError(t, err)
+	defer server.Close()
+
+	received := make(chan instance.Request, 1)
+	go func() { _ = server.Serve(func(req instance.Request) { received <- req }) }()
+
+	_, err = instance.Listen(path)
+	testutil.AssertEqual(t, instance.ErrRunning, err)
+
+	want := instance.Request{Action: instance.Open, Value: "golang/go"}
+	testutil.AssertNoError(t, instance.Forward(path, want))
+	select {
+	case got := <-received:
+		testutil.AssertEqual(t, want, got)
+	case <-time.After(time.Second):
+		t.Fatal("request was not received")
+	}
+}
+
+func TestListen_ReplacesStaleSocket(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "run", "gh-stars.sock")
+	testutil.AssertNoError(t, os.Mkdir(filepath.Dir(path), 0o700))
+	stale, err := net.ListenUnix("unix", &net.UnixAddr{Name: path, Net: "unix"})
+	testutil.AssertNoError(t, err)
+	// A crashed instance leaves its socket file behind.
+	stale.SetUnlinkOnClose(false)
+	_ = stale.Close()
+
+	testutil.AssertError(t, instance.Forward(path, instance.Request{Action: instance.Show}))
+	server, err := instance.Listen(path)
+	testutil.AssertNoError(t, err)
+	_ = server.Close()
+}
+
+func TestListen_KeepsSocketPrivate(t *testing.T) {
+	if runtime.GOOS == "windows" {
+		t.Skip("unix permissions only")
+	}
+	path := filepath.Join(t.TempDir(), "run", "gh-stars.sock")
+	server, err := instance.Listen(path)
+	testutil.AssertNoError(t, err)
+	defer server.Close()
+
+	dir, err := os.Stat(filepath.Dir(path))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, fs.FileMode(0o700), dir.Mode().Perm())
+	socket, err := os.Stat(path)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, fs.FileMode(0o600), socket.Mode().Perm())
+
+	shared := filepath.Join(t.TempDir(), "shared")
+	testutil.AssertNoError(t, os.Mkdir(shared, 0o700))
+	testutil.AssertNoError(t, os.Chmod(shared, 0o755))
+	_, err = instance.Listen(filepath.Join(shared, "gh-stars.sock"))
+	testutil.AssertError(t, err)
+}
@@ -0,0 +1,18 @@
+//go:build !unix
+
+package instance
+
+import (
+	"io/fs"
+	"net"
+)
+
+// checkPrivate trusts the dir: without unix permissions, access to the
+// user's temp dir is left to its ACLs.
+func checkPrivate(dir string, info fs.FileInfo) error {
+	return nil
+}
+
+func listenUnix(path string) (net.Listener, error) {
+	return net.Listen("unix", path)
+}
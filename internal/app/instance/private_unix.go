@@ -0,0 +1,32 @@
+//go:build unix
+
+package instance
+
+import (
+	"fmt"
+	"io/fs"
+	"net"
+	"os"
+	"syscall"
+)
+
+func checkPrivate(dir string, info fs.FileInfo) error {
+	stat, ok := info.Sys().(*syscall.Stat_t)
+	if !ok || int(stat.Uid) != os.Getuid() {
+		return fmt.Errorf("%s is not owned by the current user", dir)
+	}
+	if info.Mode().Perm()&0o077 != 0 {
+		return fmt.Errorf("%s can be entered by other users", dir)
+	}
+	return nil
+}
+
+// listenUnix creates the socket under a umask that leaves it to the user
+// alone from the start, instead of narrowing it once others could connect.
+// The umask is process wide, so this must run before other goroutines
+// create files.
+func listenUnix(path string) (net.Listener, error) {
+	old := syscall.Umask(0o177)
+	defer syscall.Umask(old)
+	return net.Listen("unix", path)
+}
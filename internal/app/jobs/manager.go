@@ -0,0 +1,307 @@
+package jobs
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const defaultKeep = 50
+
+// State is where a job is in its life.
+type State int
+
+const (
+	Running State = iota
+	Succeeded
+	Failed
+	Canceled
+)
+
+func (s State) String() string {
+	switch s {
+	case Running:
+		return "Running"
+	case Succeeded:
+		return "Done"
+	case Failed:
+		return "Failed"
+	case Canceled:
+		return "Canceled"
+	}
+	return "Unknown"
+}
+
+// Job is a snapshot of a registered operation for display.
+type Job struct {
+	ID    int
+	Name  string
+	State State
+	// Done and Total count units of work; a Total of zero means the job
+	// cannot tell how far it has come.
+	Done   int
+	Total  int
+	Detail string
+	// Result describes how a job that is no longer running ended.
+	Result     string
+	Err        error
+	StartedAt  time.Time
+	FinishedAt time.Time
+}
+
+// Fraction returns Done over Total, or 0 when Total is unknown.
+func (j Job) Fraction() float64 {
+	if j.Total <= 0 {
+		return 0
+	}
+	return float64(j.Done) / float64(j.Total)
+}
+
+// Manager keeps track of the long-running operations of every window, such
+// as syncs, enrichment and team builds, so they can be watched and canceled
+// in one place. Finished jobs are kept until ClearFinished or until more than
+// Keep have piled up.
+//
+// A nil *Manager is valid: Register still returns a working Handle, it is
+// just not listed anywhere.
+type Manager struct {
+	// Keep bounds how many finished jobs are remembered; 0 means 50.
+	Keep int
+
+	mu        sync.Mutex
+	nextID    int
+	jobs      []*entry
+	nextSub   int
+	listeners map[int]func()
+}
+
+type entry struct {
+	job    Job
+	cancel context.CancelFunc
+}
+
+// Register adds a running job called name. cancel is called when the job is
+// canceled from the manager or through the returned Handle.
+func (m *Manager) Register(name string, cancel context.CancelFunc) *Handle {
+	if m == nil {
+		return &Handle{cancel: cancel}
+	}
+	m.mu.Lock()
+	m.nextID++
+	id := m.nextID
+	m.jobs = append(m.jobs, &entry{
+		job:    Job{ID: id, Name: name, State: Running, StartedAt: time.Now()},
+		cancel: cancel,
+	})
+	m.mu.Unlock()
+	m.notify()
+	return &Handle{m: m, id: id, cancel: cancel}
+}
+
+// List returns the jobs, newest first.
+func (m *Manager) List() []Job {
+	if m == nil {
+		return nil
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	jobs := make([]Job, len(m.jobs))
+	for i, e := range m.jobs {
+		jobs[len(m.jobs)-1-i] = e.job
+	}
+	return jobs
+}
+
+// Running returns the number of jobs still running.
+func (m *Manager) Running() int {
+	if m == nil {
+		return 0
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	n := 0
+	for _, e := range m.jobs {
+		if e.job.State == Running {
+			n++
+		}
+	}
+	return n
+}
+
+// Cancel stops the running job with id. It does nothing for unknown or
+// finished jobs.
+func (m *Manager) Cancel(id int) {
+	if m == nil {
+		return
+	}
+	m.finish(id, Canceled, "Canceled", nil, true)
+}
+
+// ClearFinished forgets every job that is no longer running.
+func (m *Manager) ClearFinished() {
+	if m == nil {
+		return
+	}
+	m.mu.Lock()
+	kept := m.jobs[:0]
+	for _, e := range m.jobs {
+		if e.job.State == Running {
+			kept = append(kept, e)
+		}
+	}
+	clear(m.jobs[len(kept):])
+	m.jobs = kept
+	m.mu.Unlock()
+	m.notify()
+}
+
+// Subscribe registers fn to be called, off the main thread, whenever a job
+// is added, reports progress or ends. The returned func removes it.
+func (m *Manager) Subscribe(fn func()) func() {
+	if m == nil {
+		return func() {}
+	}
+	m.mu.Lock()
+	if m.listeners == nil {
+		m.listeners = map[int]func(){}
+	}
+	id := m.nextSub
+	m.nextSub++
+	m.listeners[id] = fn
+	m.mu.Unlock()
+
+	return func() {
+		m.mu.Lock()
+		delete(m.listeners, id)
+		m.mu.Unlock()
+	}
+}
+
+func (m *Manager) progress(id, done, total int, detail string) {
+	m.mu.Lock()
+	e := m.find(id)
+	if e == nil || e.job.State != Running {
+		m.mu.Unlock()
+		return
+	}
+	e.job.Done, e.job.Total, e.job.Detail = done, total, detail
+	m.mu.Unlock()
+	m.notify()
+}
+
+// finish ends the job with id unless it already ended, so whichever of
+// Finish and Cancel comes first decides the outcome.
+func (m *Manager) finish(id int, state State, result string, err error, cancel bool) {
+	m.mu.Lock()
+	e := m.find(id)
+	if e == nil || e.job.State != Running {
+		m.mu.Unlock()
+		return
+	}
+	e.job.State = state
+	e.job.Result = result
+	e.job.Err = err
+	e.job.FinishedAt = time.Now()
+	stop := e.cancel
+	m.prune()
+	m.mu.Unlock()
+
+	if cancel && stop != nil {
+		stop()
+	}
+	m.notify()
+}
+
+func (m *Manager) find(id int) *entry {
+	for _, e := range m.jobs {
+		if e.job.ID == id {
+			return e
+		}
+	}
+	return nil
+}
+
+// prune drops the oldest finished jobs beyond Keep. m.mu must be held.
+func (m *Manager) prune() {
+	keep := m.Keep
+	if keep <= 0 {
+		keep = defaultKeep
+	}
+	finished := 0
+	for _, e := range m.jobs {
+		if e.job.State != Running {
+			finished++
+		}
+	}
+	if finished <= keep {
+		return
+	}
+	drop := finished - keep
+	kept := m.jobs[:0]
+	for _, e := range m.jobs {
+		if drop > 0 && e.job.State != Running {
+			drop--
+			continue
+		}
+		kept = append(kept, e)
+	}
+	clear(m.jobs[len(kept):])
+	m.jobs = kept
+}
+
+func (m *Manager) notify() {
+	m.mu.Lock()
+	listeners := make([]func(), 0, len(m.listeners))
+	for _, fn := range m.listeners {
+		listeners = append(listeners, fn)
+	}
+	m.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// Handle is what the owner of a job uses to report on it.
+type Handle struct {
+	m      *Manager
+	id     int
+	cancel context.CancelFunc
+}
+
+// Progress records how far the job has come.
+func (h *Handle) Progress(done, total int, detail string) {
+	if h.m != nil {
+		h.m.progress(h.id, done, total, detail)
+	}
+}
+
+// Finish ends the job with result, or as failed when err is set. An err from
+// a canceled context ends it as canceled. Finishing a job that was already
+// canceled does nothing.
+func (h *Handle) Finish(result string, err error) {
+	if h.m == nil {
+		return
+	}
+	switch {
+	case errors.Is(err, context.Canceled):
+		h.m.finish(h.id, Canceled, "Canceled", nil, false)
+	case err != nil:
+		h.m.finish(h.id, Failed, "", err, false)
+	default:
+		h.m.finish(h.id, Succeeded, result, nil, false)
+	}
+}
+
+// Cancel stops the job and deregisters it as canceled. It is safe to call
+// on a job that has already ended; the cancel func is called either way so
+// its context is released.
+func (h *Handle) Cancel() {
+	if h.m != nil {
+		h.m.finish(h.id, Canceled, "Canceled", nil, true)
+	}
+	if h.cancel != nil {
+		h.cancel()
+	}
+}
@@ -0,0 +1,159 @@
+package jobs_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestManager_RegisterAndFinish(t *testing.T) {
+	m := &jobs.Manager{}
+	_, cancel := testutil.WithCancel()
+	defer cancel()
+
+	load := m.Register("Sync stars", cancel)
+	enrich := m.Register("Enrich stars", cancel)
+	enrich.Progress(3, 10, "Enriched 3 of 10")
+	load.Finish("Loaded 42 repos", nil)
+
+	list := m.List()
+	testutil.AssertEqual(t, 2, len(list))
+	testutil.AssertEqual(t, "Enrich stars", list[0].Name)
+	testutil.AssertEqual(t, jobs.Running, list[0].State)
+	testutil.AssertEqual(t, 0.3, list[0].Fraction())
+	testutil.AssertEqual(t, "Enriched 3 of 10", list[0].Detail)
+	testutil.AssertEqual(t, jobs.Succeeded, list[1].State)
+	testutil.AssertEqual(t, "Loaded 42 repos", list[1].Result)
+	testutil.AssertEqual(t, 1, m.Running())
+}
+
+func TestManager_CancelCallsCancelFunc(t *testing.T) {
+	m := &jobs.Manager{}
+	ctx, cancel := testutil.WithCancel()
+
+	job := m.Register("Build team", cancel)
+	m.Cancel(m.List()[0].ID)
+
+	testutil.AssertContextCancelled(t, ctx)
+	testutil.AssertEqual(t, jobs.Canceled, m.List()[0].State)
+
+	// The owner sees ctx.Err and finishes; the cancel already decided.
+	job.Finish("", errors.New("late failure"))
+	testutil.AssertEqual(t, jobs.Canceled, m.List()[0].State)
+	testutil.AssertEqual(t, 0, m.Running())
+}
+
+func TestHandle_FinishWithCanceledContext(t *testing.T) {
+	m := &jobs.Manager{}
+	_, cancel := testutil.WithCancel()
+	defer cancel()
+
+	m.Register("Sync stars", cancel).Finish("", fmt.Errorf("load: %w", context.Canceled))
+	m.Register("Sync stars", cancel).Finish("", errors.New("rate limited"))
+
+	list := m.List()
+	testutil.AssertEqual(t, jobs.Failed, list[0].State)
+	testutil.AssertEqual(t, "rate limited", list[0].Err.Error())
+	testutil.AssertEqual(t, jobs.Canceled, list[1].State)
+}
+
+func TestManager_ClearFinishedKeepsRunning(t *testing.T) {
+	m := &jobs.Manager{}
+	_, cancel := testutil.WithCancel()
+	defer cancel()
+
+	m.Register("done", cancel).Finish("ok", nil)
+	m.Register("running", cancel)
+	m.Register("canceled", cancel).Cancel()
+	m.ClearFinished()
+
+	list := m.List()
+	testutil.AssertEqual(t, 1, len(list))
+	testutil.AssertEqual(t, "running", list[0].Name)
+}
+
+func TestManager_KeepBoundsFinishedJobs(t *testing.T) {
+	m := &jobs.Manager{Keep: 2}
+	_, cancel := testutil.WithCancel()
+	defer cancel()
+
+	m.Register("running", cancel)
+	for i := 0; i < 5; i++ {
+		m.Register(fmt.Sprintf("job %d", i), cancel).Finish("ok", nil)
+	}
+
+	list := m.List()
+	testutil.AssertEqual(t, 3, len(list))
+	testutil.AssertEqual(t, "job 4", list[0].Name)
+	testutil.AssertEqual(t, "job 3", list[1].Name)
+	testutil.AssertEqual(t, "running", list[2].Name)
+}
+
+func TestManager_NilManagerStillCancels(t *testing.T) {
+	var m *jobs.Manager
+	ctx, cancel := testutil.WithCancel()
+
+	job := m.Register("Sync stars", cancel)
+	job.Progress(1, 2, "")
+	job.Cancel()
+
+	testutil.AssertContextCancelled(t, ctx)
+	testutil.AssertEqual(t, 0, len(m.List()))
+}
+
+func TestManager_Subscribe(t *testing.T) {
+	m := &jobs.Manager{}
+	_, cancel := testutil.WithCancel()
+	defer cancel()
+
+	calls := 0
+	unsubscribe := m.Subscribe(func() { calls++ })
+	job := m.Register("Sync stars", cancel)
+	job.Progress(1, 2, "")
+	job.Finish("ok", nil)
+	unsubscribe()
+	m.ClearFinished()
+
+	testutil.AssertEqual(t, 3, calls)
+}
+
+func TestManager_ConcurrentJobs_NoRaceCondition(t *testing.T) {
+	m := &jobs.Manager{Keep: 5}
+	unsubscribe := m.Subscribe(func() { _ = m.List() })
+	defer unsubscribe()
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			ctx, cancel := context.WithCancel(context.Background())
+			job := m.Register(fmt.Sprintf("job %d", i), cancel)
+			for step := 1; step <= 10; step++ {
+				job.Progress(step, 10, "")
+			}
+			if i%2 == 0 {
+				job.Cancel()
+			} else {
+				job.Finish("ok", ctx.Err())
+			}
+		}(i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, job := range m.List() {
+				m.Cancel(job.ID)
+			}
+			_ = m.Running()
+		}()
+	}
+	wg.Wait()
+
+	testutil.AssertEqual(t, 0, m.Running())
+	testutil.AssertTrue(t, len(m.List()) <= 5, "finished jobs should be pruned to Keep")
+}
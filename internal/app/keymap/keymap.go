@@ -0,0 +1,269 @@
+package keymap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+const prefsPrefix = "keymap."
+
+// Action names something a shortcut can trigger.
+type Action string
+
+const (
+	FocusSearch    Action = "focus_search"
+	Reload         Action = "reload"
+	OpenSelected   Action = "open_selected"
+	OpenInBrowser  Action = "open_in_browser"
+	CopyURL        Action = "copy_url"
+	CommandPalette Action = "command_palette"
+)
+
+// Binding is an action, its display title and the keys that trigger it.
+type Binding struct {
+	Action Action
+	Title  string
+	Keys   string
+}
+
+// Defaults are the shipped bindings, in display order.
+var Defaults = []Binding{
+	{FocusSearch, "Focus Search", "Ctrl+F"},
+	{Reload, "Reload Stars", "Ctrl+R"},
+	{OpenSelected, "Open Selected Repo", "Enter"},
+	{OpenInBrowser, "Open in Browser", "Ctrl+O"},
+	{CopyURL, "Copy Repo URL", "Ctrl+C"},
+	{CommandPalette, "Command Palette", "Ctrl+K"},
+}
+
+// Shortcut is a parsed key combination. Key uses Fyne's key names, such as
+// "F", "Return" or "F5".
+type Shortcut struct {
+	Key   string
+	Ctrl  bool
+	Shift bool
+	Alt   bool
+	Super bool
+}
+
+// HasModifier reports whether the shortcut needs more than a single key.
+func (s Shortcut) HasModifier() bool {
+	return s.Ctrl || s.Shift || s.Alt || s.Super
+}
+
+func (s Shortcut) String() string {
+	var parts []string
+	if s.Ctrl {
+		parts = append(parts, "Ctrl")
+	}
+	if s.Alt {
+		parts = append(parts, "Alt")
+	}
+	if s.Shift {
+		parts = append(parts, "Shift")
+	}
+	if s.Super {
+		parts = append(parts, "Super")
+	}
+	key := s.Key
+	if key == "Return" {
+		key = "Enter"
+	}
+	return strings.Join(append(parts, key), "+")
+}
+
+// Parse reads a combination such as "Ctrl+Shift+K". Modifiers and key names
+// are case-insensitive; "Cmd" is accepted for Super and "Enter" for Return.
+func Parse(keys string) (Shortcut, error) {
+	var s Shortcut
+	parts := strings.Split(strings.TrimSpace(keys), "+")
+	for i, part := range parts {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			return Shortcut{}, fmt.Errorf("invalid shortcut %q", keys)
+		}
+		if i == len(parts)-1 {
+			s.Key = keyName(part)
+			break
+		}
+		switch strings.ToLower(part) {
+		case "ctrl", "control":
+			s.Ctrl = true
+		case "shift":
+			s.Shift = true
+		case "alt", "option":
+			s.Alt = true
+		case "super", "cmd", "command", "meta":
+			s.Super = true
+		default:
+			return Shortcut{}, fmt.Errorf("unknown modifier %q in %q", part, keys)
+		}
+	}
+	// Without Ctrl, Alt or Super a letter would be typed rather than run,
+	// and Fyne does not report Shift alone as a shortcut.
+	if !s.Ctrl && !s.Alt && !s.Super && (s.Shift || len([]rune(s.Key)) == 1) {
+		return Shortcut{}, fmt.Errorf("%q needs Ctrl, Alt or Super", keys)
+	}
+	return s, nil
+}
+
+func keyName(key string) string {
+	switch strings.ToLower(key) {
+	case "enter", "return":
+		return "Return"
+	case "esc", "escape":
+		return "Escape"
+	case "space":
+		return "Space"
+	case "tab":
+		return "Tab"
+	case "delete", "del":
+		return "Delete"
+	case "backspace":
+		return "BackSpace"
+	case "up", "down", "left", "right", "home", "end":
+		return strings.ToUpper(key[:1]) + strings.ToLower(key[1:])
+	}
+	if len(key) > 1 && (key[0] == 'f' || key[0] == 'F') {
+		return "F" + key[1:]
+	}
+	return strings.ToUpper(key)
+}
+
+// Prefs is the subset of fyne.Preferences the store needs.
+type Prefs interface {
+	String(key string) string
+	SetString(key, value string)
+}
+
+// Store keeps rebound shortcuts in the app preferences. A nil *Store serves
+// the defaults.
+type Store struct {
+	prefs Prefs
+
+	mu        sync.Mutex
+	nextID    int
+	listeners map[int]func()
+}
+
+func NewStore(prefs Prefs) *Store {
+	return &Store{prefs: prefs}
+}
+
+// Bindings returns every action with its current keys, in display order.
+func (s *Store) Bindings() []Binding {
+	bindings := make([]Binding, len(Defaults))
+	for i, b := range Defaults {
+		b.Keys = s.Keys(b.Action)
+		bindings[i] = b
+	}
+	return bindings
+}
+
+// Keys returns the keys bound to action.
+func (s *Store) Keys(action Action) string {
+	if s != nil {
+		s.mu.Lock()
+		keys := s.prefs.String(prefsPrefix + string(action))
+		s.mu.Unlock()
+		if keys != "" {
+			return keys
+		}
+	}
+	for _, b := range Defaults {
+		if b.Action == action {
+			return b.Keys
+		}
+	}
+	return ""
+}
+
+// Shortcut returns the parsed keys bound to action.
+func (s *Store) Shortcut(action Action) (Shortcut, error) {
+	return Parse(s.Keys(action))
+}
+
+// SetAll rebinds every action in keys at once, so two actions can swap
+// shortcuts. An empty value restores the default. Nothing is saved when a
+// combination does not parse or is bound twice.
+func (s *Store) SetAll(keys map[Action]string) error {
+	if s == nil {
+		return errors.New("shortcuts cannot be changed")
+	}
+	next := map[Action]string{}
+	seen := map[string]Action{}
+	for _, b := range s.Bindings() {
+		value, ok := keys[b.Action]
+		if !ok {
+			value = b.Keys
+		}
+		if strings.TrimSpace(value) == "" {
+			value = defaultKeys(b.Action)
+		}
+		shortcut, err := Parse(value)
+		if err != nil {
+			return err
+		}
+		if other, taken := seen[shortcut.String()]; taken {
+			return fmt.Errorf("%s is bound to both %s and %s", shortcut, title(other), b.Title)
+		}
+		seen[shortcut.String()] = b.Action
+		next[b.Action] = shortcut.String()
+	}
+
+	s.mu.Lock()
+	for action, value := range next {
+		if value == defaultKeys(action) {
+			value = ""
+		}
+		s.prefs.SetString(prefsPrefix+string(action), value)
+	}
+	listeners := make([]func(), 0, len(s.listeners))
+	for _, fn := range s.listeners {
+		listeners = append(listeners, fn)
+	}
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+	return nil
+}
+
+// Subscribe calls fn after every change to the bindings, so open windows can
+// reinstall their shortcuts. The returned func unsubscribes.
+func (s *Store) Subscribe(fn func()) func() {
+	if s == nil {
+		return func() {}
+	}
+	s.mu.Lock()
+	if s.listeners == nil {
+		s.listeners = map[int]func(){}
+	}
+	id := s.nextID
+	s.nextID++
+	s.listeners[id] = fn
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		delete(s.listeners, id)
+		s.mu.Unlock()
+	}
+}
+
+func defaultKeys(action Action) string {
+	var s *Store
+	return s.Keys(action)
+}
+
+func title(action Action) string {
+	for _, b := range Defaults {
+		if b.Action == action {
+			return b.Title
+		}
+	}
+	return string(action)
+}
@@ -0,0 +1,77 @@
+package keymap_test
+
+import (
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestParse(t *testing.T) {
+	s, err := keymap.Parse("ctrl+shift+k")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, keymap.Shortcut{Key: "K", Ctrl: true, Shift: true}, s)
+	testutil.AssertEqual(t, "Ctrl+Shift+K", s.String())
+
+	s, err = keymap.Parse("Enter")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "Return", s.Key)
+	testutil.AssertFalse(t, s.HasModifier(), "Enter has no modifier")
+	testutil.AssertEqual(t, "Enter", s.String())
+
+	s, err = keymap.Parse("Cmd+f5")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, keymap.Shortcut{Key: "F5", Super: true}, s)
+
+	_, err = keymap.Parse("Hyper+K")
+	testutil.AssertError(t, err)
+	_, err = keymap.Parse("Ctrl+")
+	testutil.AssertError(t, err)
+	_, err = keymap.Parse("K")
+	testutil.AssertError(t, err)
+	_, err = keymap.Parse("Shift+F5")
+	testutil.AssertError(t, err)
+}
+
+func TestStore_DefaultsAndNilStore(t *testing.T) {
+	var nilStore *keymap.Store
+	testutil.AssertEqual(t, "Ctrl+K", nilStore.Keys(keymap.CommandPalette))
+	testutil.AssertError(t, nilStore.SetAll(nil))
+
+	store := keymap.NewStore(testutil.NewPrefs())
+	bindings := store.Bindings()
+	testutil.AssertEqual(t, len(keymap.Defaults), len(bindings))
+	testutil.AssertEqual(t, "Ctrl+F", bindings[0].Keys)
+}
+
+func TestStore_SetAllRebindsAndSwaps(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	store := keymap.NewStore(prefs)
+	changes := 0
+	unsubscribe := store.Subscribe(func() { changes++ })
+
+	err := store.SetAll(map[keymap.Action]string{
+		keymap.Reload:      "ctrl+f",
+		keymap.FocusSearch: "Ctrl+R",
+	})
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "Ctrl+F", store.Keys(keymap.Reload))
+	testutil.AssertEqual(t, "Ctrl+R", store.Keys(keymap.FocusSearch))
+	testutil.AssertEqual(t, 1, changes)
+	unsubscribe()
+
+	testutil.AssertNoError(t, store.SetAll(map[keymap.Action]string{keymap.Reload: "", keymap.FocusSearch: ""}))
+	testutil.AssertEqual(t, "Ctrl+R", store.Keys(keymap.Reload))
+	testutil.AssertEqual(t, "", prefs.String("keymap.reload"))
+}
+
+func TestStore_SetAllRejectsConflicts(t *testing.T) {
+	store := keymap.NewStore(testutil.NewPrefs())
+
+	err := store.SetAll(map[keymap.Action]string{keymap.CopyURL: "Ctrl+K"})
+	testutil.AssertError(t, err)
+	testutil.AssertEqual(t, "Ctrl+C", store.Keys(keymap.CopyURL))
+
+	err = store.SetAll(map[keymap.Action]string{keymap.CopyURL: "Ctrl+Nope+C"})
+	testutil.AssertError(t, err)
+}
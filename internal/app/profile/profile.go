@@ -0,0 +1,161 @@
+package profile
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"sync"
+)
+
+const (
+	// DefaultHost is used for profiles that do not name a host.
+	DefaultHost = "github.com"
+
+	profilesKey = "profiles"
+	activeKey   = "profiles.active"
+)
+
+// Profile is a saved account. The token itself is never stored in the
+// profile; TokenRef names it in a Tokens store.
+type Profile struct {
+	Name     string `json:"name"`
+	Username string `json:"username"`
+	TokenRef string `json:"token_ref,omitempty"`
+	Host     string `json:"host,omitempty"`
+	PerPage  int    `json:"per_page,omitempty"`
+}
+
+// DefaultTokenRef is the reference a profile's token is saved under when it
+// does not set one.
+func (p Profile) DefaultTokenRef() string {
+	return strings.ToLower(strings.TrimSpace(p.Name)) + "@" + p.HostOrDefault()
+}
+
+func (p Profile) HostOrDefault() string {
+	if host := strings.TrimSpace(p.Host); host != "" {
+		return host
+	}
+	return DefaultHost
+}
+
+// APIBaseURL is the REST API root of a GitHub Enterprise host, or "" for
+// github.com, whose API root is the client's default.
+func APIBaseURL(host string) string {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" || host == DefaultHost {
+		return ""
+	}
+	return "https://" + host + "/api/v3"
+}
+
+// Prefs is the subset of fyne.Preferences the store needs.
+type Prefs interface {
+	String(key string) string
+	SetString(key, value string)
+}
+
+// Store keeps profiles as JSON in the app preferences.
+type Store struct {
+	prefs Prefs
+
+	mu sync.Mutex
+}
+
+func NewStore(prefs Prefs) *Store {
+	return &Store{prefs: prefs}
+}
+
+// List returns the saved profiles in the order they were created. Unreadable
+// preferences are treated as no profiles.
+func (s *Store) List() []Profile {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.read()
+}
+
+func (s *Store) Get(name string) (Profile, bool) {
+	for _, p := range s.List() {
+		if strings.EqualFold(p.Name, name) {
+			return p, true
+		}
+	}
+	return Profile{}, false
+}
+
+// Save adds p, replacing a saved profile with the same name.
+func (s *Store) Save(p Profile) error {
+	p.Name = strings.TrimSpace(p.Name)
+	if p.Name == "" {
+		return errors.New("profile name is required")
+	}
+	p.Host = strings.ToLower(strings.TrimSpace(p.Host))
+	if strings.ContainsAny(p.Host, "/: ") {
+		return errors.New("host must be a name like github.example.com")
+	}
+	if p.TokenRef == "" {
+		p.TokenRef = p.DefaultTokenRef()
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profiles := s.read()
+	replaced := false
+	for i, existing := range profiles {
+		if strings.EqualFold(existing.Name, p.Name) {
+			profiles[i] = p
+			replaced = true
+		}
+	}
+	if !replaced {
+		profiles = append(profiles, p)
+	}
+	return s.write(profiles)
+}
+
+// Delete removes the profile called name. Deleting the active profile clears
+// the active selection.
+func (s *Store) Delete(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	profiles := s.read()
+	kept := profiles[:0]
+	for _, p := range profiles {
+		if !strings.EqualFold(p.Name, name) {
+			kept = append(kept, p)
+		}
+	}
+	if strings.EqualFold(s.prefs.String(activeKey), name) {
+		s.prefs.SetString(activeKey, "")
+	}
+	return s.write(kept)
+}
+
+// Active returns the name of the profile selected last, or "".
+func (s *Store) Active() string {
+	return s.prefs.String(activeKey)
+}
+
+func (s *Store) SetActive(name string) {
+	s.prefs.SetString(activeKey, name)
+}
+
+func (s *Store) read() []Profile {
+	raw := s.prefs.String(profilesKey)
+	if raw == "" {
+		return nil
+	}
+	var profiles []Profile
+	if err := json.Unmarshal([]byte(raw), &profiles); err != nil {
+		return nil
+	}
+	return profiles
+}
+
+func (s *Store) write(profiles []Profile) error {
+	data, err := json.Marshal(profiles)
+	if err != nil {
+		return err
+	}
+	s.prefs.SetString(profilesKey, string(data))
+	return nil
+}
@@ -0,0 +1,62 @@
+package profile_test
+
+import (
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestStore_SaveListDelete(t *testing.T) {
+	store := profile.NewStore(testutil.NewPrefs())
+
+	testutil.AssertNoError(t, store.Save(profile.Profile{Name: "Work", Username: "alice", PerPage: 50}))
+	testutil.AssertNoError(t, store.Save(profile.Profile{Name: "Home", Username: "alice-personal", Host: "ghe.example.com"}))
+	testutil.AssertNoError(t, store.Save(profile.Profile{Name: "work", Username: "alice-corp"}))
+	testutil.AssertError(t, store.Save(profile.Profile{Name: "  "}))
+	testutil.AssertError(t, store.Save(profile.Profile{Name: "GHE", Host: "https://ghe.example.com"}))
+
+	profiles := store.List()
+	testutil.AssertEqual(t, 2, len(profiles))
+	testutil.AssertEqual(t, "alice-corp", profiles[0].Username)
+	testutil.AssertEqual(t, "work@github.com", profiles[0].TokenRef)
+	testutil.AssertEqual(t, "home@ghe.example.com", profiles[1].TokenRef)
+
+	store.SetActive("Home")
+	testutil.AssertNoError(t, store.Delete("HOME"))
+	testutil.AssertEqual(t, "", store.Active())
+	_, ok := store.Get("home")
+	testutil.AssertFalse(t, ok, "deleted profile should be gone")
+}
+
+func TestAPIBaseURL(t *testing.T) {
+	testutil.AssertEqual(t, "", profile.APIBaseURL(""))
+	testutil.AssertEqual(t, "", profile.APIBaseURL("GitHub.com"))
+	testutil.AssertEqual(t, "https://ghe.example.com/api/v3", profile.APIBaseURL("ghe.example.com"))
+}
+
+func TestStore_IgnoresCorruptPrefs(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	prefs.SetString("profiles", "{not json")
+	store := profile.NewStore(prefs)
+
+	testutil.AssertEqual(t, 0, len(store.List()))
+	testutil.AssertNoError(t, store.Save(profile.Profile{Name: "Work"}))
+	testutil.AssertEqual(t, 1, len(store.List()))
+}
+
+func TestMemoryTokens(t *testing.T) {
+	tokens := &profile.MemoryTokens{}
+
+	_, ok := tokens.Token("work@github.com")
+	testutil.AssertFalse(t, ok, "no token expected before SetToken")
+
+	testutil.AssertNoError(t, tokens.SetToken("work@github.com", "ghp_secret"))
+	token, ok := tokens.Token("work@github.com")
+	testutil.AssertTrue(t, ok, "token should be found")
+	testutil.AssertEqual(t, "ghp_secret", token)
+
+	testutil.AssertNoError(t, tokens.ForgetToken("work@github.com"))
+	_, ok = tokens.Token("work@github.com")
+	testutil.AssertFalse(t, ok, "forgotten token should be gone")
+}
@@ -0,0 +1,42 @@
+package profile
+
+import "sync"
+
+// Tokens resolves a profile's TokenRef to the token itself.
+type Tokens interface {
+	Token(ref string) (string, bool)
+	SetToken(ref, token string) error
+	ForgetToken(ref string) error
+}
+
+// MemoryTokens keeps tokens for the current session only.
+type MemoryTokens struct {
+	mu     sync.Mutex
+	tokens map[string]string
+}
+
+var _ Tokens = (*MemoryTokens)(nil)
+
+func (m *MemoryTokens) Token(ref string) (string, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	token, ok := m.tokens[ref]
+	return token, ok
+}
+
+func (m *MemoryTokens) SetToken(ref, token string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.tokens == nil {
+		m.tokens = map[string]string{}
+	}
+	m.tokens[ref] = token
+	return nil
+}
+
+func (m *MemoryTokens) ForgetToken(ref string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.tokens, ref)
+	return nil
+}
@@ -0,0 +1,57 @@
+package ratelimit
+
+import (
+	"context"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Gate holds background work back when the API budget runs low, so bulk jobs
+// never starve the requests a user triggers interactively.
+type Gate struct {
+	// Status reports the latest known budget, usually github.Client.RateLimit.
+	Status func() domain.RateLimit
+	// Reserve is the number of requests kept back for interactive use. It is
+	// capped at a tenth of the limit, so the 60 requests an hour allowed
+	// without a token are not all held back.
+	Reserve int
+	// Now returns the current time; tests override it.
+	Now func() time.Time
+}
+
+// Low reports whether the remaining budget is at or below the reserve.
+// An unknown budget is never considered low.
+func (g *Gate) Low() bool {
+	if g == nil || g.Status == nil {
+		return false
+	}
+	status := g.Status()
+	return status.Limit > 0 && status.Remaining <= g.reserve(status.Limit) && g.now().Before(status.Reset)
+}
+
+func (g *Gate) reserve(limit int) int {
+	return min(g.Reserve, limit/10)
+}
+
+// Wait blocks while the budget is low, until it resets or ctx is done.
+func (g *Gate) Wait(ctx context.Context) error {
+	for g.Low() {
+		delay := g.Status().Reset.Sub(g.now()) + time.Second
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	return ctx.Err()
+}
+
+func (g *Gate) now() time.Time {
+	if g.Now != nil {
+		return g.Now()
+	}
+	return time.Now()
+}
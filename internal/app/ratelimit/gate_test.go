@@ -0,0 +1,99 @@
+package ratelimit_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestGate_UnknownBudgetIsNotLow(t *testing.T) {
+	gate := &ratelimit.Gate{Status: func() domain.RateLimit { return domain.RateLimit{} }, Reserve: 100}
+
+	testutil.AssertFalse(t, gate.Low(), "unknown budget should not block")
+	testutil.AssertNoError(t, gate.Wait(context.Background()))
+}
+
+func TestGate_NilGateNeverBlocks(t *testing.T) {
+	var gate *ratelimit.Gate
+
+	testutil.AssertFalse(t, gate.Low(), "nil gate should not block")
+}
+
+func TestGate_LowBudgetWaitsForReset(t *testing.T) {
+	reset := time.Now().Add(time.Hour)
+	gate := &ratelimit.Gate{
+		Status:  func() domain.RateLimit { return domain.RateLimit{Limit: 5000, Remaining: 50, Reset: reset} },
+		Reserve: 100,
+	}
+	ctx, cancel := testutil.WithTimeout(t, 30*time.Millisecond)
+	defer cancel()
+
+	testutil.AssertTrue(t, gate.Low(), "budget below reserve should be low")
+	err := gate.Wait(ctx)
+	testutil.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "wait should block until ctx is done")
+}
+
+func TestGate_ReserveScalesWithAnonymousLimit(t *testing.T) {
+	remaining := 30
+	gate := &ratelimit.Gate{
+		Status: func() domain.RateLimit {
+			return domain.RateLimit{Limit: 60, Remaining: remaining, Reset: time.Now().Add(time.Hour)}
+		},
+		Reserve: 100,
+	}
+
+	testutil.AssertFalse(t, gate.Low(), "half the anonymous budget should not be low")
+	remaining = 6
+	testutil.AssertTrue(t, gate.Low(), "a tenth of the anonymous budget should be low")
+}
+
+func TestGate_ExpiredResetIsNotLow(t *testing.T) {
+	reset := time.Now().Add(-time.Minute)
+	gate := &ratelimit.Gate{
+		Status:  func() domain.RateLimit { return domain.RateLimit{Limit: 60, Remaining: 0, Reset: reset} },
+		Reserve: 10,
+	}
+
+	testutil.AssertFalse(t, gate.Low(), "budget should count as refilled after reset")
+}
+
+func TestForEach_VisitsEveryItem(t *testing.T) {
+	var (
+		mu   sync.Mutex
+		seen []string
+	)
+	items := []string{"a", "b", "c", "d", "e"}
+
+	err := ratelimit.ForEach(context.Background(), nil, 2, items, func(ctx context.Context, item string) {
+		mu.Lock()
+		seen = append(seen, item)
+		mu.Unlock()
+	})
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, len(items), len(seen))
+}
+
+func TestForEach_StopsWhileGateIsLow(t *testing.T) {
+	gate := &ratelimit.Gate{
+		Status: func() domain.RateLimit {
+			return domain.RateLimit{Limit: 60, Remaining: 0, Reset: time.Now().Add(time.Hour)}
+		},
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	calls := 0
+
+	err := ratelimit.ForEach(ctx, gate, 1, []string{"a", "b"}, func(ctx context.Context, item string) {
+		calls++
+	})
+
+	testutil.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "should stop when ctx expires")
+	testutil.AssertEqual(t, 0, calls)
+}
@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+)
+
+// DefaultWorkers is the pool size used when a caller passes zero.
+const DefaultWorkers = 4
+
+// ForEach calls fn for every item through a bounded worker pool, waiting on
+// the gate before each call so background jobs sharing one gate back off
+// together. It stops handing out items once ctx is done and returns ctx.Err().
+// fn is called concurrently and must do its own locking.
+func ForEach(ctx context.Context, gate *Gate, workers int, items []string, fn func(ctx context.Context, item string)) error {
+	if workers <= 0 {
+		workers = DefaultWorkers
+	}
+
+	var (
+		wg    sync.WaitGroup
+		queue = make(chan string)
+	)
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for item := range queue {
+				if err := gate.Wait(ctx); err != nil {
+					return
+				}
+				fn(ctx, item)
+			}
+		}()
+	}
+
+feed:
+	for _, item := range items {
+		select {
+		case queue <- item:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+	return ctx.Err()
+}
@@ -0,0 +1,61 @@
+package recent
+
+import (
+	"strings"
+	"sync"
+)
+
+const (
+	// Limit is how many repos are remembered.
+	Limit = 10
+
+	reposKey = "recent.repos"
+)
+
+// Prefs is the subset of fyne.Preferences the store needs.
+type Prefs interface {
+	StringList(key string) []string
+	SetStringList(key string, value []string)
+}
+
+// Store remembers the repos opened last, most recent first, in the app
+// preferences.
+type Store struct {
+	prefs Prefs
+
+	mu sync.Mutex
+}
+
+func NewStore(prefs Prefs) *Store {
+	return &Store{prefs: prefs}
+}
+
+// Add moves fullName to the front, dropping the oldest entry beyond Limit.
+func (s *Store) Add(fullName string) {
+	fullName = strings.TrimSpace(fullName)
+	if fullName == "" {
+		return
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	repos := []string{fullName}
+	for _, existing := range s.prefs.StringList(reposKey) {
+		if !strings.EqualFold(existing, fullName) && len(repos) < Limit {
+			repos = append(repos, existing)
+		}
+	}
+	s.prefs.SetStringList(reposKey, repos)
+}
+
+// List returns the remembered repos, most recent first.
+func (s *Store) List() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.prefs.StringList(reposKey)
+}
+
+func (s *Store) Clear() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.prefs.SetStringList(reposKey, nil)
+}
@@ -0,0 +1,38 @@
+package recent_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/recent"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestStore_AddMovesToFront(t *testing.T) {
+	store := recent.NewStore(testutil.NewPrefs())
+
+	store.Add("golang/go")
+	store.Add("fyne-io/fyne")
+	store.Add("GOLANG/go")
+	store.Add("  ")
+
+	list := store.List()
+	testutil.AssertEqual(t, 2, len(list))
+	testutil.AssertEqual(t, "GOLANG/go", list[0])
+	testutil.AssertEqual(t, "fyne-io/fyne", list[1])
+}
+
+func TestStore_KeepsLimit(t *testing.T) {
+	store := recent.NewStore(testutil.NewPrefs())
+
+	for i := 0; i < recent.Limit+5; i++ {
+		store.Add(fmt.Sprintf("owner/repo%d", i))
+	}
+
+	list := store.List()
+	testutil.AssertEqual(t, recent.Limit, len(list))
+	testutil.AssertEqual(t, fmt.Sprintf("owner/repo%d", recent.Limit+4), list[0])
+
+	store.Clear()
+	testutil.AssertEqual(t, 0, len(store.List()))
+}
@@ -0,0 +1,201 @@
+package releases
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+const defaultInterval = time.Hour
+
+// Status describes the poller for display.
+type Status struct {
+	Checking bool
+	Last     Result
+	LastAt   time.Time
+	Err      error
+}
+
+// Poller re-runs a Watcher in the background against the most recently
+// synced catalog. While checks are enabled in the watcher's store it starts
+// with the first Track call and runs until Stop.
+type Poller struct {
+	Watcher  *Watcher
+	Interval time.Duration
+
+	mu        sync.Mutex
+	catalog   []domain.Repo
+	token     string
+	tracked   bool
+	cancel    context.CancelFunc
+	wake      chan struct{}
+	status    Status
+	nextID    int
+	listeners map[int]func()
+}
+
+// Track replaces the catalog to watch and starts polling if checks are
+// enabled.
+func (p *Poller) Track(catalog []domain.Repo, token string) {
+	p.mu.Lock()
+	p.catalog = make([]domain.Repo, len(catalog))
+	copy(p.catalog, catalog)
+	p.token = token
+	p.tracked = true
+	p.mu.Unlock()
+
+	if p.Watcher.Store.Enabled() {
+		p.start()
+	}
+}
+
+// SetEnabled turns background checks on or off and saves the choice. Once
+// on, polling starts right away if a catalog was already synced.
+func (p *Poller) SetEnabled(enabled bool) error {
+	store := p.Watcher.Store
+	store.SetEnabled(enabled)
+	err := store.Save()
+	if enabled {
+		p.start()
+	} else {
+		p.Stop()
+	}
+	return err
+}
+
+func (p *Poller) start() {
+	p.mu.Lock()
+	if p.cancel != nil || !p.tracked {
+		p.mu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.wake = make(chan struct{}, 1)
+	p.mu.Unlock()
+
+	go p.loop(ctx)
+}
+
+// CheckNow starts a pass right away instead of waiting for the interval.
+// It does nothing while polling is not running.
+func (p *Poller) CheckNow() {
+	p.mu.Lock()
+	wake := p.wake
+	p.mu.Unlock()
+	if wake == nil {
+		return
+	}
+	select {
+	case wake <- struct{}{}:
+	default:
+	}
+}
+
+// Stop ends polling; a later Track or SetEnabled starts it again.
+func (p *Poller) Stop() {
+	p.mu.Lock()
+	if p.cancel != nil {
+		p.cancel()
+		p.cancel = nil
+		p.wake = nil
+	}
+	p.mu.Unlock()
+}
+
+func (p *Poller) Status() Status {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.status
+}
+
+// Subscribe registers fn to be called, off the main thread, whenever a pass
+// starts or finishes. The returned func removes it.
+func (p *Poller) Subscribe(fn func()) func() {
+	p.mu.Lock()
+	if p.listeners == nil {
+		p.listeners = map[int]func(){}
+	}
+	id := p.nextID
+	p.nextID++
+	p.listeners[id] = fn
+	p.mu.Unlock()
+
+	return func() {
+		p.mu.Lock()
+		delete(p.listeners, id)
+		p.mu.Unlock()
+	}
+}
+
+func (p *Poller) loop(ctx context.Context) {
+	p.mu.Lock()
+	wake := p.wake
+	p.mu.Unlock()
+
+	for {
+		p.runOnce(ctx)
+
+		interval := p.Interval
+		if interval <= 0 {
+			interval = defaultInterval
+		}
+		timer := time.NewTimer(interval)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-wake:
+			timer.Stop()
+		case <-timer.C:
+		}
+	}
+}
+
+func (p *Poller) runOnce(ctx context.Context) {
+	p.mu.Lock()
+	catalog, token := p.catalog, p.token
+	p.status.Checking = true
+	p.mu.Unlock()
+	p.notify()
+
+	result, err := p.Watcher.Check(ctx, catalog, token)
+
+	p.mu.Lock()
+	p.status = Status{Last: result, LastAt: time.Now(), Err: err}
+	p.mu.Unlock()
+	p.notify()
+}
+
+func (p *Poller) notify() {
+	p.mu.Lock()
+	listeners := make([]func(), 0, len(p.listeners))
+	for _, fn := range p.listeners {
+		listeners = append(listeners, fn)
+	}
+	p.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn()
+	}
+}
+
+// Tracker decorates a stars.Loader and hands every successfully synced
+// catalog to the poller.
+type Tracker struct {
+	Next   stars.Loader
+	Poller *Poller
+}
+
+var _ stars.Loader = (*Tracker)(nil)
+
+func (t *Tracker) LoadStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+	repos, err := t.Next.LoadStarred(ctx, username, token, perPage)
+	if err == nil {
+		t.Poller.Track(repos, token)
+	}
+	return repos, err
+}
@@ -0,0 +1,223 @@
+package releases_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/releases"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+// fakeGitHub serves /releases/latest and /tags for a few repos and honours
+// If-None-Match, counting the requests it answered with 304.
+type fakeGitHub struct {
+	mu          sync.Mutex
+	releases    map[string]string
+	tags        map[string]string
+	notModified int
+	requests    int
+}
+
+func newFakeGitHub(t *testing.T) (*fakeGitHub, *github.HTTPClient) {
+	t.Helper()
+	fake := &fakeGitHub{releases: map[string]string{}, tags: map[string]string{}}
+	srv := httptest.NewServer(fake)
+	t.Cleanup(srv.Close)
+
+	client := github.NewClient(srv.Client())
+	client.SetBaseURL(srv.URL)
+	return fake, client
+}
+
+func (f *fakeGitHub) set(kind map[string]string, fullName, tag string) {
+	f.mu.Lock()
+	kind[fullName] = tag
+	f.mu.Unlock()
+}
+
+func (f *fakeGitHub) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.requests++
+
+	path := strings.TrimPrefix(r.URL.Path, "/repos/")
+	var (
+		fullName string
+		body     any
+		tag      string
+	)
+	switch {
+	case strings.HasSuffix(path, "/releases/latest"):
+		fullName = strings.TrimSuffix(path, "/releases/latest")
+		tag = f.releases[fullName]
+		body = map[string]string{"tag_name": tag, "body": "Notes for " + tag, "published_at": "2024-03-01T00:00:00Z"}
+	case strings.HasSuffix(path, "/tags"):
+		fullName = strings.TrimSuffix(path, "/tags")
+		tag = f.tags[fullName]
+		body = []map[string]string{{"name": tag}}
+	}
+	if tag == "" {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+		return
+	}
+
+	etag := fmt.Sprintf(`"%s@%s"`, r.URL.Path, tag)
+	if r.Header.Get("If-None-Match") == etag {
+		f.notModified++
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+	w.Header().Set("ETag", etag)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func catalog(names ...string) []domain.Repo {
+	repos := make([]domain.Repo, len(names))
+	for i, name := range names {
+		repos[i] = domain.Repo{FullName: name}
+	}
+	return repos
+}
+
+func TestWatcher_BaselineThenNewRelease(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.set(fake.releases, "golang/go", "go1.22.0")
+	watcher := &releases.Watcher{GH: client, Store: releases.NewStore("")}
+	repos := catalog("golang/go")
+
+	result, err := watcher.Check(context.Background(), repos, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, result.New)
+	testutil.AssertEqual(t, 0, len(watcher.Store.Updates()))
+
+	_, err = watcher.Check(context.Background(), repos, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, fake.notModified)
+
+	fake.set(fake.releases, "golang/go", "go1.22.1")
+	result, err = watcher.Check(context.Background(), repos, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, result.New)
+
+	updates := watcher.Store.Updates()
+	testutil.AssertEqual(t, 1, len(updates))
+	testutil.AssertEqual(t, "go1.22.1", updates[0].Release.Tag)
+	testutil.AssertEqual(t, "go1.22.0", updates[0].Previous)
+	testutil.AssertEqual(t, "Notes for go1.22.1", updates[0].Release.Notes)
+
+	watcher.Store.MarkSeen("GOLANG/go")
+	testutil.AssertEqual(t, 0, len(watcher.Store.Updates()))
+}
+
+func TestWatcher_FallsBackToTags(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.set(fake.tags, "user/tags-only", "v0.1.0")
+	watcher := &releases.Watcher{GH: client, Store: releases.NewStore("")}
+	repos := catalog("user/tags-only")
+
+	_, err := watcher.Check(context.Background(), repos, "")
+	testutil.AssertNoError(t, err)
+	entry, ok := watcher.Store.Get("user/tags-only")
+	testutil.AssertTrue(t, ok, "entry should be recorded")
+	testutil.AssertTrue(t, entry.UsesTags, "repo without releases should use tags")
+
+	before := fake.requests
+	_, err = watcher.Check(context.Background(), repos, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, before+1, fake.requests)
+
+	fake.set(fake.tags, "user/tags-only", "v0.2.0")
+	result, err := watcher.Check(context.Background(), repos, "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, result.New)
+
+	updates := watcher.Store.Updates()
+	testutil.AssertEqual(t, 1, len(updates))
+	testutil.AssertEqual(t, "v0.2.0", updates[0].Release.Tag)
+	testutil.AssertTrue(t, updates[0].Release.FromTag, "update should come from a tag")
+}
+
+func TestWatcher_OnlyChecksSubset(t *testing.T) {
+	fake, client := newFakeGitHub(t)
+	fake.set(fake.releases, "golang/go", "go1.22.0")
+	fake.set(fake.releases, "microsoft/vscode", "1.87.0")
+	store := releases.NewStore("")
+	store.SetSubset([]string{"microsoft/vscode", " "})
+	watcher := &releases.Watcher{GH: client, Store: store}
+
+	result, err := watcher.Check(context.Background(), catalog("golang/go", "microsoft/vscode"), "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, result.Checked)
+	_, ok := store.Get("golang/go")
+	testutil.AssertFalse(t, ok, "repo outside the subset should not be checked")
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "releases.json")
+	store := releases.NewStore(path)
+	store.Put(releases.Entry{FullName: "golang/go", Latest: domain.Release{Tag: "go1.22.1"}, Seen: "go1.22.0"})
+	store.SetSubset([]string{"golang/go"})
+	store.SetEnabled(true)
+	testutil.AssertNoError(t, store.Save())
+
+	reloaded := releases.NewStore(path)
+	testutil.AssertNoError(t, reloaded.Load())
+
+	testutil.AssertEqual(t, 1, len(reloaded.Updates()))
+	testutil.AssertEqual(t, 1, len(reloaded.Subset()))
+	testutil.AssertTrue(t, reloaded.Enabled(), "enabled should be saved")
+}
+
+func TestTracker_StartsPollingOnceEnabled(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.GetLatestReleaseFunc = func(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+		testutil.AssertEqual(t, "token123", token)
+		return domain.Release{Tag: "v1"}, `"e"`, nil
+	}
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	poller := &releases.Poller{
+		Watcher:  &releases.Watcher{GH: mockClient, Store: releases.NewStore("")},
+		Interval: time.Hour,
+	}
+	defer poller.Stop()
+	done := make(chan struct{}, 1)
+	unsubscribe := poller.Subscribe(func() {
+		if !poller.Status().Checking {
+			done <- struct{}{}
+		}
+	})
+	defer unsubscribe()
+	tracker := &releases.Tracker{Next: mockSvc, Poller: poller}
+
+	_, err := tracker.LoadStarred(context.Background(), "testuser", "token123", 100)
+	testutil.AssertNoError(t, err)
+	poller.CheckNow()
+	testutil.AssertTrue(t, poller.Status().LastAt.IsZero(), "checks should wait for the user to enable them")
+
+	testutil.AssertNoError(t, poller.SetEnabled(true))
+	select {
+	case <-done:
+	case <-time.After(2 * time.Second):
+		t.Fatal("poller did not finish a pass")
+	}
+	testutil.AssertEqual(t, 3, poller.Status().Last.Checked)
+	testutil.AssertEqual(t, 3, mockClient.GetLatestReleaseCount())
+	testutil.AssertTrue(t, poller.Watcher.Store.Enabled(), "the choice should be kept")
+}
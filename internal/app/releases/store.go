@@ -0,0 +1,209 @@
+package releases
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Entry is what the watcher knows about one repo. The ETags let the next
+// check use conditional requests, which do not count against the rate limit
+// when nothing changed.
+type Entry struct {
+	FullName    string         `json:"full_name"`
+	ReleaseETag string         `json:"release_etag,omitempty"`
+	TagETag     string         `json:"tag_etag,omitempty"`
+	UsesTags    bool           `json:"uses_tags,omitempty"`
+	Latest      domain.Release `json:"latest"`
+	// Seen is the tag the user last acknowledged.
+	Seen      string    `json:"seen"`
+	CheckedAt time.Time `json:"checked_at"`
+}
+
+// Update is a version the user has not acknowledged yet.
+type Update struct {
+	FullName string
+	Release  domain.Release
+	Previous string
+}
+
+type storeFile struct {
+	Repos   map[string]Entry `json:"repos"`
+	Subset  []string         `json:"subset,omitempty"`
+	Enabled bool             `json:"enabled,omitempty"`
+}
+
+// Store keeps watcher state, the optional watch subset and whether background
+// checks are turned on as a single JSON file.
+type Store struct {
+	path string
+
+	mu      sync.RWMutex
+	entries map[string]Entry
+	subset  []string
+	enabled bool
+}
+
+// NewStore creates a store saved to path. With an empty path every check
+// starts without ETags and acknowledged versions are forgotten on exit.
+func NewStore(path string) *Store {
+	return &Store{path: path, entries: map[string]Entry{}}
+}
+
+// Load reads previously saved state. A missing file is not an error.
+func (s *Store) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	var file storeFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return err
+	}
+	if file.Repos == nil {
+		file.Repos = map[string]Entry{}
+	}
+
+	s.mu.Lock()
+	s.entries = file.Repos
+	s.subset = file.Subset
+	s.enabled = file.Enabled
+	s.mu.Unlock()
+	return nil
+}
+
+// Save persists the entries with their ETags, the watch subset and whether
+// checks are enabled.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return filestore.WriteJSON(s.path, storeFile{Repos: s.entries, Subset: s.subset, Enabled: s.enabled}, 0o600)
+}
+
+func (s *Store) Get(fullName string) (Entry, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	entry, ok := s.entries[storeKey(fullName)]
+	return entry, ok
+}
+
+func (s *Store) Put(entry Entry) {
+	s.mu.Lock()
+	s.entries[storeKey(entry.FullName)] = entry
+	s.mu.Unlock()
+}
+
+// Updates lists unacknowledged versions, newest first.
+func (s *Store) Updates() []Update {
+	s.mu.RLock()
+	var updates []Update
+	for _, entry := range s.entries {
+		if entry.Latest.Tag == "" || entry.Latest.Tag == entry.Seen {
+			continue
+		}
+		updates = append(updates, Update{FullName: entry.FullName, Release: entry.Latest, Previous: entry.Seen})
+	}
+	s.mu.RUnlock()
+
+	sort.Slice(updates, func(i, j int) bool {
+		a, b := updates[i].Release.PublishedAt, updates[j].Release.PublishedAt
+		if !a.Equal(b) {
+			return a.After(b)
+		}
+		return updates[i].FullName < updates[j].FullName
+	})
+	return updates
+}
+
+// MarkSeen acknowledges the latest version of fullName.
+func (s *Store) MarkSeen(fullName string) {
+	key := storeKey(fullName)
+	s.mu.Lock()
+	if entry, ok := s.entries[key]; ok {
+		entry.Seen = entry.Latest.Tag
+		s.entries[key] = entry
+	}
+	s.mu.Unlock()
+}
+
+// MarkAllSeen acknowledges every pending update.
+func (s *Store) MarkAllSeen() {
+	s.mu.Lock()
+	for key, entry := range s.entries {
+		entry.Seen = entry.Latest.Tag
+		s.entries[key] = entry
+	}
+	s.mu.Unlock()
+}
+
+// Subset returns the repos to watch; empty means every starred repo.
+func (s *Store) Subset() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]string, len(s.subset))
+	copy(out, s.subset)
+	return out
+}
+
+func (s *Store) SetSubset(fullNames []string) {
+	var subset []string
+	for _, name := range fullNames {
+		if name = strings.TrimSpace(name); name != "" {
+			subset = append(subset, name)
+		}
+	}
+	s.mu.Lock()
+	s.subset = subset
+	s.mu.Unlock()
+}
+
+// Enabled reports whether the user turned on background checks. They are
+// off until then, as each pass spends rate limit on every watched repo.
+func (s *Store) Enabled() bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.enabled
+}
+
+func (s *Store) SetEnabled(enabled bool) {
+	s.mu.Lock()
+	s.enabled = enabled
+	s.mu.Unlock()
+}
+
+// Watched reports whether fullName is covered by the subset.
+func (s *Store) Watched(fullName string) bool {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if len(s.subset) == 0 {
+		return true
+	}
+	key := storeKey(fullName)
+	for _, name := range s.subset {
+		if storeKey(name) == key {
+			return true
+		}
+	}
+	return false
+}
+
+func storeKey(fullName string) string {
+	return strings.ToLower(strings.TrimSpace(fullName))
+}
@@ -0,0 +1,133 @@
+package releases
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/github"
+)
+
+// Result summarizes one pass over the catalog.
+type Result struct {
+	Checked int
+	New     int
+	Failed  int
+}
+
+// Watcher checks the latest release of each watched repo, falling back to
+// tags for repos that never publish releases. The first sighting of a repo
+// only records a baseline, so an initial check does not flood the inbox.
+type Watcher struct {
+	GH      github.Client
+	Store   *Store
+	Gate    *ratelimit.Gate
+	Workers int
+	// Now returns the current time; tests override it.
+	Now func() time.Time
+}
+
+// Check runs one pass over catalog. Individual failures are counted and
+// skipped; only cancellation stops the pass early.
+func (w *Watcher) Check(ctx context.Context, catalog []domain.Repo, token string) (Result, error) {
+	var pending []string
+	for _, repo := range catalog {
+		if w.Store.Watched(repo.FullName) {
+			pending = append(pending, repo.FullName)
+		}
+	}
+
+	var (
+		mu     sync.Mutex
+		result Result
+	)
+	runErr := ratelimit.ForEach(ctx, w.Gate, w.Workers, pending, func(ctx context.Context, fullName string) {
+		isNew, err := w.checkRepo(ctx, fullName, token)
+		if ctx.Err() != nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		result.Checked++
+		switch {
+		case err != nil:
+			result.Failed++
+		case isNew:
+			result.New++
+		}
+	})
+
+	if err := w.Store.Save(); err != nil {
+		return result, err
+	}
+	return result, runErr
+}
+
+// checkRepo refreshes the entry for fullName and reports whether it now has
+// a version the user has not seen before.
+func (w *Watcher) checkRepo(ctx context.Context, fullName, token string) (bool, error) {
+	entry, known := w.Store.Get(fullName)
+	entry.FullName = fullName
+	previous := entry.Latest.Tag
+	latest := entry.Latest
+
+	if !entry.UsesTags {
+		release, etag, err := w.GH.GetLatestRelease(ctx, fullName, token, entry.ReleaseETag)
+		switch {
+		case errors.Is(err, github.ErrNotModified):
+		case errors.Is(err, github.ErrNotFound):
+			entry.UsesTags = true
+			entry.ReleaseETag = ""
+		case err != nil:
+			return false, err
+		default:
+			entry.ReleaseETag = etag
+			latest = release
+		}
+	}
+
+	if entry.UsesTags {
+		tag, etag, err := w.GH.GetLatestTag(ctx, fullName, token, entry.TagETag)
+		switch {
+		case errors.Is(err, github.ErrNotModified):
+		case errors.Is(err, github.ErrNotFound):
+			entry.TagETag = etag
+		case err != nil:
+			return false, err
+		default:
+			entry.TagETag = etag
+			if tag.Tag != previous {
+				// A new tag may come with the repo's first real release, which
+				// has notes worth showing.
+				if release, etag, err := w.GH.GetLatestRelease(ctx, fullName, token, ""); err == nil {
+					entry.UsesTags = false
+					entry.ReleaseETag = etag
+					tag = release
+				}
+				if tag.PublishedAt.IsZero() {
+					tag.PublishedAt = w.now()
+				}
+				latest = tag
+			}
+		}
+	}
+
+	entry.Latest = latest
+	entry.CheckedAt = w.now()
+	if !known {
+		entry.Seen = latest.Tag
+	}
+	w.Store.Put(entry)
+	return latest.Tag != previous && latest.Tag != entry.Seen, nil
+}
+
+func (w *Watcher) now() time.Time {
+	if w.Now != nil {
+		return w.Now()
+	}
+	return time.Now()
+}
@@ -0,0 +1,244 @@
+package repos
+
+import (
+	"container/list"
+	"context"
+	"encoding/json"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/flight"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// DefaultMaxEntries bounds each tier of a CachedLoader unless MaxEntries is
+// changed.
+const DefaultMaxEntries = 2000
+
+// Cache is implemented by loaders that keep previously fetched details, so a
+// view can show them instantly and revalidate in the background. Entries are
+// per token, so details one token could see are never shown for another.
+type Cache interface {
+	Cached(fullName, token string) (details domain.RepoDetails, fetchedAt time.Time, ok bool)
+	Revalidate(ctx context.Context, fullName, token string) (domain.RepoDetails, error)
+}
+
+// CachedLoader decorates a Loader with a memory tier and an optional disk tier.
+// Entries younger than the TTL are served without a request; older entries are
+// still returned by Cached so they can be shown while LoadDetails refetches.
+// Private repos only ever live in memory.
+type CachedLoader struct {
+	// Now returns the current time; tests override it to age entries.
+	Now func() time.Time
+	// MaxEntries bounds the memory tier and the disk tier separately; the
+	// entries fetched longest ago are evicted first.
+	MaxEntries int
+
+	next Loader
+	dir  string
+
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+
+	// diskMu serializes disk writes. files lists the keys on disk, least
+	// recently written first, and is read from dir once, on the first write.
+	diskMu sync.Mutex
+	files  *list.List
+	onDisk map[string]*list.Element
+}
+
+type cacheEntry struct {
+	Details   domain.RepoDetails `json:"details"`
+	FetchedAt time.Time          `json:"fetched_at"`
+}
+
+var (
+	_ Loader = (*CachedLoader)(nil)
+	_ Cache  = (*CachedLoader)(nil)
+)
+
+// NewCachedLoader wraps next with a cache. An empty dir keeps the cache in
+// memory only.
+func NewCachedLoader(next Loader, dir string, ttl time.Duration) *CachedLoader {
+	return &CachedLoader{
+		Now:        time.Now,
+		MaxEntries: DefaultMaxEntries,
+		next:       next,
+		dir:        dir,
+		ttl:        ttl,
+		entries:    map[string]cacheEntry{},
+	}
+}
+
+// SetTTL changes how long entries are served without a request.
+func (c *CachedLoader) SetTTL(ttl time.Duration) {
+	c.mu.Lock()
+	c.ttl = ttl
+	c.mu.Unlock()
+}
+
+func (c *CachedLoader) LoadDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+	c.mu.Lock()
+	ttl := c.ttl
+	c.mu.Unlock()
+	if entry, ok := c.lookup(cacheKey(fullName, token)); ok && c.Now().Sub(entry.FetchedAt) < ttl {
+		return entry.Details, nil
+	}
+	return c.Revalidate(ctx, fullName, token)
+}
+
+func (c *CachedLoader) LoadActivity(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+	return c.next.LoadActivity(ctx, fullName, token)
+}
+
+// Revalidate fetches fresh details regardless of the TTL and stores them.
+// Failed requests leave the existing entry untouched.
+func (c *CachedLoader) Revalidate(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+	details, err := c.next.LoadDetails(ctx, fullName, token)
+	if err != nil {
+		return domain.RepoDetails{}, err
+	}
+	c.store(cacheKey(fullName, token), cacheEntry{Details: details, FetchedAt: c.Now()})
+	return details, nil
+}
+
+// Cached returns the entry stored for fullName and token, however old it is.
+func (c *CachedLoader) Cached(fullName, token string) (domain.RepoDetails, time.Time, bool) {
+	entry, ok := c.lookup(cacheKey(fullName, token))
+	return entry.Details, entry.FetchedAt, ok
+}
+
+// Clear drops every entry, from memory and disk.
+func (c *CachedLoader) Clear() error {
+	c.mu.Lock()
+	c.entries = map[string]cacheEntry{}
+	c.mu.Unlock()
+	if c.dir == "" {
+		return nil
+	}
+	c.diskMu.Lock()
+	defer c.diskMu.Unlock()
+	c.files, c.onDisk = nil, nil
+	return os.RemoveAll(c.dir)
+}
+
+func (c *CachedLoader) lookup(key string) (cacheEntry, bool) {
+	c.mu.Lock()
+	entry, ok := c.entries[key]
+	c.mu.Unlock()
+	if ok || c.dir == "" {
+		return entry, ok
+	}
+
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return cacheEntry{}, false
+	}
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return cacheEntry{}, false
+	}
+
+	c.remember(key, entry)
+	return entry, true
+}
+
+func (c *CachedLoader) store(key string, entry cacheEntry) {
+	c.remember(key, entry)
+
+	if c.dir == "" || entry.Details.Private {
+		return
+	}
+	// The disk tier is best effort: a read-only cache dir only costs requests.
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	c.diskMu.Lock()
+	defer c.diskMu.Unlock()
+	if err := os.MkdirAll(c.dir, 0o700); err != nil {
+		return
+	}
+	if err := os.WriteFile(c.path(key), data, 0o600); err != nil {
+		return
+	}
+	c.noteFile(key)
+}
+
+// remember keeps entry in memory, evicting the oldest entry when full.
+func (c *CachedLoader) remember(key string, entry cacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries[key] = entry
+	if c.MaxEntries <= 0 || len(c.entries) <= c.MaxEntries {
+		return
+	}
+	oldest := ""
+	for k, e := range c.entries {
+		if oldest == "" || e.FetchedAt.Before(c.entries[oldest].FetchedAt) {
+			oldest = k
+		}
+	}
+	delete(c.entries, oldest)
+}
+
+// noteFile records key as the most recently written file and removes the
+// least recently written ones beyond MaxEntries. c.diskMu must be held.
+func (c *CachedLoader) noteFile(key string) {
+	if c.files == nil {
+		c.scanFiles()
+	}
+	if e, ok := c.onDisk[key]; ok {
+		c.files.MoveToBack(e)
+	} else {
+		c.onDisk[key] = c.files.PushBack(key)
+	}
+	for c.MaxEntries > 0 && c.files.Len() > c.MaxEntries {
+		oldest := c.files.Remove(c.files.Front()).(string)
+		delete(c.onDisk, oldest)
+		_ = os.Remove(c.path(oldest))
+	}
+}
+
+// scanFiles lists the files earlier runs left in dir, oldest first.
+func (c *CachedLoader) scanFiles() {
+	c.files, c.onDisk = list.New(), map[string]*list.Element{}
+	dirEntries, _ := os.ReadDir(c.dir)
+	type file struct {
+		key     string
+		modTime time.Time
+	}
+	files := make([]file, 0, len(dirEntries))
+	for _, e := range dirEntries {
+		name, ok := strings.CutSuffix(e.Name(), ".json")
+		if !ok {
+			continue
+		}
+		key, err := url.PathUnescape(name)
+		if err != nil {
+			continue
+		}
+		if info, err := e.Info(); err == nil && info.Mode().IsRegular() {
+			files = append(files, file{key, info.ModTime()})
+		}
+	}
+	sort.Slice(files, func(i, j int) bool { return files[i].modTime.Before(files[j].modTime) })
+	for _, f := range files {
+		c.onDisk[f.key] = c.files.PushBack(f.key)
+	}
+}
+
+func (c *CachedLoader) path(key string) string {
+	return filepath.Join(c.dir, url.PathEscape(key)+".json")
+}
+
+// cacheKey scopes fullName to the token, hashed like flight.Key, so details
+// fetched with one token are not served for another or for none.
+func cacheKey(fullName, token string) string {
+	return flight.Key(strings.ToLower(strings.TrimSpace(fullName)), token)
+}
@@ -0,0 +1,277 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func newDetailsClient() *github.MockClient {
+	mockClient := github.NewMockClient()
+	mockClient.GetRepoDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return testdata.SampleRepoDetails(), nil
+	}
+	return mockClient
+}
+
+// fakeClock lets tests age cache entries without sleeping.
+type fakeClock struct{ now time.Time }
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)}
+}
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+func (c *fakeClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func TestCachedLoader_FreshEntryServedFromMemory(t *testing.T) {
+	mockClient := newDetailsClient()
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, "", time.Hour)
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	details, err := cache.LoadDetails(ctx, "Golang/Go", "token123")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "golang/go", details.FullName)
+	testutil.AssertEqual(t, 1, mockClient.CallCounts.GetRepoDetails)
+}
+
+func TestCachedLoader_StaleEntryIsRefetched(t *testing.T) {
+	mockClient := newDetailsClient()
+	clock := newFakeClock()
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, "", time.Hour)
+	cache.Now = clock.Now
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	clock.Advance(3 * time.Hour)
+
+	_, fetchedAt, ok := cache.Cached("golang/go", "token123")
+	testutil.AssertTrue(t, ok, "stale entry should still be readable")
+	testutil.AssertEqual(t, 3*time.Hour, clock.Now().Sub(fetchedAt))
+
+	_, err = cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, mockClient.CallCounts.GetRepoDetails)
+
+	_, fetchedAt, _ = cache.Cached("golang/go", "token123")
+	testutil.AssertEqual(t, clock.Now(), fetchedAt)
+}
+
+func TestCachedLoader_SetTTL(t *testing.T) {
+	mockClient := newDetailsClient()
+	clock := newFakeClock()
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, "", 6*time.Hour)
+	cache.Now = clock.Now
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	clock.Advance(2 * time.Hour)
+	cache.SetTTL(time.Hour)
+	_, err = cache.LoadDetails(ctx, "golang/go", "token123")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, mockClient.CallCounts.GetRepoDetails)
+}
+
+func TestCachedLoader_RevalidateIgnoresTTL(t *testing.T) {
+	mockClient := newDetailsClient()
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, "", time.Hour)
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	_, err = cache.Revalidate(ctx, "golang/go", "token123")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, mockClient.CallCounts.GetRepoDetails)
+}
+
+func TestCachedLoader_DiskTierSurvivesRestart(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+
+	first := newDetailsClient()
+	_, err := repos.NewCachedLoader(repos.Service{GH: first}, dir, time.Hour).LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+
+	second := newDetailsClient()
+	restarted := repos.NewCachedLoader(repos.Service{GH: second}, dir, time.Hour)
+
+	cached, _, ok := restarted.Cached("golang/go", "token123")
+	testutil.AssertTrue(t, ok, "entry should be read back from disk")
+	testutil.AssertEqual(t, "BSD 3-Clause", cached.License)
+
+	_, err = restarted.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, second.CallCounts.GetRepoDetails)
+}
+
+func TestCachedLoader_ErrorsAreNotCached(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.GetRepoDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return domain.RepoDetails{}, errors.New("404 not found")
+	}
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, t.TempDir(), time.Hour)
+
+	_, err := cache.LoadDetails(context.Background(), "nonexistent/repo", "token123")
+	testutil.AssertError(t, err)
+
+	_, _, ok := cache.Cached("nonexistent/repo", "token123")
+	testutil.AssertFalse(t, ok, "failed loads should not be cached")
+}
+
+func TestCachedLoader_FailedRevalidateKeepsStaleEntry(t *testing.T) {
+	mockClient := newDetailsClient()
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, "", time.Hour)
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+
+	mockClient.GetRepoDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return domain.RepoDetails{}, errors.New("offline")
+	}
+	_, err = cache.Revalidate(ctx, "golang/go", "token123")
+	testutil.AssertError(t, err)
+
+	cached, _, ok := cache.Cached("golang/go", "token123")
+	testutil.AssertTrue(t, ok, "stale entry should survive a failed refresh")
+	testutil.AssertEqual(t, "golang/go", cached.FullName)
+}
+
+func TestCachedLoader_Clear(t *testing.T) {
+	dir := t.TempDir()
+	ctx := context.Background()
+	client := newDetailsClient()
+	cache := repos.NewCachedLoader(repos.Service{GH: client}, dir, time.Hour)
+	_, err := cache.LoadDetails(ctx, "golang/go", "")
+	testutil.AssertNoError(t, err)
+
+	testutil.AssertNoError(t, cache.Clear())
+	_, _, ok := cache.Cached("golang/go", "")
+	testutil.AssertFalse(t, ok, "cleared entry should be gone")
+	_, _, ok = repos.NewCachedLoader(repos.Service{GH: client}, dir, time.Hour).Cached("golang/go", "")
+	testutil.AssertFalse(t, ok, "cleared entry should be gone from disk")
+
+	_, err = cache.LoadDetails(ctx, "golang/go", "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, client.CallCounts.GetRepoDetails)
+}
+
+func TestCachedLoader_EntriesArePerToken(t *testing.T) {
+	mockClient := newDetailsClient()
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, t.TempDir(), time.Hour)
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+
+	_, _, ok := cache.Cached("golang/go", "other")
+	testutil.AssertFalse(t, ok, "another token should not see the entry")
+	_, _, ok = cache.Cached("golang/go", "")
+	testutil.AssertFalse(t, ok, "no token should not see the entry")
+
+	_, err = cache.LoadDetails(ctx, "golang/go", "")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, mockClient.CallCounts.GetRepoDetails)
+}
+
+func TestCachedLoader_PrivateReposStayInMemory(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "details")
+	mockClient := github.NewMockClient()
+	mockClient.GetRepoDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		details := testdata.SampleRepoDetails()
+		details.FullName = fullName
+		details.Private = fullName == "user/private-repo"
+		return details, nil
+	}
+	cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, dir, time.Hour)
+	ctx := context.Background()
+
+	_, err := cache.LoadDetails(ctx, "user/private-repo", "token123")
+	testutil.AssertNoError(t, err)
+	_, _, ok := cache.Cached("user/private-repo", "token123")
+	testutil.AssertTrue(t, ok, "private entry should be kept in memory")
+	_, _, ok = repos.NewCachedLoader(repos.Service{GH: mockClient}, dir, time.Hour).Cached("user/private-repo", "token123")
+	testutil.AssertFalse(t, ok, "private entry should not be written to disk")
+
+	// Public entries are written, readable only by the user.
+	_, err = cache.LoadDetails(ctx, "golang/go", "token123")
+	testutil.AssertNoError(t, err)
+	dirInfo, err := os.Stat(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o700), dirInfo.Mode().Perm())
+	files, err := os.ReadDir(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(files))
+	fileInfo, err := files[0].Info()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, os.FileMode(0o600), fileInfo.Mode().Perm())
+}
+
+func TestCachedLoader_EvictsOldestEntries(t *testing.T) {
+	dir := t.TempDir()
+	clock := newFakeClock()
+	mockClient := newDetailsClient()
+	newCache := func() *repos.CachedLoader {
+		cache := repos.NewCachedLoader(repos.Service{GH: mockClient}, dir, time.Hour)
+		cache.Now = clock.Now
+		cache.MaxEntries = 2
+		return cache
+	}
+	ctx := context.Background()
+
+	// The first two files are left by an earlier run.
+	earlier := newCache()
+	for i, name := range []string{"a/a", "b/b"} {
+		_, err := earlier.LoadDetails(ctx, name, "token123")
+		testutil.AssertNoError(t, err)
+		clock.Advance(time.Minute)
+		// Backdate the file just written so each has its own modification
+		// time even on filesystems with coarse timestamps.
+		modTime := time.Date(2024, 1, 1, 0, i, 0, 0, time.UTC)
+		files, _ := os.ReadDir(dir)
+		for _, f := range files {
+			if info, _ := f.Info(); info.ModTime().After(modTime) {
+				_ = os.Chtimes(filepath.Join(dir, f.Name()), modTime, modTime)
+			}
+		}
+	}
+
+	cache := newCache()
+	for _, name := range []string{"c/c", "d/d", "e/e"} {
+		_, err := cache.LoadDetails(ctx, name, "token123")
+		testutil.AssertNoError(t, err)
+		clock.Advance(time.Minute)
+	}
+
+	_, _, ok := cache.Cached("c/c", "token123")
+	testutil.AssertFalse(t, ok, "oldest entry should be evicted from memory")
+	_, _, ok = cache.Cached("e/e", "token123")
+	testutil.AssertTrue(t, ok, "newest entry should be kept")
+	files, err := os.ReadDir(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(files))
+	fresh := newCache()
+	for _, name := range []string{"a/a", "b/b", "c/c"} {
+		_, _, ok = fresh.Cached(name, "token123")
+		testutil.AssertFalse(t, ok, name+" should be evicted from disk")
+	}
+	_, _, ok = fresh.Cached("d/d", "token123")
+	testutil.AssertTrue(t, ok, "recent entry should stay on disk")
+}
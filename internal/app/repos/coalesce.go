@@ -0,0 +1,44 @@
+package repos
+
+import (
+	"context"
+	"strings"
+
+	"github.com/tbxark/gh-stars/internal/app/flight"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// CoalescingLoader merges concurrent identical requests, such as a quick
+// double-click on a row, into one call to the wrapped Loader. Requests are
+// identical when they target the same endpoint with the same token.
+type CoalescingLoader struct {
+	next     Loader
+	details  flight.Group[domain.RepoDetails]
+	activity flight.Group[domain.RepoActivity]
+}
+
+var _ Loader = (*CoalescingLoader)(nil)
+
+func NewCoalescingLoader(next Loader) *CoalescingLoader {
+	return &CoalescingLoader{next: next}
+}
+
+func (c *CoalescingLoader) LoadDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+	return c.details.Do(ctx, detailsKey(fullName, token), func(ctx context.Context) (domain.RepoDetails, error) {
+		return c.next.LoadDetails(ctx, fullName, token)
+	})
+}
+
+func (c *CoalescingLoader) LoadActivity(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+	return c.activity.Do(ctx, activityKey(fullName, token), func(ctx context.Context) (domain.RepoActivity, error) {
+		return c.next.LoadActivity(ctx, fullName, token)
+	})
+}
+
+func detailsKey(fullName, token string) string {
+	return flight.Key("repos/"+strings.ToLower(fullName), token)
+}
+
+func activityKey(fullName, token string) string {
+	return flight.Key("repos/"+strings.ToLower(fullName)+"/stats", token)
+}
@@ -0,0 +1,135 @@
+package repos_test
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+// blockingDetailsClient returns a client whose GetRepoDetails blocks until
+// release is closed, reporting each call on started.
+func blockingDetailsClient() (*github.MockClient, chan struct{}, chan struct{}) {
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	mockClient := github.NewMockClient()
+	mockClient.GetRepoDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		started <- struct{}{}
+		select {
+		case <-release:
+			return testdata.SampleRepoDetails(), nil
+		case <-ctx.Done():
+			return domain.RepoDetails{}, ctx.Err()
+		}
+	}
+	return mockClient, started, release
+}
+
+func TestCoalescingLoader_MergesConcurrentRequests(t *testing.T) {
+	mockClient, started, release := blockingDetailsClient()
+	loader := repos.NewCoalescingLoader(repos.Service{GH: mockClient})
+
+	var wg sync.WaitGroup
+	results := make(chan string, 5)
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			details, err := loader.LoadDetails(context.Background(), "golang/go", "token123")
+			if err == nil {
+				results <- details.FullName
+			}
+		}()
+	}
+
+	<-started
+	testutil.Eventually(t, func() bool { return loader.DetailsWaiting("golang/go", "token123") == 5 }, "callers to join")
+	close(release)
+	wg.Wait()
+	close(results)
+
+	count := 0
+	for name := range results {
+		testutil.AssertEqual(t, "golang/go", name)
+		count++
+	}
+	testutil.AssertEqual(t, 5, count)
+	testutil.AssertEqual(t, 1, mockClient.GetRepoDetailsCount())
+}
+
+func TestCoalescingLoader_DifferentTokensAreNotMerged(t *testing.T) {
+	mockClient, started, release := blockingDetailsClient()
+	loader := repos.NewCoalescingLoader(repos.Service{GH: mockClient})
+
+	var wg sync.WaitGroup
+	for _, token := range []string{"token-a", "token-b"} {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, _ = loader.LoadDetails(context.Background(), "golang/go", token)
+		}()
+	}
+
+	<-started
+	<-started
+	close(release)
+	wg.Wait()
+
+	testutil.AssertEqual(t, 2, mockClient.GetRepoDetailsCount())
+}
+
+func TestCoalescingLoader_CallerCancellationIsIndependent(t *testing.T) {
+	mockClient, started, release := blockingDetailsClient()
+	loader := repos.NewCoalescingLoader(repos.Service{GH: mockClient})
+
+	patient := make(chan error, 1)
+	go func() {
+		_, err := loader.LoadDetails(context.Background(), "golang/go", "token123")
+		patient <- err
+	}()
+	<-started
+
+	ctx, cancel := testutil.WithCancel()
+	impatient := make(chan error, 1)
+	go func() {
+		_, err := loader.LoadDetails(ctx, "golang/go", "token123")
+		impatient <- err
+	}()
+	testutil.Eventually(t, func() bool { return loader.DetailsWaiting("golang/go", "token123") == 2 }, "callers to join")
+	cancel()
+
+	testutil.AssertTrue(t, errors.Is(<-impatient, context.Canceled), "cancelled caller should return its own ctx error")
+
+	close(release)
+	testutil.AssertNoError(t, <-patient)
+	testutil.AssertEqual(t, 1, mockClient.GetRepoDetailsCount())
+}
+
+func TestCoalescingLoader_LastCallerLeavingCancelsRequest(t *testing.T) {
+	mockClient, started, _ := blockingDetailsClient()
+	loader := repos.NewCoalescingLoader(repos.Service{GH: mockClient})
+
+	ctx, cancel := testutil.WithCancel()
+	done := make(chan error, 1)
+	go func() {
+		_, err := loader.LoadDetails(ctx, "golang/go", "token123")
+		done <- err
+	}()
+	<-started
+	cancel()
+	testutil.AssertTrue(t, errors.Is(<-done, context.Canceled), "caller should see cancellation")
+
+	// The abandoned request was dropped, so a new caller starts a fresh one.
+	ctx2, cancel2 := testutil.WithTimeout(t, 50*time.Millisecond)
+	defer cancel2()
+	_, _ = loader.LoadDetails(ctx2, "golang/go", "token123")
+
+	testutil.AssertEqual(t, 2, mockClient.GetRepoDetailsCount())
+}
@@ -0,0 +1,7 @@
+package repos
+
+// DetailsWaiting reports how many callers wait on the details request for
+// fullName and token.
+func (c *CoalescingLoader) DetailsWaiting(fullName, token string) int {
+	return c.details.Waiting(detailsKey(fullName, token))
+}
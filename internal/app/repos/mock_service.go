@@ -13,10 +13,14 @@ type MockService struct {
 	// LoadDetailsFunc allows overriding the behavior in tests
 	LoadDetailsFunc func(ctx context.Context, fullName, token string) (domain.RepoDetails, error)
 
+	// LoadActivityFunc allows overriding the behavior in tests
+	LoadActivityFunc func(ctx context.Context, fullName, token string) (domain.RepoActivity, error)
+
 	// CallCounts tracks how many times each method was called
 	CallCounts struct {
-		mu          sync.Mutex
-		LoadDetails int
+		mu           sync.Mutex
+		LoadDetails  int
+		LoadActivity int
 	}
 }
 
@@ -28,6 +32,9 @@ func NewMockService() *MockService {
 		LoadDetailsFunc: func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
 			return domain.RepoDetails{}, fmt.Errorf("mock LoadDetails not implemented")
 		},
+		LoadActivityFunc: func(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+			return domain.RepoActivity{}, fmt.Errorf("mock LoadActivity not implemented")
+		},
 	}
 }
 
@@ -39,10 +46,19 @@ func (m *MockService) LoadDetails(ctx context.Context, fullName, token string) (
 	return m.LoadDetailsFunc(ctx, fullName, token)
 }
 
+// LoadActivity implements the Loader interface
+func (m *MockService) LoadActivity(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.LoadActivity++
+	m.CallCounts.mu.Unlock()
+	return m.LoadActivityFunc(ctx, fullName, token)
+}
+
 // ResetCallCounts resets all call counters (useful between test cases)
 func (m *MockService) ResetCallCounts() {
 	m.CallCounts.mu.Lock()
 	m.CallCounts.LoadDetails = 0
+	m.CallCounts.LoadActivity = 0
 	m.CallCounts.mu.Unlock()
 }
 
@@ -52,3 +68,10 @@ func (m *MockService) GetLoadDetailsCount() int {
 	defer m.CallCounts.mu.Unlock()
 	return m.CallCounts.LoadDetails
 }
+
+// GetLoadActivityCount returns the current call count in a thread-safe manner
+func (m *MockService) GetLoadActivityCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.LoadActivity
+}
@@ -2,6 +2,7 @@ package repos
 
 import (
 	"context"
+	"sync"
 
 	"github.com/tbxark/gh-stars/internal/domain"
 	"github.com/tbxark/gh-stars/internal/github"
@@ -10,6 +11,7 @@ import (
 // Loader defines the interface for loading repository details
 type Loader interface {
 	LoadDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error)
+	LoadActivity(ctx context.Context, fullName, token string) (domain.RepoActivity, error)
 }
 
 type Service struct {
@@ -19,3 +21,33 @@ type Service struct {
 func (s Service) LoadDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
 	return s.GH.GetRepoDetails(ctx, fullName, token)
 }
+
+// LoadActivity fetches the commit activity and code frequency series in
+// parallel, since either may need several polls while GitHub computes it.
+// Code frequency is best effort: GitHub refuses it for repositories with
+// 10k+ commits, so only a commit activity failure is reported.
+func (s Service) LoadActivity(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+	var (
+		wg       sync.WaitGroup
+		activity domain.RepoActivity
+		err      error
+	)
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		activity.Commits, err = s.GH.GetCommitActivity(ctx, fullName, token)
+	}()
+	go func() {
+		defer wg.Done()
+		frequency, freqErr := s.GH.GetCodeFrequency(ctx, fullName, token)
+		if freqErr == nil {
+			activity.CodeFrequency = frequency
+		}
+	}()
+	wg.Wait()
+
+	if err != nil {
+		return domain.RepoActivity{}, err
+	}
+	return activity, nil
+}
@@ -76,3 +76,59 @@ func TestService_LoadDetails_ContextCancellation(t *testing.T) {
 	testutil.AssertError(t, err)
 	testutil.AssertEqual(t, "", details.FullName)
 }
+
+func TestService_LoadActivity_Success(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.GetCommitActivityFunc = func(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error) {
+		return testdata.SampleCommitActivity(), nil
+	}
+	mockClient.GetCodeFrequencyFunc = func(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error) {
+		return testdata.SampleCodeFrequency(), nil
+	}
+
+	service := repos.Service{GH: mockClient}
+
+	activity, err := service.LoadActivity(context.Background(), "golang/go", "token123")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 4, len(activity.Commits))
+	testutil.AssertEqual(t, 4, len(activity.CodeFrequency))
+	testutil.AssertEqual(t, 1, mockClient.CallCounts.GetCommitActivity)
+	testutil.AssertEqual(t, 1, mockClient.CallCounts.GetCodeFrequency)
+}
+
+func TestService_LoadActivity_CodeFrequencyUnavailable(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.GetCommitActivityFunc = func(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error) {
+		return testdata.SampleCommitActivity(), nil
+	}
+	mockClient.GetCodeFrequencyFunc = func(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error) {
+		return nil, errors.New("422 too many commits")
+	}
+
+	service := repos.Service{GH: mockClient}
+
+	activity, err := service.LoadActivity(context.Background(), "golang/go", "token123")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 4, len(activity.Commits))
+	testutil.AssertEqual(t, 0, len(activity.CodeFrequency))
+}
+
+func TestService_LoadActivity_CommitActivityError(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.GetCommitActivityFunc = func(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error) {
+		return nil, github.ErrStatsPending
+	}
+	mockClient.GetCodeFrequencyFunc = func(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error) {
+		return testdata.SampleCodeFrequency(), nil
+	}
+
+	service := repos.Service{GH: mockClient}
+
+	activity, err := service.LoadActivity(context.Background(), "golang/go", "token123")
+
+	testutil.AssertError(t, err)
+	testutil.AssertTrue(t, errors.Is(err, github.ErrStatsPending), "should surface the pending error")
+	testutil.AssertEqual(t, 0, len(activity.CodeFrequency))
+}
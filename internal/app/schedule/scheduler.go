@@ -0,0 +1,256 @@
+package schedule
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+)
+
+const (
+	starsKey   = "schedule.stars_minutes"
+	detailsKey = "schedule.details_minutes"
+
+	onlineTimeout = 10 * time.Second
+)
+
+// Intervals are the choices offered for each schedule; zero turns it off.
+var Intervals = []time.Duration{0, time.Hour, 6 * time.Hour, 24 * time.Hour}
+
+// DescribeInterval formats an interval for display.
+func DescribeInterval(every time.Duration) string {
+	switch {
+	case every <= 0:
+		return "Off"
+	case every == time.Hour:
+		return "Hourly"
+	case every == 24*time.Hour:
+		return "Daily"
+	case every%time.Hour == 0:
+		return fmt.Sprintf("Every %dh", int(every.Hours()))
+	}
+	return "Every " + every.String()
+}
+
+// Kind says which schedule a task follows.
+type Kind int
+
+const (
+	// StarsRefresh re-syncs a stars list.
+	StarsRefresh Kind = iota
+	// DetailsCheck refetches the details shown in an open window.
+	DetailsCheck
+)
+
+// Settings are the refresh intervals; zero turns a schedule off.
+type Settings struct {
+	Stars   time.Duration
+	Details time.Duration
+}
+
+func (s Settings) interval(kind Kind) time.Duration {
+	if kind == DetailsCheck {
+		return s.Details
+	}
+	return s.Stars
+}
+
+// Prefs is the subset of fyne.Preferences the scheduler needs.
+type Prefs interface {
+	Int(key string) int
+	SetInt(key string, value int)
+}
+
+// Task is work run on one of the schedules for as long as it is added.
+type Task struct {
+	Kind Kind
+	Name string
+	// Run does the work and returns a short summary of what it did.
+	Run func(ctx context.Context) (string, error)
+	// OnResult is called off the main thread after every run or skip.
+	OnResult func(Result)
+}
+
+// Result describes the last run of a task.
+type Result struct {
+	At      time.Time
+	Summary string
+	// Skipped says why the run did not happen, such as being offline.
+	Skipped string
+	Err     error
+}
+
+// Describe formats r for a status line.
+func (r Result) Describe() string {
+	at := r.At.Local().Format("15:04")
+	switch {
+	case r.Skipped != "":
+		return fmt.Sprintf("Auto-refresh skipped at %s: %s", at, r.Skipped)
+	case r.Err != nil:
+		return fmt.Sprintf("Auto-refresh failed at %s: %v", at, r.Err)
+	case r.Summary != "":
+		return fmt.Sprintf("Auto-refreshed at %s: %s", at, r.Summary)
+	}
+	return "Auto-refreshed at " + at
+}
+
+// Scheduler runs the tasks that windows add on the configured intervals while
+// the app is open. A run is skipped rather than queued when Online fails or
+// the rate-limit gate is low, so a laptop waking up offline does not fire a
+// burst of requests.
+type Scheduler struct {
+	Gate *ratelimit.Gate
+	// Online reports whether GitHub can be reached; nil assumes it can.
+	Online func(ctx context.Context) error
+	// Jobs lists each run in the activity panel when set.
+	Jobs *jobs.Manager
+	// Prefs persists the settings when set.
+	Prefs Prefs
+
+	mu       sync.Mutex
+	settings Settings
+	loaded   bool
+	nextID   int
+	tasks    map[int]*task
+}
+
+type task struct {
+	Task
+	wake chan struct{}
+}
+
+// Settings returns the current intervals, reading them from Prefs the first
+// time.
+func (s *Scheduler) Settings() Settings {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.current()
+}
+
+// SetSettings saves settings and restarts every task's timer with the new
+// interval.
+func (s *Scheduler) SetSettings(settings Settings) {
+	s.mu.Lock()
+	s.settings = settings
+	s.loaded = true
+	if s.Prefs != nil {
+		s.Prefs.SetInt(starsKey, int(settings.Stars/time.Minute))
+		s.Prefs.SetInt(detailsKey, int(settings.Details/time.Minute))
+	}
+	tasks := make([]*task, 0, len(s.tasks))
+	for _, t := range s.tasks {
+		tasks = append(tasks, t)
+	}
+	s.mu.Unlock()
+
+	for _, t := range tasks {
+		select {
+		case t.wake <- struct{}{}:
+		default:
+		}
+	}
+}
+
+// Add starts running t on its schedule. The returned func removes it and
+// cancels a run in progress.
+func (s *Scheduler) Add(t Task) func() {
+	ctx, cancel := context.WithCancel(context.Background())
+	entry := &task{Task: t, wake: make(chan struct{}, 1)}
+
+	s.mu.Lock()
+	if s.tasks == nil {
+		s.tasks = map[int]*task{}
+	}
+	id := s.nextID
+	s.nextID++
+	s.tasks[id] = entry
+	s.mu.Unlock()
+
+	go s.loop(ctx, entry)
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			s.mu.Lock()
+			delete(s.tasks, id)
+			s.mu.Unlock()
+			cancel()
+		})
+	}
+}
+
+func (s *Scheduler) loop(ctx context.Context, t *task) {
+	for {
+		every := s.Settings().interval(t.Kind)
+		var (
+			timer *time.Timer
+			fire  <-chan time.Time
+		)
+		if every > 0 {
+			timer = time.NewTimer(every)
+			fire = timer.C
+		}
+
+		select {
+		case <-ctx.Done():
+		case <-t.wake:
+		case <-fire:
+			s.run(ctx, t)
+		}
+		if timer != nil {
+			timer.Stop()
+		}
+		if ctx.Err() != nil {
+			return
+		}
+	}
+}
+
+func (s *Scheduler) run(ctx context.Context, t *task) {
+	result := Result{At: time.Now()}
+	switch {
+	case s.offline(ctx):
+		result.Skipped = "offline"
+	case s.Gate.Low():
+		result.Skipped = "rate limit is low"
+	default:
+		runCtx, cancel := context.WithCancel(ctx)
+		job := s.Jobs.Register("Auto-refresh: "+t.Name, cancel)
+		result.Summary, result.Err = t.Run(runCtx)
+		job.Finish(result.Summary, result.Err)
+		cancel()
+		result.At = time.Now()
+	}
+	if ctx.Err() != nil {
+		// Removed while running; nobody is left to show the result.
+		return
+	}
+	if t.OnResult != nil {
+		t.OnResult(result)
+	}
+}
+
+func (s *Scheduler) offline(ctx context.Context) bool {
+	if s.Online == nil {
+		return false
+	}
+	ctx, cancel := context.WithTimeout(ctx, onlineTimeout)
+	defer cancel()
+	return s.Online(ctx) != nil
+}
+
+// current returns the settings, loading them first if needed. s.mu must be
+// held.
+func (s *Scheduler) current() Settings {
+	if !s.loaded && s.Prefs != nil {
+		s.settings = Settings{
+			Stars:   time.Duration(s.Prefs.Int(starsKey)) * time.Minute,
+			Details: time.Duration(s.Prefs.Int(detailsKey)) * time.Minute,
+		}
+	}
+	s.loaded = true
+	return s.settings
+}
@@ -0,0 +1,181 @@
+package schedule_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+// collect returns an OnResult func and a channel receiving its results.
+func collect() (func(schedule.Result), chan schedule.Result) {
+	results := make(chan schedule.Result, 16)
+	return func(r schedule.Result) { results <- r }, results
+}
+
+func next(t *testing.T, results chan schedule.Result) schedule.Result {
+	t.Helper()
+	select {
+	case r := <-results:
+		return r
+	case <-time.After(time.Second):
+		t.Fatal("timeout waiting for a scheduled run")
+		return schedule.Result{}
+	}
+}
+
+// skipUntil drops results until one was skipped for reason, where "" is a
+// run that happened.
+func skipUntil(t *testing.T, results chan schedule.Result, reason string) {
+	t.Helper()
+	for next(t, results).Skipped != reason {
+	}
+}
+
+func TestScheduler_RunsOnInterval(t *testing.T) {
+	manager := &jobs.Manager{}
+	s := &schedule.Scheduler{Jobs: manager}
+	s.SetSettings(schedule.Settings{Stars: 10 * time.Millisecond})
+	onResult, results := collect()
+
+	remove := s.Add(schedule.Task{
+		Kind:     schedule.StarsRefresh,
+		Name:     "stars",
+		Run:      func(ctx context.Context) (string, error) { return "Loaded 3 repos", nil },
+		OnResult: onResult,
+	})
+	defer remove()
+
+	r := next(t, results)
+	testutil.AssertEqual(t, "Loaded 3 repos", r.Summary)
+	testutil.AssertEqual(t, "", r.Skipped)
+	next(t, results)
+	testutil.AssertTrue(t, len(manager.List()) >= 2, "each run should be listed as a job")
+	testutil.AssertEqual(t, "Auto-refresh: stars", manager.List()[0].Name)
+}
+
+func TestScheduler_OffDoesNotRun(t *testing.T) {
+	s := &schedule.Scheduler{}
+	s.SetSettings(schedule.Settings{Stars: 10 * time.Millisecond})
+	var runs atomic.Int32
+
+	remove := s.Add(schedule.Task{
+		Kind: schedule.DetailsCheck,
+		Run: func(ctx context.Context) (string, error) {
+			runs.Add(1)
+			return "", nil
+		},
+	})
+	defer remove()
+
+	time.Sleep(50 * time.Millisecond)
+	testutil.AssertEqual(t, int32(0), runs.Load())
+}
+
+func TestScheduler_SkipsWhenOfflineOrRateLimited(t *testing.T) {
+	var offline atomic.Bool
+	offline.Store(true)
+	var remaining atomic.Int32
+	s := &schedule.Scheduler{
+		Online: func(ctx context.Context) error {
+			if offline.Load() {
+				return errors.New("no route to host")
+			}
+			return nil
+		},
+		Gate: &ratelimit.Gate{
+			Status: func() domain.RateLimit {
+				return domain.RateLimit{Limit: 5000, Remaining: int(remaining.Load()), Reset: time.Now().Add(time.Hour)}
+			},
+			Reserve: 100,
+		},
+	}
+	s.SetSettings(schedule.Settings{Stars: 10 * time.Millisecond})
+	onResult, results := collect()
+	var runs atomic.Int32
+
+	remove := s.Add(schedule.Task{
+		Run: func(ctx context.Context) (string, error) {
+			runs.Add(1)
+			return "", nil
+		},
+		OnResult: onResult,
+	})
+	defer remove()
+
+	testutil.AssertEqual(t, "offline", next(t, results).Skipped)
+	offline.Store(false)
+	skipUntil(t, results, "rate limit is low")
+	testutil.AssertEqual(t, int32(0), runs.Load())
+
+	remaining.Store(4000)
+	skipUntil(t, results, "")
+	testutil.AssertTrue(t, runs.Load() > 0, "run should happen once online with budget")
+}
+
+func TestScheduler_RemoveCancelsRunningTask(t *testing.T) {
+	s := &schedule.Scheduler{}
+	s.SetSettings(schedule.Settings{Details: 5 * time.Millisecond})
+	started := make(chan struct{})
+	stopped := make(chan struct{})
+	onResult, results := collect()
+
+	remove := s.Add(schedule.Task{
+		Kind: schedule.DetailsCheck,
+		Run: func(ctx context.Context) (string, error) {
+			close(started)
+			<-ctx.Done()
+			close(stopped)
+			return "", ctx.Err()
+		},
+		OnResult: onResult,
+	})
+
+	testutil.WaitOrTimeout(t, started, time.Second, "run to start")
+	remove()
+	remove()
+	testutil.WaitOrTimeout(t, stopped, time.Second, "run to be canceled")
+
+	select {
+	case <-results:
+		t.Fatal("a removed task should not report a result")
+	case <-time.After(20 * time.Millisecond):
+	}
+}
+
+func TestScheduler_SetSettingsPersistsAndReschedules(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	s := &schedule.Scheduler{Prefs: prefs}
+	onResult, results := collect()
+
+	remove := s.Add(schedule.Task{
+		Run:      func(ctx context.Context) (string, error) { return "ok", nil },
+		OnResult: onResult,
+	})
+	defer remove()
+
+	s.SetSettings(schedule.Settings{Stars: 6 * time.Hour, Details: time.Hour})
+	reloaded := &schedule.Scheduler{Prefs: prefs}
+	testutil.AssertEqual(t, 6*time.Hour, reloaded.Settings().Stars)
+	testutil.AssertEqual(t, time.Hour, reloaded.Settings().Details)
+
+	s.SetSettings(schedule.Settings{Stars: 10 * time.Millisecond})
+	testutil.AssertEqual(t, "ok", next(t, results).Summary)
+}
+
+func TestResult_Describe(t *testing.T) {
+	at := time.Date(2024, 3, 5, 14, 30, 0, 0, time.Local)
+
+	testutil.AssertEqual(t, "Auto-refreshed at 14:30: Loaded 3 repos", schedule.Result{At: at, Summary: "Loaded 3 repos"}.Describe())
+	testutil.AssertEqual(t, "Auto-refresh skipped at 14:30: offline", schedule.Result{At: at, Skipped: "offline"}.Describe())
+	testutil.AssertEqual(t, "Auto-refresh failed at 14:30: boom", schedule.Result{At: at, Err: errors.New("boom")}.Describe())
+	testutil.AssertEqual(t, "Every 6h", schedule.DescribeInterval(6*time.Hour))
+	testutil.AssertEqual(t, "Off", schedule.DescribeInterval(0))
+}
@@ -0,0 +1,165 @@
+package session
+
+import (
+	"encoding/json"
+	"slices"
+	"sort"
+
+	"github.com/tbxark/gh-stars/internal/app/tabstack"
+)
+
+const stateKey = "session.state"
+
+// Route names a window the navigator can reopen.
+type Route string
+
+const (
+	Details  Route = "details"
+	Tabs     Route = "tabs"
+	Changes  Route = "changes"
+	Updates  Route = "updates"
+	Compare  Route = "compare"
+	Team     Route = "team"
+	Activity Route = "activity"
+	Settings Route = "settings"
+)
+
+// Size is a window size in Fyne units. Fyne can neither read nor set window
+// positions, so only sizes are kept.
+type Size struct {
+	Width  float32 `json:"width"`
+	Height float32 `json:"height"`
+}
+
+// IsZero reports whether the size was never recorded.
+func (s Size) IsZero() bool {
+	return s.Width <= 0 || s.Height <= 0
+}
+
+// Window is an open window other than the stars window.
+type Window struct {
+	Route Route `json:"route"`
+	// Name is the repo of a Details window or the user of a Changes window.
+	Name string `json:"name,omitempty"`
+	Size Size   `json:"size"`
+	// Tabs holds the tabs of a Tabs window.
+	Tabs *tabstack.State `json:"tabs,omitempty"`
+}
+
+// State is what the app had open when it was last closed. Tokens are left
+// out; they come back through the saved profile or token discovery.
+type State struct {
+	// Username is the user whose stars were listed, for checking which
+	// saved repos are still starred.
+	Username    string `json:"username,omitempty"`
+	Query       string `json:"query,omitempty"`
+	Sort        int    `json:"sort,omitempty"`
+	HidePrivate bool   `json:"hide_private,omitempty"`
+	// Selected is the repo selected in the stars list.
+	Selected string `json:"selected,omitempty"`
+	// Pane is the repo shown next to the list in the split layout.
+	Pane    string   `json:"pane,omitempty"`
+	Stars   Size     `json:"stars"`
+	Windows []Window `json:"windows,omitempty"`
+}
+
+// Prune drops the repos starred reports false for and returns their names,
+// sorted, so repos unstarred since the session was saved are not reopened.
+func (s State) Prune(starred func(fullName string) bool) (State, []string) {
+	dropped := map[string]bool{}
+	keep := func(name string) bool {
+		if name == "" || starred(name) {
+			return true
+		}
+		dropped[name] = true
+		return false
+	}
+
+	if !keep(s.Selected) {
+		s.Selected = ""
+	}
+	if !keep(s.Pane) {
+		s.Pane = ""
+	}
+	windows := make([]Window, 0, len(s.Windows))
+	for _, w := range s.Windows {
+		switch w.Route {
+		case Details:
+			if !keep(w.Name) {
+				continue
+			}
+		case Tabs:
+			if w.Tabs == nil {
+				continue
+			}
+			tabs := *w.Tabs
+			tabs.Tabs = slices.DeleteFunc(slices.Clone(tabs.Tabs), func(name string) bool { return !keep(name) })
+			tabs.Closed = slices.DeleteFunc(slices.Clone(tabs.Closed), func(name string) bool { return !keep(name) })
+			if len(tabs.Tabs) == 0 {
+				continue
+			}
+			w.Tabs = &tabs
+		}
+		windows = append(windows, w)
+	}
+	s.Windows = windows
+
+	names := make([]string, 0, len(dropped))
+	for name := range dropped {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return s, names
+}
+
+// Prefs is the subset of fyne.Preferences the store needs.
+type Prefs interface {
+	String(key string) string
+	SetString(key, value string)
+}
+
+// Store keeps the last session in the app preferences. A nil *Store never
+// has one.
+type Store struct {
+	prefs Prefs
+}
+
+func NewStore(prefs Prefs) *Store {
+	return &Store{prefs: prefs}
+}
+
+// Load returns the saved session; ok is false when there is none or it
+// cannot be read.
+func (s *Store) Load() (State, bool) {
+	if s == nil {
+		return State{}, false
+	}
+	raw := s.prefs.String(stateKey)
+	if raw == "" {
+		return State{}, false
+	}
+	var state State
+	if err := json.Unmarshal([]byte(raw), &state); err != nil {
+		return State{}, false
+	}
+	return state, true
+}
+
+func (s *Store) Save(state State) error {
+	if s == nil {
+		return nil
+	}
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	s.prefs.SetString(stateKey, string(data))
+	return nil
+}
+
+// Clear forgets the saved session.
+func (s *Store) Clear() {
+	if s != nil {
+		s.prefs.SetString(stateKey, "")
+	}
+}
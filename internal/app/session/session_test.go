@@ -0,0 +1,81 @@
+package session_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/session"
+	"github.com/tbxark/gh-stars/internal/app/tabstack"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestStore_RoundTrip(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	state := session.State{
+		Username: "octocat",
+		Query:    "lang:go",
+		Sort:     2,
+		Selected: "golang/go",
+		Stars:    session.Size{Width: 1200, Height: 800},
+		Windows: []session.Window{
+			{Route: session.Details, Name: "golang/go", Size: session.Size{Width: 900, Height: 650}},
+			{Route: session.Tabs, Tabs: &tabstack.State{Tabs: []string{"a/a", "b/b"}, Active: "b/b"}},
+			{Route: session.Updates},
+		},
+	}
+	testutil.AssertNoError(t, session.NewStore(prefs).Save(state))
+
+	got, ok := session.NewStore(prefs).Load()
+	testutil.AssertTrue(t, ok, "saved session should load")
+	testutil.AssertEqual(t, "lang:go", got.Query)
+	testutil.AssertEqual(t, 2, got.Sort)
+	testutil.AssertEqual(t, float32(1200), got.Stars.Width)
+	testutil.AssertEqual(t, 3, len(got.Windows))
+	testutil.AssertEqual(t, "b/b", got.Windows[1].Tabs.Active)
+
+	session.NewStore(prefs).Clear()
+	_, ok = session.NewStore(prefs).Load()
+	testutil.AssertFalse(t, ok, "cleared session should not load")
+}
+
+func TestStore_IgnoresUnreadableState(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	prefs.SetString("session.state", "{")
+	_, ok := session.NewStore(prefs).Load()
+	testutil.AssertFalse(t, ok, "broken session should not load")
+
+	var nilStore *session.Store
+	testutil.AssertNoError(t, nilStore.Save(session.State{Query: "x"}))
+	_, ok = nilStore.Load()
+	testutil.AssertFalse(t, ok, "nil store has no session")
+}
+
+func TestState_PruneDropsUnstarredRepos(t *testing.T) {
+	state := session.State{
+		Selected: "gone/repo",
+		Pane:     "kept/repo",
+		Windows: []session.Window{
+			{Route: session.Details, Name: "gone/repo"},
+			{Route: session.Details, Name: "kept/repo"},
+			{Route: session.Tabs, Tabs: &tabstack.State{
+				Tabs:   []string{"kept/repo", "old/tab"},
+				Active: "old/tab",
+				Closed: []string{"old/closed"},
+			}},
+			{Route: session.Tabs, Tabs: &tabstack.State{Tabs: []string{"old/tab"}}},
+			{Route: session.Changes, Name: "octocat"},
+		},
+	}
+
+	got, dropped := state.Prune(func(name string) bool { return strings.HasPrefix(name, "kept/") })
+	testutil.AssertEqual(t, "gone/repo,old/closed,old/tab", strings.Join(dropped, ","))
+	testutil.AssertEqual(t, "", got.Selected)
+	testutil.AssertEqual(t, "kept/repo", got.Pane)
+	testutil.AssertEqual(t, 3, len(got.Windows))
+	testutil.AssertEqual(t, "kept/repo", got.Windows[0].Name)
+	testutil.AssertEqual(t, "kept/repo", strings.Join(got.Windows[1].Tabs.Tabs, ","))
+	testutil.AssertEqual(t, 0, len(got.Windows[1].Tabs.Closed))
+	testutil.AssertEqual(t, session.Changes, got.Windows[2].Route)
+	// The original state is left alone.
+	testutil.AssertEqual(t, 2, len(state.Windows[2].Tabs.Tabs))
+}
@@ -0,0 +1,300 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+)
+
+const (
+	themeKey    = "settings.theme"
+	sortKey     = "settings.default_sort"
+	perPageKey  = "settings.per_page"
+	timeoutKey  = "settings.timeout_seconds"
+	cacheTTLKey = "settings.cache_ttl_hours"
+	cacheDirKey = "settings.cache_dir"
+	baseURLKey  = "settings.base_url"
+	proxyKey    = "settings.proxy"
+)
+
+// Timeouts accepted for API requests.
+const (
+	MinTimeout = 5 * time.Second
+	MaxTimeout = 10 * time.Minute
+)
+
+// How long cached repo details are served before they are fetched again.
+const (
+	MinCacheTTL = time.Hour
+	MaxCacheTTL = 7 * 24 * time.Hour
+)
+
+// Theme picks the app's colors.
+type Theme string
+
+const (
+	// System follows the desktop's light or dark setting.
+	System Theme = "system"
+	Light  Theme = "light"
+	Dark   Theme = "dark"
+)
+
+// Themes are the choices offered, in display order.
+var Themes = []Theme{System, Light, Dark}
+
+func (t Theme) String() string {
+	switch t {
+	case Light:
+		return "Light"
+	case Dark:
+		return "Dark"
+	default:
+		return "System"
+	}
+}
+
+// Settings are the app-wide preferences edited in the settings window.
+type Settings struct {
+	Theme Theme
+	// DefaultSort is the position in stars.SortKeys a new stars list is
+	// ordered by.
+	DefaultSort int
+	// PerPage is the page size of a sync when the profile has none.
+	PerPage int
+	// Timeout bounds each API request.
+	Timeout time.Duration
+	// CacheTTL is how long cached repo details are served before they are
+	// fetched again. It is kept in whole hours.
+	CacheTTL time.Duration
+	// CacheDir replaces the per-user cache dir when set. It takes effect on
+	// the next launch.
+	CacheDir string
+	// BaseURL is the API root, for GitHub Enterprise; "" is api.github.com.
+	BaseURL string
+	// Proxy is the proxy URL; "" uses HTTPS_PROXY and NO_PROXY.
+	Proxy string
+}
+
+// Defaults are the settings before anything is changed.
+func Defaults() Settings {
+	return Settings{Theme: System, PerPage: 100, Timeout: 20 * time.Second, CacheTTL: 6 * time.Hour}
+}
+
+// Validate reports the first setting that cannot be applied.
+func (s Settings) Validate() error {
+	if !knownTheme(s.Theme) {
+		return fmt.Errorf("unknown theme %q", s.Theme)
+	}
+	if s.DefaultSort < 0 || s.DefaultSort >= len(stars.SortKeys) {
+		return errors.New("unknown default sort")
+	}
+	if s.PerPage < 1 || s.PerPage > 100 {
+		return errors.New("per page must be between 1 and 100")
+	}
+	if s.Timeout < MinTimeout || s.Timeout > MaxTimeout {
+		return fmt.Errorf("timeout must be between %s and %s", MinTimeout, MaxTimeout)
+	}
+	if s.CacheTTL < MinCacheTTL || s.CacheTTL > MaxCacheTTL {
+		return fmt.Errorf("cache lifetime must be between %d and %d hours", MinCacheTTL/time.Hour, MaxCacheTTL/time.Hour)
+	}
+	if s.BaseURL != "" {
+		if err := checkURL(s.BaseURL, "http", "https"); err != nil {
+			return fmt.Errorf("base URL: %w", err)
+		}
+	}
+	if s.Proxy != "" {
+		if err := checkURL(s.Proxy, "http", "https", "socks5"); err != nil {
+			return fmt.Errorf("proxy: %w", err)
+		}
+	}
+	return nil
+}
+
+// CacheRoot is the directory caches are kept in: CacheDir, or gh-stars in
+// the per-user cache dir. It is "" when there is neither.
+func (s Settings) CacheRoot() string {
+	if s.CacheDir != "" {
+		return s.CacheDir
+	}
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-stars")
+}
+
+func knownTheme(theme Theme) bool {
+	for _, known := range Themes {
+		if theme == known {
+			return true
+		}
+	}
+	return false
+}
+
+func checkURL(raw string, schemes ...string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Host == "" {
+		return fmt.Errorf("%q is not a URL", raw)
+	}
+	for _, scheme := range schemes {
+		if u.Scheme == scheme {
+			return nil
+		}
+	}
+	return fmt.Errorf("%q must start with %s://", raw, strings.Join(schemes, ":// or "))
+}
+
+// DirSize adds up the sizes of the files under dir. A missing dir is empty.
+func DirSize(dir string) (int64, error) {
+	if dir == "" {
+		return 0, nil
+	}
+	var size int64
+	err := filepath.WalkDir(dir, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.Type().IsRegular() {
+			info, err := d.Info()
+			if err != nil {
+				return err
+			}
+			size += info.Size()
+		}
+		return nil
+	})
+	if errors.Is(err, fs.ErrNotExist) {
+		return 0, nil
+	}
+	return size, err
+}
+
+// Prefs is the subset of fyne.Preferences the store needs.
+type Prefs interface {
+	String(key string) string
+	SetString(key, value string)
+	Int(key string) int
+	SetInt(key string, value int)
+}
+
+// Store keeps the settings in the app preferences. A nil *Store always
+// reports Defaults.
+type Store struct {
+	prefs Prefs
+
+	mu        sync.Mutex
+	nextID    int
+	listeners map[int]func(Settings)
+}
+
+func NewStore(prefs Prefs) *Store {
+	return &Store{prefs: prefs}
+}
+
+// Load returns the saved settings; anything unset or invalid reads as its
+// default.
+func (s *Store) Load() Settings {
+	defaults := Defaults()
+	if s == nil {
+		return defaults
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	loaded := Settings{
+		Theme:       Theme(s.prefs.String(themeKey)),
+		DefaultSort: s.prefs.Int(sortKey),
+		PerPage:     s.prefs.Int(perPageKey),
+		Timeout:     time.Duration(s.prefs.Int(timeoutKey)) * time.Second,
+		CacheTTL:    time.Duration(s.prefs.Int(cacheTTLKey)) * time.Hour,
+		CacheDir:    s.prefs.String(cacheDirKey),
+		BaseURL:     s.prefs.String(baseURLKey),
+		Proxy:       s.prefs.String(proxyKey),
+	}
+	// Each field falls back on its own, so one bad value does not reset
+	// the others.
+	if !knownTheme(loaded.Theme) {
+		loaded.Theme = defaults.Theme
+	}
+	if loaded.DefaultSort < 0 || loaded.DefaultSort >= len(stars.SortKeys) {
+		loaded.DefaultSort = defaults.DefaultSort
+	}
+	if loaded.PerPage < 1 || loaded.PerPage > 100 {
+		loaded.PerPage = defaults.PerPage
+	}
+	if loaded.Timeout < MinTimeout || loaded.Timeout > MaxTimeout {
+		loaded.Timeout = defaults.Timeout
+	}
+	if loaded.CacheTTL < MinCacheTTL || loaded.CacheTTL > MaxCacheTTL {
+		loaded.CacheTTL = defaults.CacheTTL
+	}
+	if loaded.BaseURL != "" && checkURL(loaded.BaseURL, "http", "https") != nil {
+		loaded.BaseURL = defaults.BaseURL
+	}
+	if loaded.Proxy != "" && checkURL(loaded.Proxy, "http", "https", "socks5") != nil {
+		loaded.Proxy = defaults.Proxy
+	}
+	return loaded
+}
+
+// Save stores settings and tells subscribers, unless they are invalid.
+func (s *Store) Save(settings Settings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	if s == nil {
+		return errors.New("settings cannot be changed")
+	}
+	settings.BaseURL = strings.TrimRight(settings.BaseURL, "/")
+
+	s.mu.Lock()
+	s.prefs.SetString(themeKey, string(settings.Theme))
+	s.prefs.SetInt(sortKey, settings.DefaultSort)
+	s.prefs.SetInt(perPageKey, settings.PerPage)
+	s.prefs.SetInt(timeoutKey, int(settings.Timeout/time.Second))
+	s.prefs.SetInt(cacheTTLKey, int(settings.CacheTTL/time.Hour))
+	s.prefs.SetString(cacheDirKey, settings.CacheDir)
+	s.prefs.SetString(baseURLKey, settings.BaseURL)
+	s.prefs.SetString(proxyKey, settings.Proxy)
+	listeners := make([]func(Settings), 0, len(s.listeners))
+	for _, fn := range s.listeners {
+		listeners = append(listeners, fn)
+	}
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(settings)
+	}
+	return nil
+}
+
+// Subscribe calls fn with the new settings after every Save. The returned
+// func unsubscribes.
+func (s *Store) Subscribe(fn func(Settings)) func() {
+	if s == nil {
+		return func() {}
+	}
+	s.mu.Lock()
+	if s.listeners == nil {
+		s.listeners = map[int]func(Settings){}
+	}
+	id := s.nextID
+	s.nextID++
+	s.listeners[id] = fn
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		delete(s.listeners, id)
+		s.mu.Unlock()
+	}
+}
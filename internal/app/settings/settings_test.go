@@ -0,0 +1,90 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/settings"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestStore_LoadDefaults(t *testing.T) {
+	var nilStore *settings.Store
+	testutil.AssertEqual(t, settings.Defaults(), nilStore.Load())
+	testutil.AssertError(t, nilStore.Save(settings.Defaults()))
+	testutil.AssertEqual(t, settings.Defaults(), settings.NewStore(testutil.NewPrefs()).Load())
+}
+
+func TestStore_SaveNotifiesAndPersists(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	store := settings.NewStore(prefs)
+	var seen []settings.Settings
+	unsubscribe := store.Subscribe(func(s settings.Settings) { seen = append(seen, s) })
+
+	want := settings.Settings{
+		Theme:       settings.Dark,
+		DefaultSort: 2,
+		PerPage:     50,
+		Timeout:     time.Minute,
+		CacheTTL:    24 * time.Hour,
+		BaseURL:     "https://ghe.example.com/api/v3",
+		Proxy:       "socks5://localhost:1080",
+	}
+	in := want
+	in.BaseURL += "/"
+	testutil.AssertNoError(t, store.Save(in))
+	testutil.AssertEqual(t, want, settings.NewStore(prefs).Load())
+	testutil.AssertEqual(t, 1, len(seen))
+	testutil.AssertEqual(t, want, seen[0])
+
+	unsubscribe()
+	testutil.AssertNoError(t, store.Save(settings.Defaults()))
+	testutil.AssertEqual(t, 1, len(seen))
+}
+
+func TestStore_SaveRejectsInvalid(t *testing.T) {
+	store := settings.NewStore(testutil.NewPrefs())
+	for _, change := range []func(*settings.Settings){
+		func(s *settings.Settings) { s.Theme = "neon" },
+		func(s *settings.Settings) { s.DefaultSort = 99 },
+		func(s *settings.Settings) { s.PerPage = 101 },
+		func(s *settings.Settings) { s.Timeout = time.Second },
+		func(s *settings.Settings) { s.CacheTTL = time.Minute },
+		func(s *settings.Settings) { s.BaseURL = "ghe.example.com" },
+		func(s *settings.Settings) { s.Proxy = "ftp://proxy:21" },
+	} {
+		s := settings.Defaults()
+		change(&s)
+		testutil.AssertError(t, store.Save(s))
+	}
+	testutil.AssertEqual(t, settings.Defaults(), store.Load())
+}
+
+func TestStore_LoadResetsBadFieldsOnly(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	prefs.SetString("settings.theme", "light")
+	prefs.SetInt("settings.per_page", 500)
+	prefs.SetString("settings.proxy", "not a url")
+
+	got := settings.NewStore(prefs).Load()
+	testutil.AssertEqual(t, settings.Light, got.Theme)
+	testutil.AssertEqual(t, 100, got.PerPage)
+	testutil.AssertEqual(t, "", got.Proxy)
+}
+
+func TestDirSize(t *testing.T) {
+	dir := t.TempDir()
+	testutil.AssertNoError(t, os.MkdirAll(filepath.Join(dir, "details"), 0o755))
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(dir, "enriched.json"), make([]byte, 10), 0o644))
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(dir, "details", "a.json"), make([]byte, 5), 0o644))
+
+	size, err := settings.DirSize(dir)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, int64(15), size)
+
+	size, err = settings.DirSize(filepath.Join(dir, "missing"))
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, int64(0), size)
+}
@@ -0,0 +1,38 @@
+package stars
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/tbxark/gh-stars/internal/app/flight"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// CoalescingLoader merges concurrent identical starred-list requests into one
+// call to the wrapped Loader. Requests are identical when they target the same
+// endpoint with the same token.
+type CoalescingLoader struct {
+	next    Loader
+	starred flight.Group[[]domain.Repo]
+}
+
+var _ Loader = (*CoalescingLoader)(nil)
+
+func NewCoalescingLoader(next Loader) *CoalescingLoader {
+	return &CoalescingLoader{next: next}
+}
+
+func (c *CoalescingLoader) LoadStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+	repos, err := c.starred.Do(ctx, starredKey(username, token, perPage), func(ctx context.Context) ([]domain.Repo, error) {
+		return c.next.LoadStarred(ctx, username, token, perPage)
+	})
+	// Every merged caller gets its own slice so none can mutate another's result.
+	return slices.Clone(repos), err
+}
+
+func starredKey(username, token string, perPage int) string {
+	endpoint := fmt.Sprintf("users/%s/starred?per_page=%d", strings.ToLower(username), perPage)
+	return flight.Key(endpoint, token)
+}
@@ -0,0 +1,75 @@
+package stars_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestCoalescingLoader_MergesConcurrentRequests(t *testing.T) {
+	// Arrange
+	started := make(chan struct{}, 10)
+	release := make(chan struct{})
+	mockClient := github.NewMockClient()
+	mockClient.ListStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		started <- struct{}{}
+		<-release
+		return testdata.SampleRepoList(), nil
+	}
+	loader := stars.NewCoalescingLoader(stars.Service{GH: mockClient})
+
+	// Act
+	var wg sync.WaitGroup
+	lengths := make(chan int, 4)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			repos, err := loader.LoadStarred(context.Background(), "testuser", "token123", 100)
+			if err == nil {
+				lengths <- len(repos)
+			}
+		}()
+	}
+	<-started
+	testutil.Eventually(t, func() bool { return loader.StarredWaiting("testuser", "token123", 100) == 4 }, "callers to join")
+	close(release)
+	wg.Wait()
+	close(lengths)
+
+	// Assert
+	count := 0
+	for n := range lengths {
+		testutil.AssertEqual(t, 3, n)
+		count++
+	}
+	testutil.AssertEqual(t, 4, count)
+	testutil.AssertEqual(t, 1, mockClient.GetListStarredCount())
+}
+
+func TestCoalescingLoader_SequentialRequestsAreNotMerged(t *testing.T) {
+	// Arrange
+	mockClient := github.NewMockClient()
+	mockClient.ListStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	loader := stars.NewCoalescingLoader(stars.Service{GH: mockClient})
+	ctx := context.Background()
+
+	// Act
+	first, err := loader.LoadStarred(ctx, "testuser", "token123", 100)
+	testutil.AssertNoError(t, err)
+	first[0].FullName = "mutated/by-caller"
+	second, err := loader.LoadStarred(ctx, "testuser", "token123", 100)
+
+	// Assert
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "golang/go", second[0].FullName)
+	testutil.AssertEqual(t, 2, mockClient.GetListStarredCount())
+}
@@ -0,0 +1,7 @@
+package stars
+
+// StarredWaiting reports how many callers wait on the starred list request
+// for username, token and perPage.
+func (c *CoalescingLoader) StarredWaiting(username, token string, perPage int) int {
+	return c.starred.Waiting(starredKey(username, token, perPage))
+}
@@ -0,0 +1,146 @@
+package stars
+
+import (
+	"cmp"
+	"slices"
+	"strings"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// SortKey selects the order of the starred list.
+type SortKey int
+
+const (
+	// SortStarred keeps the order GitHub returned, most recently starred
+	// first.
+	SortStarred SortKey = iota
+	// SortStars orders by stargazer count.
+	SortStars
+	// SortName orders alphabetically by full name.
+	SortName
+	// SortUpdated puts the most recently updated repos first.
+	SortUpdated
+)
+
+// SortKeys lists the keys in the order the UI offers them.
+var SortKeys = []SortKey{SortStarred, SortStars, SortName, SortUpdated}
+
+func (k SortKey) String() string {
+	switch k {
+	case SortStars:
+		return "Stars"
+	case SortName:
+		return "Name"
+	case SortUpdated:
+		return "Updated"
+	default:
+		return "Recently starred"
+	}
+}
+
+// Index is an immutable catalog prepared for repeated filtering and sorting.
+// Filter and Sort work on positions into the catalog, so narrowing tens of
+// thousands of repos copies ints rather than repos, and the lower-cased text
+// a query needs is computed once when the index is built.
+type Index struct {
+	repos []domain.Repo
+	text  []repoText
+	names []string
+}
+
+// NewIndex builds an index over a copy of repos.
+func NewIndex(repos []domain.Repo) *Index {
+	ix := &Index{
+		repos: slices.Clone(repos),
+		text:  make([]repoText, len(repos)),
+		names: make([]string, len(repos)),
+	}
+	for i, repo := range ix.repos {
+		ix.text[i] = textOf(repo)
+		ix.names[i] = strings.ToLower(repo.FullName)
+	}
+	return ix
+}
+
+// Len returns the number of repos in the catalog. A nil index is empty.
+func (ix *Index) Len() int {
+	if ix == nil {
+		return 0
+	}
+	return len(ix.repos)
+}
+
+// Repo returns the repo at position id.
+func (ix *Index) Repo(id int) domain.Repo {
+	return ix.repos[id]
+}
+
+// All returns a copy of the catalog in its original order.
+func (ix *Index) All() []domain.Repo {
+	if ix == nil {
+		return nil
+	}
+	return slices.Clone(ix.repos)
+}
+
+// Repos returns the repos at ids, in that order.
+func (ix *Index) Repos(ids []int) []domain.Repo {
+	repos := make([]domain.Repo, len(ids))
+	for i, id := range ids {
+		repos[i] = ix.repos[id]
+	}
+	return repos
+}
+
+// Filter returns the positions of the repos matching q, in catalog order.
+// Private repos are left out when hidePrivate is set. lookup supplies
+// enriched details and may be nil.
+func (ix *Index) Filter(q Query, hidePrivate bool, lookup func(fullName string) (domain.RepoDetails, bool)) []int {
+	ids := make([]int, 0, ix.Len())
+	if ix == nil {
+		return ids
+	}
+	needsDetails := lookup != nil && (q.License != "" || q.Topic != "" || len(q.Terms) > 0)
+	empty := q.IsEmpty()
+	for i, repo := range ix.repos {
+		if hidePrivate && repo.Private {
+			continue
+		}
+		if !empty {
+			var details *domain.RepoDetails
+			if needsDetails {
+				if d, ok := lookup(repo.FullName); ok {
+					details = &d
+				}
+			}
+			if !q.match(ix.text[i], repo.Private, details) {
+				continue
+			}
+		}
+		ids = append(ids, i)
+	}
+	return ids
+}
+
+// Sort orders ids in place. Ties keep catalog order, so the result is stable.
+func (ix *Index) Sort(ids []int, key SortKey) {
+	var compare func(a, b int) int
+	switch key {
+	case SortStars:
+		compare = func(a, b int) int { return cmp.Compare(ix.repos[b].Stars, ix.repos[a].Stars) }
+	case SortName:
+		compare = func(a, b int) int { return strings.Compare(ix.names[a], ix.names[b]) }
+	case SortUpdated:
+		compare = func(a, b int) int { return ix.repos[b].UpdatedAt.Compare(ix.repos[a].UpdatedAt) }
+	default:
+		slices.Sort(ids)
+		return
+	}
+	slices.SortFunc(ids, func(a, b int) int {
+		if c := compare(a, b); c != 0 {
+			return c
+		}
+		return cmp.Compare(a, b)
+	})
+}
@@ -0,0 +1,107 @@
+package stars_test
+
+import (
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestIndex_FilterAndSort(t *testing.T) {
+	repos := append(testdata.SampleRepoList(), testdata.SampleRepoPrivate())
+	ix := stars.NewIndex(repos)
+	testutil.AssertEqual(t, len(repos), ix.Len())
+
+	ids := ix.Filter(stars.ParseQuery("lang:go"), false, nil)
+	testutil.AssertEqual(t, "golang/go", ix.Repo(ids[0]).FullName)
+	testutil.AssertEqual(t, "kubernetes/kubernetes", ix.Repo(ids[1]).FullName)
+
+	all := ix.Filter(stars.Query{}, false, nil)
+	public := ix.Filter(stars.Query{}, true, nil)
+	testutil.AssertEqual(t, len(repos), len(all))
+	testutil.AssertEqual(t, len(repos)-1, len(public))
+
+	ix.Sort(all, stars.SortName)
+	sorted := ix.Repos(all)
+	for i := 1; i < len(sorted); i++ {
+		testutil.AssertTrue(t, sorted[i-1].FullName <= sorted[i].FullName, "names should be ascending")
+	}
+	ix.Sort(all, stars.SortStars)
+	sorted = ix.Repos(all)
+	for i := 1; i < len(sorted); i++ {
+		testutil.AssertTrue(t, sorted[i-1].Stars >= sorted[i].Stars, "stars should be descending")
+	}
+	ix.Sort(all, stars.SortStarred)
+	testutil.AssertEqual(t, repos[0].FullName, ix.Repo(all[0]).FullName)
+}
+
+func TestIndex_MatchesFilter(t *testing.T) {
+	repos := testdata.SampleRepoList()
+	ix := stars.NewIndex(repos)
+	lookup := func(fullName string) (domain.RepoDetails, bool) {
+		if fullName == "golang/go" {
+			return domain.RepoDetails{License: "BSD-3-Clause", Topics: []string{"language"}}, true
+		}
+		return domain.RepoDetails{}, false
+	}
+
+	for _, query := range []string{"", "go", "license:bsd", "topic:language", "owner:microsoft", "is:public editor"} {
+		q := stars.ParseQuery(query)
+		want := stars.Filter(repos, q, lookup)
+		got := ix.Repos(ix.Filter(q, false, lookup))
+		testutil.AssertEqual(t, len(want), len(got))
+		for i := range want {
+			testutil.AssertEqual(t, want[i].FullName, got[i].FullName)
+		}
+	}
+}
+
+func TestIndex_Nil(t *testing.T) {
+	var ix *stars.Index
+	testutil.AssertEqual(t, 0, ix.Len())
+	testutil.AssertEqual(t, 0, len(ix.Filter(stars.Query{}, false, nil)))
+}
+
+const benchSize = 50_000
+
+func BenchmarkNewIndex_50k(b *testing.B) {
+	repos := testdata.LargeRepoList(benchSize)
+	b.ResetTimer()
+	for b.Loop() {
+		stars.NewIndex(repos)
+	}
+}
+
+func BenchmarkIndexFilter_50k(b *testing.B) {
+	ix := stars.NewIndex(testdata.LargeRepoList(benchSize))
+	q := stars.ParseQuery("lang:go container 12")
+	b.ResetTimer()
+	for b.Loop() {
+		ix.Filter(q, true, nil)
+	}
+}
+
+func BenchmarkFilter_50k(b *testing.B) {
+	repos := testdata.LargeRepoList(benchSize)
+	q := stars.ParseQuery("lang:go container 12")
+	b.ResetTimer()
+	for b.Loop() {
+		stars.Filter(repos, q, nil)
+	}
+}
+
+func BenchmarkIndexSort_50k(b *testing.B) {
+	ix := stars.NewIndex(testdata.LargeRepoList(benchSize))
+	all := ix.Filter(stars.Query{}, false, nil)
+	for _, key := range []stars.SortKey{stars.SortStars, stars.SortName, stars.SortUpdated} {
+		b.Run(key.String(), func(b *testing.B) {
+			ids := make([]int, len(all))
+			for b.Loop() {
+				copy(ids, all)
+				ix.Sort(ids, key)
+			}
+		})
+	}
+}
@@ -0,0 +1,150 @@
+package stars
+
+import (
+	"strings"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Query is a parsed filter such as "cli lang:go license:mit is:private".
+// Every bare term must appear in the name, description or topics; qualifiers
+// narrow by field. License and topic live only in enriched details, so those
+// qualifiers never match a repo that has not been enriched yet.
+type Query struct {
+	Terms      []string
+	Language   string
+	License    string
+	Topic      string
+	Owner      string
+	Visibility string
+}
+
+// ParseQuery splits s into bare terms and known qualifiers. Matching is case
+// insensitive; unknown qualifiers are kept as plain terms.
+func ParseQuery(s string) Query {
+	var q Query
+	for _, field := range strings.Fields(strings.ToLower(s)) {
+		key, value, ok := strings.Cut(field, ":")
+		if !ok || value == "" {
+			q.Terms = append(q.Terms, field)
+			continue
+		}
+		switch key {
+		case "lang", "language":
+			q.Language = value
+		case "license":
+			q.License = value
+		case "topic":
+			q.Topic = value
+		case "owner", "user", "org":
+			q.Owner = value
+		case "is":
+			if value == "private" || value == "public" {
+				q.Visibility = value
+			} else {
+				q.Terms = append(q.Terms, field)
+			}
+		default:
+			q.Terms = append(q.Terms, field)
+		}
+	}
+	return q
+}
+
+func (q Query) IsEmpty() bool {
+	return len(q.Terms) == 0 && q.Language == "" && q.License == "" && q.Topic == "" && q.Owner == "" && q.Visibility == ""
+}
+
+// Match reports whether repo satisfies q. details is nil when the repo has
+// not been enriched.
+func (q Query) Match(repo domain.Repo, details *domain.RepoDetails) bool {
+	return q.match(textOf(repo), repo.Private, details)
+}
+
+// repoText holds the lower-cased fields a query looks at, so an Index can
+// compute them once per repo instead of once per keystroke.
+type repoText struct {
+	owner    string
+	language string
+	// text is the full name and description.
+	text string
+}
+
+func textOf(repo domain.Repo) repoText {
+	fullName := strings.ToLower(repo.FullName)
+	owner, _, _ := strings.Cut(fullName, "/")
+	return repoText{
+		owner:    owner,
+		language: strings.ToLower(repo.Language),
+		text:     fullName + " " + strings.ToLower(repo.Description),
+	}
+}
+
+func (q Query) match(t repoText, private bool, details *domain.RepoDetails) bool {
+	if q.Language != "" && t.language != q.Language {
+		return false
+	}
+	if q.Owner != "" && t.owner != q.Owner {
+		return false
+	}
+	switch q.Visibility {
+	case "private":
+		if !private {
+			return false
+		}
+	case "public":
+		if private {
+			return false
+		}
+	}
+	if q.License != "" && (details == nil || !strings.Contains(strings.ToLower(details.License), q.License)) {
+		return false
+	}
+	if q.Topic != "" && (details == nil || !hasTopic(details.Topics, q.Topic)) {
+		return false
+	}
+	if len(q.Terms) == 0 {
+		return true
+	}
+
+	var topics string
+	if details != nil {
+		topics = strings.ToLower(strings.Join(details.Topics, " "))
+	}
+	for _, term := range q.Terms {
+		if !strings.Contains(t.text, term) && !strings.Contains(topics, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// Filter returns the repos matching q, preserving order. lookup supplies
+// enriched details and may be nil.
+func Filter(repos []domain.Repo, q Query, lookup func(fullName string) (domain.RepoDetails, bool)) []domain.Repo {
+	if q.IsEmpty() {
+		return repos
+	}
+	matched := make([]domain.Repo, 0, len(repos))
+	for _, repo := range repos {
+		var details *domain.RepoDetails
+		if lookup != nil {
+			if d, ok := lookup(repo.FullName); ok {
+				details = &d
+			}
+		}
+		if q.Match(repo, details) {
+			matched = append(matched, repo)
+		}
+	}
+	return matched
+}
+
+func hasTopic(topics []string, topic string) bool {
+	for _, t := range topics {
+		if strings.ToLower(t) == topic {
+			return true
+		}
+	}
+	return false
+}
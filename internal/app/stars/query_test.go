@@ -0,0 +1,76 @@
+package stars_test
+
+import (
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestParseQuery_QualifiersAndTerms(t *testing.T) {
+	q := stars.ParseQuery("  Container lang:Go license:MIT topic:cli owner:kubernetes is:public foo:bar ")
+
+	testutil.AssertEqual(t, "go", q.Language)
+	testutil.AssertEqual(t, "mit", q.License)
+	testutil.AssertEqual(t, "cli", q.Topic)
+	testutil.AssertEqual(t, "kubernetes", q.Owner)
+	testutil.AssertEqual(t, "public", q.Visibility)
+	testutil.AssertSliceLength(t, q.Terms, 2)
+	testutil.AssertEqual(t, "container", q.Terms[0])
+	testutil.AssertEqual(t, "foo:bar", q.Terms[1])
+}
+
+func TestParseQuery_Empty(t *testing.T) {
+	testutil.AssertTrue(t, stars.ParseQuery("   ").IsEmpty(), "blank query should be empty")
+}
+
+func TestFilter_LanguageAndTerms(t *testing.T) {
+	repos := testdata.SampleRepoList()
+
+	matched := stars.Filter(repos, stars.ParseQuery("lang:go container"), nil)
+
+	testutil.AssertEqual(t, 1, len(matched))
+	testutil.AssertEqual(t, "kubernetes/kubernetes", matched[0].FullName)
+}
+
+func TestFilter_LicenseNeedsEnrichedDetails(t *testing.T) {
+	repos := testdata.SampleRepoList()
+	q := stars.ParseQuery("license:bsd")
+
+	testutil.AssertEqual(t, 0, len(stars.Filter(repos, q, nil)))
+
+	lookup := func(fullName string) (domain.RepoDetails, bool) {
+		if fullName == "golang/go" {
+			return testdata.SampleRepoDetails(), true
+		}
+		return domain.RepoDetails{}, false
+	}
+	matched := stars.Filter(repos, q, lookup)
+
+	testutil.AssertEqual(t, 1, len(matched))
+	testutil.AssertEqual(t, "golang/go", matched[0].FullName)
+}
+
+func TestFilter_TopicsAreSearchedWhenEnriched(t *testing.T) {
+	repos := testdata.SampleRepoList()
+	lookup := func(fullName string) (domain.RepoDetails, bool) {
+		if fullName == "golang/go" {
+			return testdata.SampleRepoDetails(), true
+		}
+		return domain.RepoDetails{}, false
+	}
+
+	testutil.AssertEqual(t, 1, len(stars.Filter(repos, stars.ParseQuery("compiler"), lookup)))
+	testutil.AssertEqual(t, 1, len(stars.Filter(repos, stars.ParseQuery("topic:golang"), lookup)))
+}
+
+func TestFilter_Visibility(t *testing.T) {
+	repos := append(testdata.SampleRepoList(), testdata.SampleRepoPrivate())
+
+	private := stars.Filter(repos, stars.ParseQuery("is:private"), nil)
+
+	testutil.AssertEqual(t, 1, len(private))
+	testutil.AssertEqual(t, 3, len(stars.Filter(repos, stars.ParseQuery("is:public"), nil)))
+}
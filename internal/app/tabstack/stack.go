@@ -0,0 +1,206 @@
+package tabstack
+
+import "slices"
+
+// MaxClosed is how many closed tabs can be reopened.
+const MaxClosed = 20
+
+// MaxHistory is how many visits the back and forward histories each keep.
+const MaxHistory = 50
+
+// State is a Stack's contents, for saving and restoring it.
+type State struct {
+	Tabs    []string `json:"tabs"`
+	Active  string   `json:"active"`
+	Back    []string `json:"back,omitempty"`
+	Forward []string `json:"forward,omitempty"`
+	Closed  []string `json:"closed,omitempty"`
+}
+
+// Stack tracks the open tabs of a tabbed window: their order, the active
+// one, a back/forward history of the tabs visited and the tabs closed most
+// recently. History entries whose tab has since closed are skipped. A Stack
+// is not safe for concurrent use.
+type Stack struct {
+	tabs    []string
+	active  string
+	back    []string
+	forward []string
+	closed  []string
+}
+
+// Tabs returns the open tabs in order.
+func (s *Stack) Tabs() []string {
+	return slices.Clone(s.tabs)
+}
+
+// Active returns the active tab, or "" when none is open.
+func (s *Stack) Active() string {
+	return s.active
+}
+
+func (s *Stack) IsOpen(name string) bool {
+	return slices.Contains(s.tabs, name)
+}
+
+// Open activates name, adding a tab for it at the end when it is not open.
+// The previously active tab goes on the back history and the forward
+// history is dropped, as when following a link.
+func (s *Stack) Open(name string) {
+	if name == "" || name == s.active {
+		return
+	}
+	if !s.IsOpen(name) {
+		s.tabs = append(s.tabs, name)
+	}
+	if s.active != "" {
+		push(&s.back, s.active, MaxHistory)
+	}
+	s.forward = nil
+	s.active = name
+}
+
+// Close closes name and returns the tab that is active afterwards: the one
+// that took its place, or the one before it when it was last.
+func (s *Stack) Close(name string) string {
+	i := slices.Index(s.tabs, name)
+	if i < 0 {
+		return s.active
+	}
+	s.tabs = slices.Delete(s.tabs, i, i+1)
+	s.pushClosed(name)
+	if s.active == name {
+		s.active = ""
+		if len(s.tabs) > 0 {
+			s.active = s.tabs[min(i, len(s.tabs)-1)]
+		}
+	}
+	return s.active
+}
+
+// CloseOthers closes every tab but name, which becomes active, and returns
+// the tabs it closed.
+func (s *Stack) CloseOthers(name string) []string {
+	if !s.IsOpen(name) {
+		return nil
+	}
+	var closed []string
+	for _, tab := range s.tabs {
+		if tab != name {
+			closed = append(closed, tab)
+			s.pushClosed(tab)
+		}
+	}
+	s.tabs = []string{name}
+	if s.active != name {
+		s.Open(name)
+	}
+	return closed
+}
+
+// ReopenClosed opens the most recently closed tab that is not open again.
+func (s *Stack) ReopenClosed() (string, bool) {
+	for len(s.closed) > 0 {
+		name := s.closed[len(s.closed)-1]
+		s.closed = s.closed[:len(s.closed)-1]
+		if !s.IsOpen(name) {
+			s.Open(name)
+			return name, true
+		}
+	}
+	return "", false
+}
+
+func (s *Stack) CanReopen() bool {
+	return slices.ContainsFunc(s.closed, func(name string) bool { return !s.IsOpen(name) })
+}
+
+// Back activates the last visited tab that is still open.
+func (s *Stack) Back() (string, bool) {
+	return s.step(&s.back, &s.forward)
+}
+
+// Forward undoes Back.
+func (s *Stack) Forward() (string, bool) {
+	return s.step(&s.forward, &s.back)
+}
+
+func (s *Stack) CanGoBack() bool {
+	return s.reachable(s.back)
+}
+
+func (s *Stack) CanGoForward() bool {
+	return s.reachable(s.forward)
+}
+
+func (s *Stack) step(from, to *[]string) (string, bool) {
+	for len(*from) > 0 {
+		name := (*from)[len(*from)-1]
+		*from = (*from)[:len(*from)-1]
+		if name == s.active || !s.IsOpen(name) {
+			continue
+		}
+		if s.active != "" {
+			push(to, s.active, MaxHistory)
+		}
+		s.active = name
+		return name, true
+	}
+	return "", false
+}
+
+func (s *Stack) reachable(history []string) bool {
+	return slices.ContainsFunc(history, func(name string) bool {
+		return name != s.active && s.IsOpen(name)
+	})
+}
+
+func (s *Stack) pushClosed(name string) {
+	push(&s.closed, name, MaxClosed)
+}
+
+// push appends name to list, dropping the oldest entries beyond limit.
+func push(list *[]string, name string, limit int) {
+	*list = append(*list, name)
+	if len(*list) > limit {
+		*list = slices.Delete(*list, 0, len(*list)-limit)
+	}
+}
+
+// State returns a copy of the stack's contents.
+func (s *Stack) State() State {
+	return State{
+		Tabs:    slices.Clone(s.tabs),
+		Active:  s.active,
+		Back:    slices.Clone(s.back),
+		Forward: slices.Clone(s.forward),
+		Closed:  slices.Clone(s.closed),
+	}
+}
+
+// Restore replaces the stack's contents with state, dropping duplicate or
+// empty tabs and an active tab that is not open.
+func (s *Stack) Restore(state State) {
+	s.tabs = nil
+	for _, tab := range state.Tabs {
+		if tab != "" && !s.IsOpen(tab) {
+			s.tabs = append(s.tabs, tab)
+		}
+	}
+	s.active = ""
+	if s.IsOpen(state.Active) {
+		s.active = state.Active
+	} else if len(s.tabs) > 0 {
+		s.active = s.tabs[0]
+	}
+	s.back, s.forward, s.closed = nil, nil, nil
+	for _, name := range state.Back {
+		push(&s.back, name, MaxHistory)
+	}
+	for _, name := range state.Forward {
+		push(&s.forward, name, MaxHistory)
+	}
+	for _, name := range state.Closed {
+		s.pushClosed(name)
+	}
+}
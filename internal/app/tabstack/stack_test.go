@@ -0,0 +1,136 @@
+package tabstack_test
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/tabstack"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestStack_BackAndForwardAcrossTabs(t *testing.T) {
+	var s tabstack.Stack
+	s.Open("a")
+	s.Open("b")
+	s.Open("c")
+	s.Open("a")
+
+	testutil.AssertEqual(t, 3, len(s.Tabs()))
+	name, ok := s.Back()
+	testutil.AssertTrue(t, ok, "back should work")
+	testutil.AssertEqual(t, "c", name)
+	name, _ = s.Back()
+	testutil.AssertEqual(t, "b", name)
+	name, _ = s.Forward()
+	testutil.AssertEqual(t, "c", name)
+
+	s.Open("d")
+	testutil.AssertFalse(t, s.CanGoForward(), "opening a tab drops the forward history")
+	testutil.AssertTrue(t, s.CanGoBack(), "back history remains")
+}
+
+func TestStack_BackSkipsClosedTabs(t *testing.T) {
+	var s tabstack.Stack
+	s.Open("a")
+	s.Open("b")
+	s.Open("c")
+	s.Close("b")
+
+	name, ok := s.Back()
+	testutil.AssertTrue(t, ok, "a is still open")
+	testutil.AssertEqual(t, "a", name)
+	testutil.AssertFalse(t, s.CanGoBack(), "nothing open before a")
+}
+
+func TestStack_CloseActivatesNeighbour(t *testing.T) {
+	var s tabstack.Stack
+	s.Open("a")
+	s.Open("b")
+	s.Open("c")
+
+	testutil.AssertEqual(t, "b", s.Close("c"))
+	testutil.AssertEqual(t, "b", s.Active())
+	s.Open("a")
+	testutil.AssertEqual(t, "b", s.Close("a"))
+	testutil.AssertEqual(t, "", s.Close("b"))
+	testutil.AssertEqual(t, 0, len(s.Tabs()))
+}
+
+func TestStack_CloseOthersAndReopen(t *testing.T) {
+	var s tabstack.Stack
+	for _, name := range []string{"a", "b", "c", "d"} {
+		s.Open(name)
+	}
+
+	closed := s.CloseOthers("b")
+	testutil.AssertEqual(t, 3, len(closed))
+	testutil.AssertEqual(t, "b", s.Active())
+	testutil.AssertEqual(t, 1, len(s.Tabs()))
+
+	name, ok := s.ReopenClosed()
+	testutil.AssertTrue(t, ok, "a closed tab should reopen")
+	testutil.AssertEqual(t, "d", name)
+	testutil.AssertEqual(t, "d", s.Active())
+
+	s.ReopenClosed()
+	s.ReopenClosed()
+	testutil.AssertFalse(t, s.CanReopen(), "every closed tab is open again")
+	_, ok = s.ReopenClosed()
+	testutil.AssertFalse(t, ok, "nothing left to reopen")
+}
+
+func TestStack_KeepsLimitedClosedTabs(t *testing.T) {
+	var s tabstack.Stack
+	for i := 0; i < tabstack.MaxClosed+5; i++ {
+		name := fmt.Sprintf("owner/repo%d", i)
+		s.Open(name)
+		s.Close(name)
+	}
+	testutil.AssertEqual(t, tabstack.MaxClosed, len(s.State().Closed))
+}
+
+func TestStack_KeepsLimitedHistory(t *testing.T) {
+	var s tabstack.Stack
+	for i := 0; i < tabstack.MaxHistory+10; i++ {
+		s.Open(fmt.Sprintf("owner/repo%d", i))
+	}
+	testutil.AssertEqual(t, tabstack.MaxHistory, len(s.State().Back))
+
+	for s.CanGoBack() {
+		s.Back()
+	}
+	testutil.AssertEqual(t, "owner/repo9", s.Active())
+	testutil.AssertEqual(t, tabstack.MaxHistory, len(s.State().Forward))
+
+	var restored tabstack.Stack
+	state := s.State()
+	state.Forward = append(state.Forward, state.Forward...)
+	restored.Restore(state)
+	testutil.AssertEqual(t, tabstack.MaxHistory, len(restored.State().Forward))
+}
+
+func TestStack_StateRoundTrip(t *testing.T) {
+	var s tabstack.Stack
+	s.Open("a")
+	s.Open("b")
+	s.Open("c")
+	s.Close("a")
+	s.Back()
+
+	data, err := json.Marshal(s.State())
+	testutil.AssertNoError(t, err)
+	var state tabstack.State
+	testutil.AssertNoError(t, json.Unmarshal(data, &state))
+
+	var restored tabstack.Stack
+	restored.Restore(state)
+	testutil.AssertTrue(t, slices.Equal(s.Tabs(), restored.Tabs()), "tabs should survive")
+	testutil.AssertEqual(t, s.Active(), restored.Active())
+	testutil.AssertEqual(t, s.CanGoForward(), restored.CanGoForward())
+
+	restored.Restore(tabstack.State{Tabs: []string{"x", "x", ""}, Active: "gone"})
+	testutil.AssertEqual(t, 1, len(restored.Tabs()))
+	testutil.AssertEqual(t, "x", restored.Active())
+}
@@ -0,0 +1,142 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+)
+
+// MemberLister resolves an org to its public member logins; github.Client
+// implements it.
+type MemberLister interface {
+	ListOrgMembers(ctx context.Context, org, token string) ([]string, error)
+}
+
+// Progress describes how many members have been loaded so far.
+type Progress struct {
+	Done   int
+	Failed int
+	Total  int
+}
+
+// Builder loads every member's stars through the shared rate-limit gate and
+// merges them into a Catalog, which is saved to the store when one is set.
+type Builder struct {
+	Stars   stars.Loader
+	Members MemberLister
+	Gate    *ratelimit.Gate
+	Workers int
+	Store   *Store
+	// IncludePrivate keeps private repos, which the token's own account
+	// lists, in the catalog and the saved file. They are dropped by default.
+	IncludePrivate bool
+	// Now returns the current time; tests override it.
+	Now func() time.Time
+}
+
+// Build merges the stars of every member of t. Members that fail to load are
+// listed in Catalog.Failed; the build only fails when none could be loaded.
+func (b *Builder) Build(ctx context.Context, t Team, token string, onProgress func(Progress)) (Catalog, error) {
+	members, err := b.resolve(ctx, t, token)
+	if err != nil {
+		return Catalog{}, err
+	}
+
+	var (
+		mu       sync.Mutex
+		progress = Progress{Total: len(members)}
+		loaded   = map[string]compare.UserStars{}
+		failed   []string
+	)
+	report(onProgress, progress)
+	err = ratelimit.ForEach(ctx, b.Gate, b.Workers, members, func(ctx context.Context, member string) {
+		repos, err := b.Stars.LoadStarred(ctx, member, token, 100)
+		if ctx.Err() != nil {
+			return
+		}
+
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			failed = append(failed, member)
+			progress.Failed++
+		} else {
+			if !b.IncludePrivate {
+				repos = compare.PublicOnly(repos)
+			}
+			loaded[member] = compare.UserStars{Username: member, Repos: repos}
+			progress.Done++
+		}
+		report(onProgress, progress)
+	})
+	if err != nil {
+		return Catalog{}, err
+	}
+	if len(loaded) == 0 {
+		return Catalog{}, fmt.Errorf("could not load stars for any of %d members", len(members))
+	}
+
+	users := make([]compare.UserStars, 0, len(loaded))
+	names := make([]string, 0, len(loaded))
+	for _, member := range members {
+		if user, ok := loaded[member]; ok {
+			users = append(users, user)
+			names = append(names, member)
+		}
+	}
+	catalog := Catalog{
+		Team:    t,
+		Members: names,
+		Failed:  failed,
+		BuiltAt: b.now(),
+		Entries: compare.Compare(users).Union,
+	}
+	if b.Store != nil {
+		if err := b.Store.SaveCatalog(catalog); err != nil {
+			return catalog, err
+		}
+	}
+	return catalog, nil
+}
+
+// resolve lists the explicit usernames followed by the org members, without
+// duplicates.
+func (b *Builder) resolve(ctx context.Context, t Team, token string) ([]string, error) {
+	all := append([]string(nil), t.Usernames...)
+	if org := strings.TrimSpace(t.Org); org != "" {
+		if b.Members == nil {
+			return nil, errors.New("org members cannot be listed")
+		}
+		logins, err := b.Members.ListOrgMembers(ctx, org, token)
+		if err != nil {
+			return nil, fmt.Errorf("list members of %s: %w", org, err)
+		}
+		all = append(all, logins...)
+	}
+
+	members := compare.UniqueNames(all)
+	if len(members) == 0 {
+		return nil, errors.New("team has no members")
+	}
+	return members, nil
+}
+
+func (b *Builder) now() time.Time {
+	if b.Now != nil {
+		return b.Now()
+	}
+	return time.Now()
+}
+
+func report(onProgress func(Progress), progress Progress) {
+	if onProgress != nil {
+		onProgress(progress)
+	}
+}
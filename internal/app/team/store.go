@@ -0,0 +1,143 @@
+package team
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+)
+
+// Store keeps team definitions in teams.json and the last built catalog of
+// each team in catalogs/<name>.json under one directory.
+type Store struct {
+	dir string
+
+	mu sync.Mutex
+}
+
+// NewStore creates a store under dir. An empty dir disables persistence.
+func NewStore(dir string) *Store {
+	return &Store{dir: dir}
+}
+
+// Teams returns the saved team definitions in the order they were created.
+func (s *Store) Teams() ([]Team, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.readTeams()
+}
+
+// SaveTeam adds t, replacing a saved team with the same name.
+func (s *Store) SaveTeam(t Team) error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return errors.New("team name is required")
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	teams, err := s.readTeams()
+	if err != nil {
+		return err
+	}
+	replaced := false
+	for i, existing := range teams {
+		if strings.EqualFold(existing.Name, t.Name) {
+			teams[i] = t
+			replaced = true
+		}
+	}
+	if !replaced {
+		teams = append(teams, t)
+	}
+	return s.writeJSON(filepath.Join(s.dir, "teams.json"), teams)
+}
+
+// DeleteTeam removes the definition and the saved catalog of name.
+func (s *Store) DeleteTeam(name string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	teams, err := s.readTeams()
+	if err != nil {
+		return err
+	}
+	kept := teams[:0]
+	for _, t := range teams {
+		if !strings.EqualFold(t.Name, name) {
+			kept = append(kept, t)
+		}
+	}
+	if err := s.writeJSON(filepath.Join(s.dir, "teams.json"), kept); err != nil {
+		return err
+	}
+	if s.dir == "" {
+		return nil
+	}
+	if err := os.Remove(s.catalogPath(name)); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return err
+	}
+	return nil
+}
+
+func (s *Store) SaveCatalog(catalog Catalog) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.writeJSON(s.catalogPath(catalog.Team.Name), catalog)
+}
+
+// LoadCatalog returns the last catalog built for the team called name.
+func (s *Store) LoadCatalog(name string) (Catalog, bool, error) {
+	if s.dir == "" {
+		return Catalog{}, false, nil
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	data, err := os.ReadFile(s.catalogPath(name))
+	if errors.Is(err, fs.ErrNotExist) {
+		return Catalog{}, false, nil
+	}
+	if err != nil {
+		return Catalog{}, false, err
+	}
+	var catalog Catalog
+	if err := json.Unmarshal(data, &catalog); err != nil {
+		return Catalog{}, false, err
+	}
+	return catalog, true, nil
+}
+
+func (s *Store) readTeams() ([]Team, error) {
+	if s.dir == "" {
+		return nil, nil
+	}
+	data, err := os.ReadFile(filepath.Join(s.dir, "teams.json"))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var teams []Team
+	if err := json.Unmarshal(data, &teams); err != nil {
+		return nil, err
+	}
+	return teams, nil
+}
+
+func (s *Store) writeJSON(path string, v any) error {
+	if s.dir == "" {
+		return nil
+	}
+	return filestore.WriteJSON(path, v, 0o600)
+}
+
+func (s *Store) catalogPath(name string) string {
+	key := strings.ToLower(strings.TrimSpace(name))
+	return filepath.Join(s.dir, "catalogs", url.PathEscape(key)+".json")
+}
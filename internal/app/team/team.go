@@ -0,0 +1,75 @@
+package team
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+)
+
+// Team is a named group of users, listed explicitly, taken from a GitHub org
+// whose public members are fetched at build time, or both.
+type Team struct {
+	Name      string   `json:"name"`
+	Org       string   `json:"org,omitempty"`
+	Usernames []string `json:"usernames,omitempty"`
+}
+
+// Catalog is the merged starred list of a team. Each entry records which
+// members starred the repo; Failed lists members whose stars could not be
+// loaded.
+type Catalog struct {
+	Team    Team            `json:"team"`
+	Members []string        `json:"members"`
+	Failed  []string        `json:"failed,omitempty"`
+	BuiltAt time.Time       `json:"built_at"`
+	Entries []compare.Entry `json:"entries"`
+}
+
+// SortKey selects the order of catalog entries.
+type SortKey int
+
+const (
+	// SortTeamStars puts the repos starred by the most members first.
+	SortTeamStars SortKey = iota
+	// SortStars orders by GitHub stargazer count.
+	SortStars
+	// SortName orders alphabetically by full name.
+	SortName
+)
+
+// SortKeys lists the keys in the order the UI offers them.
+var SortKeys = []SortKey{SortTeamStars, SortStars, SortName}
+
+func (k SortKey) String() string {
+	switch k {
+	case SortStars:
+		return "GitHub stars"
+	case SortName:
+		return "Name"
+	default:
+		return "Team stars"
+	}
+}
+
+// SortEntries orders entries in place. Ties fall back to team stars, then
+// name, so the order is stable across rebuilds.
+func SortEntries(entries []compare.Entry, key SortKey) {
+	name := func(e compare.Entry) string { return strings.ToLower(e.Repo.FullName) }
+	sort.SliceStable(entries, func(i, j int) bool {
+		a, b := entries[i], entries[j]
+		switch key {
+		case SortStars:
+			if a.Repo.Stars != b.Repo.Stars {
+				return a.Repo.Stars > b.Repo.Stars
+			}
+		case SortName:
+			return name(a) < name(b)
+		}
+		if len(a.StarredBy) != len(b.StarredBy) {
+			return len(a.StarredBy) > len(b.StarredBy)
+		}
+		return name(a) < name(b)
+	})
+}
@@ -0,0 +1,133 @@
+package team_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/team"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+var builtAt = time.Date(2024, 3, 4, 9, 0, 0, 0, time.UTC)
+
+func newStarsService() *stars.MockService {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		list := testdata.SampleRepoList()
+		switch username {
+		case "ghost":
+			return nil, errors.New("github api error: 404 Not Found: Not Found")
+		case "bob":
+			return list[2:], nil
+		case "carol":
+			return append(list[:1:1], testdata.SampleRepoPrivate()), nil
+		}
+		return list, nil
+	}
+	return mockSvc
+}
+
+func TestBuilder_MergesUsernamesAndOrgMembers(t *testing.T) {
+	mockClient := github.NewMockClient()
+	mockClient.ListOrgMembersFunc = func(ctx context.Context, org, token string) ([]string, error) {
+		testutil.AssertEqual(t, "acme", org)
+		return []string{"bob", "Alice", "ghost"}, nil
+	}
+	store := team.NewStore(t.TempDir())
+	builder := &team.Builder{
+		Stars:   newStarsService(),
+		Members: mockClient,
+		Store:   store,
+		Now:     func() time.Time { return builtAt },
+	}
+	var last team.Progress
+
+	catalog, err := builder.Build(context.Background(), team.Team{Name: "Acme", Org: "acme", Usernames: []string{"alice"}}, "", func(p team.Progress) {
+		last = p
+	})
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, team.Progress{Done: 2, Failed: 1, Total: 3}, last)
+	testutil.AssertEqual(t, 2, len(catalog.Members))
+	testutil.AssertEqual(t, "alice", catalog.Members[0])
+	testutil.AssertEqual(t, 1, len(catalog.Failed))
+	testutil.AssertEqual(t, 3, len(catalog.Entries))
+	testutil.AssertEqual(t, "microsoft/vscode", catalog.Entries[0].Repo.FullName)
+	testutil.AssertEqual(t, 2, len(catalog.Entries[0].StarredBy))
+
+	saved, ok, err := store.LoadCatalog("acme")
+	testutil.AssertNoError(t, err)
+	testutil.AssertTrue(t, ok, "catalog should be saved")
+	testutil.AssertEqual(t, builtAt, saved.BuiltAt)
+	testutil.AssertEqual(t, 3, len(saved.Entries))
+}
+
+func TestBuilder_FailsWithoutMembers(t *testing.T) {
+	builder := &team.Builder{Stars: newStarsService()}
+
+	_, err := builder.Build(context.Background(), team.Team{Name: "empty"}, "", nil)
+	testutil.AssertError(t, err)
+
+	_, err = builder.Build(context.Background(), team.Team{Name: "ghosts", Usernames: []string{"ghost"}}, "", nil)
+	testutil.AssertError(t, err)
+}
+
+func TestBuilder_KeepsPrivateReposOutOfTheCatalog(t *testing.T) {
+	store := team.NewStore(t.TempDir())
+	builder := &team.Builder{Stars: newStarsService(), Store: store}
+
+	catalog, err := builder.Build(context.Background(), team.Team{Name: "solo", Usernames: []string{"carol"}}, "", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(catalog.Entries))
+	saved, _, err := store.LoadCatalog("solo")
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(saved.Entries))
+
+	builder.IncludePrivate = true
+	catalog, err = builder.Build(context.Background(), team.Team{Name: "solo", Usernames: []string{"carol"}}, "", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(catalog.Entries))
+}
+
+func TestSortEntries(t *testing.T) {
+	entries := []compare.Entry{
+		{Repo: domain.Repo{FullName: "b/small", Stars: 10}, StarredBy: []string{"a", "b"}},
+		{Repo: domain.Repo{FullName: "a/big", Stars: 500}, StarredBy: []string{"a"}},
+		{Repo: domain.Repo{FullName: "c/mid", Stars: 100}, StarredBy: []string{"b"}},
+	}
+
+	team.SortEntries(entries, team.SortStars)
+	testutil.AssertEqual(t, "a/big", entries[0].Repo.FullName)
+
+	team.SortEntries(entries, team.SortTeamStars)
+	testutil.AssertEqual(t, "b/small", entries[0].Repo.FullName)
+	testutil.AssertEqual(t, "a/big", entries[1].Repo.FullName)
+
+	team.SortEntries(entries, team.SortName)
+	testutil.AssertEqual(t, "c/mid", entries[2].Repo.FullName)
+}
+
+func TestStore_Teams(t *testing.T) {
+	store := team.NewStore(t.TempDir())
+
+	testutil.AssertNoError(t, store.SaveTeam(team.Team{Name: "Core", Usernames: []string{"alice"}}))
+	testutil.AssertNoError(t, store.SaveTeam(team.Team{Name: "Infra", Org: "acme"}))
+	testutil.AssertNoError(t, store.SaveTeam(team.Team{Name: "core", Usernames: []string{"alice", "bob"}}))
+	testutil.AssertError(t, store.SaveTeam(team.Team{Name: " "}))
+
+	teams, err := store.Teams()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(teams))
+	testutil.AssertEqual(t, 2, len(teams[0].Usernames))
+
+	testutil.AssertNoError(t, store.DeleteTeam("INFRA"))
+	teams, _ = store.Teams()
+	testutil.AssertEqual(t, 1, len(teams))
+}
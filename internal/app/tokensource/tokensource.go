@@ -0,0 +1,205 @@
+package tokensource
+
+import (
+	"bufio"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+)
+
+const defaultHost = "github.com"
+
+// Found is a token and a short description of where it came from, suitable
+// for "using token from …".
+type Found struct {
+	Token  string
+	Source string
+}
+
+// Finder looks for an existing token in the places other GitHub tools keep
+// one: environment variables, then the gh CLI's hosts.yml, then ~/.netrc.
+// The zero value reads nothing; use NewFinder for the real environment.
+type Finder struct {
+	Getenv func(string) string
+	// GHConfigDir is the gh CLI config directory holding hosts.yml.
+	GHConfigDir string
+	// Netrc is the path of the netrc file.
+	Netrc string
+}
+
+// NewFinder returns a Finder for the current user, resolving the gh config
+// directory the way gh does.
+func NewFinder() Finder {
+	f := Finder{Getenv: os.Getenv}
+	home, _ := os.UserHomeDir()
+
+	switch {
+	case os.Getenv("GH_CONFIG_DIR") != "":
+		f.GHConfigDir = os.Getenv("GH_CONFIG_DIR")
+	case os.Getenv("XDG_CONFIG_HOME") != "":
+		f.GHConfigDir = filepath.Join(os.Getenv("XDG_CONFIG_HOME"), "gh")
+	case runtime.GOOS == "windows" && os.Getenv("AppData") != "":
+		f.GHConfigDir = filepath.Join(os.Getenv("AppData"), "GitHub CLI")
+	case home != "":
+		f.GHConfigDir = filepath.Join(home, ".config", "gh")
+	}
+
+	switch {
+	case os.Getenv("NETRC") != "":
+		f.Netrc = os.Getenv("NETRC")
+	case home != "":
+		name := ".netrc"
+		if runtime.GOOS == "windows" {
+			name = "_netrc"
+		}
+		f.Netrc = filepath.Join(home, name)
+	}
+	return f
+}
+
+// Find returns the first token found for host. An empty host means
+// github.com.
+func (f Finder) Find(host string) (Found, bool) {
+	host = strings.ToLower(strings.TrimSpace(host))
+	if host == "" {
+		host = defaultHost
+	}
+	if found, ok := f.fromEnv(host); ok {
+		return found, true
+	}
+	if token := f.fromGHConfig(host); token != "" {
+		return Found{Token: token, Source: "gh CLI"}, true
+	}
+	if token := f.fromNetrc(host); token != "" {
+		return Found{Token: token, Source: ".netrc"}, true
+	}
+	return Found{}, false
+}
+
+func (f Finder) fromEnv(host string) (Found, bool) {
+	if f.Getenv == nil {
+		return Found{}, false
+	}
+	names := []string{"GH_TOKEN", "GITHUB_TOKEN"}
+	if host != defaultHost {
+		names = []string{"GH_ENTERPRISE_TOKEN", "GITHUB_ENTERPRISE_TOKEN"}
+	}
+	for _, name := range names {
+		if token := strings.TrimSpace(f.Getenv(name)); token != "" {
+			return Found{Token: token, Source: name}, true
+		}
+	}
+	return Found{}, false
+}
+
+// fromGHConfig reads oauth_token from host's section of hosts.yml. Newer gh
+// versions keep the token in the OS keyring instead, in which case there is
+// nothing to find here.
+func (f Finder) fromGHConfig(host string) string {
+	if f.GHConfigDir == "" {
+		return ""
+	}
+	file, err := os.Open(filepath.Join(f.GHConfigDir, "hosts.yml"))
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+	return parseHostsYAML(bufio.NewScanner(file), host)
+}
+
+// parseHostsYAML understands the flat layout gh writes:
+//
+//	github.com:
+//	    user: octocat
+//	    oauth_token: gho_…
+//
+// Only keys directly under the host are considered, so tokens nested under
+// "users:" are ignored in favour of the active one.
+func parseHostsYAML(scanner *bufio.Scanner, host string) string {
+	inHost := false
+	childIndent := -1
+	for scanner.Scan() {
+		line := scanner.Text()
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " \t"))
+		key, value, _ := strings.Cut(trimmed, ":")
+		key = unquote(strings.TrimSpace(key))
+		value = unquote(strings.TrimSpace(value))
+
+		if indent == 0 {
+			inHost = strings.EqualFold(key, host)
+			childIndent = -1
+			continue
+		}
+		if !inHost {
+			continue
+		}
+		if childIndent < 0 {
+			childIndent = indent
+		}
+		if indent == childIndent && key == "oauth_token" && value != "" {
+			return value
+		}
+	}
+	return ""
+}
+
+func (f Finder) fromNetrc(host string) string {
+	if f.Netrc == "" {
+		return ""
+	}
+	data, err := os.ReadFile(f.Netrc)
+	if err != nil {
+		return ""
+	}
+	return parseNetrc(string(data), host)
+}
+
+// parseNetrc returns the password of the machine entry for host or its API
+// host. The default entry is ignored: its password was never meant for
+// GitHub and must not be sent there as a token.
+func parseNetrc(data, host string) string {
+	machines := map[string]bool{host: true, "api." + host: true}
+	if host == defaultHost {
+		machines["api.github.com"] = true
+	}
+
+	fields := strings.Fields(data)
+	matched := false
+	for i := 0; i < len(fields); i++ {
+		switch fields[i] {
+		case "machine":
+			matched = false
+			if i+1 < len(fields) {
+				i++
+				matched = machines[strings.ToLower(fields[i])]
+			}
+		case "default":
+			matched = false
+		case "macdef":
+			// Macro bodies run to the next blank line, which Fields has
+			// already lost; stop rather than misread them.
+			return ""
+		case "password":
+			if i+1 >= len(fields) {
+				continue
+			}
+			i++
+			if matched {
+				return fields[i]
+			}
+		}
+	}
+	return ""
+}
+
+func unquote(s string) string {
+	if len(s) >= 2 && (s[0] == '"' || s[0] == '\'') && s[len(s)-1] == s[0] {
+		return s[1 : len(s)-1]
+	}
+	return s
+}
@@ -0,0 +1,91 @@
+package tokensource_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/tokensource"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+const hostsYAML = `github.com:
+    users:
+        octocat:
+            oauth_token: gho_nested
+    user: octocat
+    oauth_token: gho_cli
+    git_protocol: https
+ghe.example.com:
+    oauth_token: "gho_enterprise"
+`
+
+const netrc = `machine example.com login a password nope
+machine api.github.com
+  login octocat
+  password ghp_netrc
+default login anon password ghp_default
+`
+
+func writeFile(t *testing.T, dir, name, content string) string {
+	t.Helper()
+	path := filepath.Join(dir, name)
+	testutil.AssertNoError(t, os.WriteFile(path, []byte(content), 0o600))
+	return path
+}
+
+func env(vars map[string]string) func(string) string {
+	return func(key string) string { return vars[key] }
+}
+
+func TestFinder_Precedence(t *testing.T) {
+	dir := t.TempDir()
+	writeFile(t, dir, "hosts.yml", hostsYAML)
+	finder := tokensource.Finder{
+		Getenv:      env(map[string]string{"GITHUB_TOKEN": "ghp_env"}),
+		GHConfigDir: dir,
+		Netrc:       writeFile(t, dir, "netrc", netrc),
+	}
+
+	found, ok := finder.Find("")
+	testutil.AssertTrue(t, ok, "env token should be found")
+	testutil.AssertEqual(t, tokensource.Found{Token: "ghp_env", Source: "GITHUB_TOKEN"}, found)
+
+	finder.Getenv = env(nil)
+	found, _ = finder.Find("github.com")
+	testutil.AssertEqual(t, tokensource.Found{Token: "gho_cli", Source: "gh CLI"}, found)
+
+	found, _ = finder.Find("ghe.example.com")
+	testutil.AssertEqual(t, "gho_enterprise", found.Token)
+
+	finder.GHConfigDir = ""
+	found, _ = finder.Find("github.com")
+	testutil.AssertEqual(t, tokensource.Found{Token: "ghp_netrc", Source: ".netrc"}, found)
+
+	_, ok = finder.Find("other.example.com")
+	testutil.AssertFalse(t, ok, "the netrc default entry should be ignored")
+}
+
+func TestFinder_EnterpriseEnv(t *testing.T) {
+	finder := tokensource.Finder{Getenv: env(map[string]string{
+		"GH_TOKEN":            "ghp_public",
+		"GH_ENTERPRISE_TOKEN": "ghp_enterprise",
+	})}
+
+	found, _ := finder.Find("github.com")
+	testutil.AssertEqual(t, "ghp_public", found.Token)
+	found, _ = finder.Find("ghe.example.com")
+	testutil.AssertEqual(t, "ghp_enterprise", found.Token)
+}
+
+func TestFinder_NothingFound(t *testing.T) {
+	dir := t.TempDir()
+	finder := tokensource.Finder{
+		Getenv:      env(nil),
+		GHConfigDir: dir,
+		Netrc:       filepath.Join(dir, "missing"),
+	}
+
+	_, ok := finder.Find("github.com")
+	testutil.AssertFalse(t, ok, "no token expected")
+}
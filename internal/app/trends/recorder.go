@@ -0,0 +1,35 @@
+package trends
+
+import (
+	"context"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// Recorder decorates a stars.Loader and records a star-count sample for every
+// repo of each successful sync. Persisting is best effort and never fails the
+// load itself.
+type Recorder struct {
+	Next  stars.Loader
+	Store *Store
+	// Now returns the current time; tests override it.
+	Now func() time.Time
+}
+
+var _ stars.Loader = (*Recorder)(nil)
+
+func (r *Recorder) LoadStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+	repos, err := r.Next.LoadStarred(ctx, username, token, perPage)
+	if err != nil {
+		return repos, err
+	}
+	now := time.Now
+	if r.Now != nil {
+		now = r.Now
+	}
+	r.Store.Record(repos, now())
+	_ = r.Store.Save()
+	return repos, nil
+}
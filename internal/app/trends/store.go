@@ -0,0 +1,139 @@
+package trends
+
+import (
+	"encoding/json"
+	"errors"
+	"io/fs"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/filestore"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// maxSamples bounds the history kept per repo. Samples are daily, so this is
+// a bit over a year.
+const maxSamples = 400
+
+// Sample is the star and fork count of a repo at one sync.
+type Sample struct {
+	At    time.Time `json:"at"`
+	Stars int       `json:"stars"`
+	Forks int       `json:"forks"`
+}
+
+// Store keeps star-count samples keyed by repo full name and persists them as
+// a single JSON file. At most one sample per repo per UTC day is kept; a later
+// sync on the same day replaces the earlier one.
+type Store struct {
+	path string
+
+	mu      sync.RWMutex
+	samples map[string][]Sample
+}
+
+// NewStore creates a store whose samples are saved to path. With an empty
+// path growth is only tracked for the current session.
+func NewStore(path string) *Store {
+	return &Store{path: path, samples: map[string][]Sample{}}
+}
+
+// Load reads previously saved samples. A missing file is not an error.
+func (s *Store) Load() error {
+	if s.path == "" {
+		return nil
+	}
+	data, err := os.ReadFile(s.path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	samples := map[string][]Sample{}
+	if err := json.Unmarshal(data, &samples); err != nil {
+		return err
+	}
+
+	s.mu.Lock()
+	s.samples = samples
+	s.mu.Unlock()
+	return nil
+}
+
+// Save writes the samples of every repo, so growth survives a restart.
+func (s *Store) Save() error {
+	if s.path == "" {
+		return nil
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return filestore.WriteJSON(s.path, s.samples, 0o600)
+}
+
+// Record adds a sample taken at the given time for every repo.
+func (s *Store) Record(repos []domain.Repo, at time.Time) {
+	at = at.UTC()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, repo := range repos {
+		key := storeKey(repo.FullName)
+		sample := Sample{At: at, Stars: repo.Stars, Forks: repo.Forks}
+		series := s.samples[key]
+		if n := len(series); n > 0 && sameDay(series[n-1].At, at) {
+			series[n-1] = sample
+		} else {
+			series = append(series, sample)
+		}
+		if len(series) > maxSamples {
+			series = append([]Sample(nil), series[len(series)-maxSamples:]...)
+		}
+		s.samples[key] = series
+	}
+}
+
+// Samples returns the recorded samples for fullName, oldest first.
+func (s *Store) Samples(fullName string) []Sample {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.samples[storeKey(fullName)]
+	out := make([]Sample, len(series))
+	copy(out, series)
+	return out
+}
+
+// Delta reports how many stars fullName gained over the window ending at now.
+// The baseline is the newest sample at least window old, or the oldest sample
+// when history is shorter than the window; since is that baseline's time.
+// ok is false until at least two samples exist.
+func (s *Store) Delta(fullName string, window time.Duration, now time.Time) (delta int, since time.Time, ok bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	series := s.samples[storeKey(fullName)]
+	if len(series) < 2 {
+		return 0, time.Time{}, false
+	}
+	cutoff := now.Add(-window)
+	base := series[0]
+	for _, sample := range series[:len(series)-1] {
+		if sample.At.After(cutoff) {
+			break
+		}
+		base = sample
+	}
+	latest := series[len(series)-1]
+	return latest.Stars - base.Stars, base.At, true
+}
+
+func sameDay(a, b time.Time) bool {
+	ay, am, ad := a.UTC().Date()
+	by, bm, bd := b.UTC().Date()
+	return ay == by && am == bm && ad == bd
+}
+
+func storeKey(fullName string) string {
+	return strings.ToLower(strings.TrimSpace(fullName))
+}
@@ -0,0 +1,94 @@
+package trends_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/trends"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+var day0 = time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+
+func repoWithStars(stars int) []domain.Repo {
+	repo := testdata.SampleRepo()
+	repo.Stars = stars
+	return []domain.Repo{repo}
+}
+
+func TestStore_DeltaUsesSampleAtLeastWindowOld(t *testing.T) {
+	store := trends.NewStore("")
+	store.Record(repoWithStars(100), day0)
+	store.Record(repoWithStars(110), day0.AddDate(0, 0, 10))
+	store.Record(repoWithStars(150), day0.AddDate(0, 0, 35))
+	now := day0.AddDate(0, 0, 45)
+	store.Record(repoWithStars(180), now)
+
+	delta, since, ok := store.Delta("GOLANG/go", 30*24*time.Hour, now)
+
+	testutil.AssertTrue(t, ok, "delta should be known")
+	testutil.AssertEqual(t, 70, delta)
+	testutil.AssertEqual(t, day0.AddDate(0, 0, 10), since)
+}
+
+func TestStore_DeltaFallsBackToOldestSample(t *testing.T) {
+	store := trends.NewStore("")
+	store.Record(repoWithStars(100), day0)
+
+	_, _, ok := store.Delta("golang/go", 30*24*time.Hour, day0)
+	testutil.AssertFalse(t, ok, "one sample is not enough for a delta")
+
+	store.Record(repoWithStars(104), day0.AddDate(0, 0, 3))
+	delta, since, ok := store.Delta("golang/go", 30*24*time.Hour, day0.AddDate(0, 0, 3))
+
+	testutil.AssertTrue(t, ok, "delta should be known")
+	testutil.AssertEqual(t, 4, delta)
+	testutil.AssertEqual(t, day0, since)
+}
+
+func TestStore_KeepsOneSamplePerDay(t *testing.T) {
+	store := trends.NewStore("")
+	store.Record(repoWithStars(100), day0)
+	store.Record(repoWithStars(101), day0.Add(6*time.Hour))
+
+	samples := store.Samples("golang/go")
+
+	testutil.AssertEqual(t, 1, len(samples))
+	testutil.AssertEqual(t, 101, samples[0].Stars)
+}
+
+func TestStore_SaveAndLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "trends.json")
+	store := trends.NewStore(path)
+	store.Record(repoWithStars(100), day0)
+	store.Record(repoWithStars(120), day0.AddDate(0, 0, 1))
+	testutil.AssertNoError(t, store.Save())
+
+	reloaded := trends.NewStore(path)
+	testutil.AssertNoError(t, reloaded.Load())
+
+	samples := reloaded.Samples("golang/go")
+	testutil.AssertEqual(t, 2, len(samples))
+	testutil.AssertEqual(t, 120, samples[1].Stars)
+}
+
+func TestRecorder_RecordsSamplesOnSuccess(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	store := trends.NewStore("")
+	recorder := &trends.Recorder{Next: mockSvc, Store: store, Now: func() time.Time { return day0 }}
+
+	_, err := recorder.LoadStarred(context.Background(), "testuser", "token123", 100)
+
+	testutil.AssertNoError(t, err)
+	samples := store.Samples("kubernetes/kubernetes")
+	testutil.AssertEqual(t, 1, len(samples))
+	testutil.AssertEqual(t, day0, samples[0].At)
+}
@@ -0,0 +1,107 @@
+package viewmode
+
+import "sync"
+
+const modeKey = "layout.mode"
+
+// Mode is how repo details are laid out.
+type Mode string
+
+const (
+	// Windows opens every repo in its own window.
+	Windows Mode = "windows"
+	// Split shows the selected repo next to the stars list.
+	Split Mode = "split"
+	// Tabs opens every repo as a tab of one details window.
+	Tabs Mode = "tabs"
+)
+
+// Modes are the choices offered, in display order.
+var Modes = []Mode{Windows, Split, Tabs}
+
+func (m Mode) String() string {
+	switch m {
+	case Split:
+		return "Split View"
+	case Tabs:
+		return "Tabs"
+	default:
+		return "Separate Windows"
+	}
+}
+
+// Prefs is the subset of fyne.Preferences the store needs.
+type Prefs interface {
+	String(key string) string
+	SetString(key, value string)
+}
+
+// Store keeps the layout mode in the app preferences. A nil *Store always
+// reports Windows.
+type Store struct {
+	prefs Prefs
+
+	mu        sync.Mutex
+	nextID    int
+	listeners map[int]func(Mode)
+}
+
+func NewStore(prefs Prefs) *Store {
+	return &Store{prefs: prefs}
+}
+
+// Mode returns the saved mode; anything unknown reads as Windows.
+func (s *Store) Mode() Mode {
+	if s == nil {
+		return Windows
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	mode := Mode(s.prefs.String(modeKey))
+	for _, known := range Modes {
+		if mode == known {
+			return mode
+		}
+	}
+	return Windows
+}
+
+// SetMode saves mode and tells subscribers when it changed.
+func (s *Store) SetMode(mode Mode) {
+	if s == nil || s.Mode() == mode {
+		return
+	}
+	s.mu.Lock()
+	s.prefs.SetString(modeKey, string(mode))
+	listeners := make([]func(Mode), 0, len(s.listeners))
+	for _, fn := range s.listeners {
+		listeners = append(listeners, fn)
+	}
+	s.mu.Unlock()
+
+	for _, fn := range listeners {
+		fn(mode)
+	}
+}
+
+// Subscribe calls fn with the new mode after every change. The returned func
+// unsubscribes.
+func (s *Store) Subscribe(fn func(Mode)) func() {
+	if s == nil {
+		return func() {}
+	}
+	s.mu.Lock()
+	if s.listeners == nil {
+		s.listeners = map[int]func(Mode){}
+	}
+	id := s.nextID
+	s.nextID++
+	s.listeners[id] = fn
+	s.mu.Unlock()
+
+	return func() {
+		s.mu.Lock()
+		delete(s.listeners, id)
+		s.mu.Unlock()
+	}
+}
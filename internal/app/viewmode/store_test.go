@@ -0,0 +1,35 @@
+package viewmode_test
+
+import (
+	"testing"
+
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func TestStore_DefaultsToWindows(t *testing.T) {
+	var nilStore *viewmode.Store
+	testutil.AssertEqual(t, viewmode.Windows, nilStore.Mode())
+	nilStore.SetMode(viewmode.Split)
+
+	prefs := testutil.NewPrefs()
+	prefs.SetString("layout.mode", "carousel")
+	store := viewmode.NewStore(prefs)
+	testutil.AssertEqual(t, viewmode.Windows, store.Mode())
+}
+
+func TestStore_SetModeNotifiesOnChange(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	store := viewmode.NewStore(prefs)
+	var seen []viewmode.Mode
+	unsubscribe := store.Subscribe(func(m viewmode.Mode) { seen = append(seen, m) })
+
+	store.SetMode(viewmode.Split)
+	store.SetMode(viewmode.Split)
+	testutil.AssertEqual(t, viewmode.Split, viewmode.NewStore(prefs).Mode())
+	testutil.AssertEqual(t, 1, len(seen))
+
+	unsubscribe()
+	store.SetMode(viewmode.Windows)
+	testutil.AssertEqual(t, 1, len(seen))
+}
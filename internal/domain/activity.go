@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// CommitWeek is one week of the /stats/commit_activity series.
+type CommitWeek struct {
+	Week  time.Time
+	Total int
+	Days  [7]int
+}
+
+// CodeFrequencyWeek is one week of the /stats/code_frequency series.
+// Deletions is reported as a positive number of removed lines.
+type CodeFrequencyWeek struct {
+	Week      time.Time
+	Additions int
+	Deletions int
+}
+
+type RepoActivity struct {
+	Commits       []CommitWeek
+	CodeFrequency []CodeFrequencyWeek
+}
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+// RateLimit is the most recent rate-limit budget reported by the API.
+// A zero Limit means no response has been seen yet.
+type RateLimit struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
@@ -0,0 +1,15 @@
+package domain
+
+import "time"
+
+// Release is the newest version of a repo: its latest published release, or
+// its newest tag when the repo only tags. Tag-only versions have no notes and
+// FromTag set.
+type Release struct {
+	Tag         string
+	Name        string
+	Notes       string
+	HTMLURL     string
+	PublishedAt time.Time
+	FromTag     bool
+}
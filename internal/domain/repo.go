@@ -1,8 +1,13 @@
 package domain
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type Repo struct {
+	ID          int64
 	FullName    string
 	HTMLURL     string
 	Description string
@@ -11,6 +16,16 @@ type Repo struct {
 	Forks       int
 	UpdatedAt   time.Time
 	Private     bool
+	Archived    bool
+}
+
+// Key identifies a repo across renames and transfers by its ID, falling back
+// to the lower-cased full name for repos without one.
+func (r Repo) Key() string {
+	if r.ID != 0 {
+		return fmt.Sprintf("id:%d", r.ID)
+	}
+	return "name:" + strings.ToLower(r.FullName)
 }
 
 type RepoDetails struct {
@@ -1,6 +1,7 @@
 package testdata
 
 import (
+	"fmt"
 	"time"
 
 	"github.com/tbxark/gh-stars/internal/domain"
@@ -9,6 +10,7 @@ import (
 // SampleRepo returns a sample Repo for testing
 func SampleRepo() domain.Repo {
 	return domain.Repo{
+		ID:          23096959,
 		FullName:    "golang/go",
 		HTMLURL:     "https://github.com/golang/go",
 		Description: "The Go programming language",
@@ -23,6 +25,7 @@ func SampleRepo() domain.Repo {
 // SampleRepoPrivate returns a private repo for testing
 func SampleRepoPrivate() domain.Repo {
 	return domain.Repo{
+		ID:          1000001,
 		FullName:    "user/private-repo",
 		HTMLURL:     "https://github.com/user/private-repo",
 		Description: "Private repository",
@@ -39,6 +42,7 @@ func SampleRepoList() []domain.Repo {
 	return []domain.Repo{
 		SampleRepo(),
 		{
+			ID:          20580498,
 			FullName:    "kubernetes/kubernetes",
 			HTMLURL:     "https://github.com/kubernetes/kubernetes",
 			Description: "Production-Grade Container Scheduling and Management",
@@ -49,6 +53,7 @@ func SampleRepoList() []domain.Repo {
 			Private:     false,
 		},
 		{
+			ID:          41881900,
 			FullName:    "microsoft/vscode",
 			HTMLURL:     "https://github.com/microsoft/vscode",
 			Description: "Visual Studio Code",
@@ -114,6 +119,28 @@ func SampleRepoDetailsWithNilTopics() domain.RepoDetails {
 	return details
 }
 
+// SampleCommitActivity returns a four-week commit activity series for testing
+func SampleCommitActivity() []domain.CommitWeek {
+	start := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+	return []domain.CommitWeek{
+		{Week: start, Total: 12, Days: [7]int{0, 3, 2, 4, 1, 2, 0}},
+		{Week: start.AddDate(0, 0, 7), Total: 0},
+		{Week: start.AddDate(0, 0, 14), Total: 5, Days: [7]int{0, 1, 1, 1, 1, 1, 0}},
+		{Week: start.AddDate(0, 0, 21), Total: 20, Days: [7]int{2, 4, 4, 4, 4, 2, 0}},
+	}
+}
+
+// SampleCodeFrequency returns a four-week additions/deletions series for testing
+func SampleCodeFrequency() []domain.CodeFrequencyWeek {
+	start := time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC)
+	return []domain.CodeFrequencyWeek{
+		{Week: start, Additions: 420, Deletions: 120},
+		{Week: start.AddDate(0, 0, 7)},
+		{Week: start.AddDate(0, 0, 14), Additions: 35, Deletions: 80},
+		{Week: start.AddDate(0, 0, 21), Additions: 1200, Deletions: 300},
+	}
+}
+
 // InvalidRepoFullName represents invalid repo names for error testing
 var InvalidRepoFullName = []string{
 	"",               // empty
@@ -122,3 +149,23 @@ var InvalidRepoFullName = []string{
 	"no-repo/",       // missing repo
 	"too/many/parts", // too many slashes
 }
+
+// LargeRepoList returns n synthetic repos shaped like a big starred list, for
+// benchmarks.
+func LargeRepoList(n int) []domain.Repo {
+	languages := []string{"Go", "Rust", "TypeScript", "Python", ""}
+	base := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	repos := make([]domain.Repo, n)
+	for i := range repos {
+		repos[i] = domain.Repo{
+			ID:          int64(i + 1),
+			FullName:    fmt.Sprintf("owner%d/project-%d", i%997, i),
+			Description: fmt.Sprintf("A %s tool for container workloads number %d", languages[i%len(languages)], i),
+			Language:    languages[i%len(languages)],
+			Stars:       (i * 7919) % 100000,
+			UpdatedAt:   base.Add(time.Duration(i*37%50000) * time.Hour),
+			Private:     i%50 == 0,
+		}
+	}
+	return repos
+}
@@ -0,0 +1,137 @@
+package domain
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+)
+
+type TokenKind string
+
+const (
+	TokenUnknown     TokenKind = ""
+	TokenClassic     TokenKind = "classic"
+	TokenFineGrained TokenKind = "fine-grained"
+	TokenOAuth       TokenKind = "oauth"
+	TokenApp         TokenKind = "app"
+)
+
+// TokenKindOf tells token kinds apart by their documented prefixes.
+func TokenKindOf(token string) TokenKind {
+	switch {
+	case strings.HasPrefix(token, "github_pat_"):
+		return TokenFineGrained
+	case strings.HasPrefix(token, "ghp_"):
+		return TokenClassic
+	case strings.HasPrefix(token, "gho_"):
+		return TokenOAuth
+	case strings.HasPrefix(token, "ghu_"), strings.HasPrefix(token, "ghs_"):
+		return TokenApp
+	}
+	return TokenUnknown
+}
+
+func (k TokenKind) String() string {
+	if k == TokenUnknown {
+		return "unknown"
+	}
+	return string(k)
+}
+
+// TokenInfo is what a token check learned about a token. Scopes are only
+// reported for classic and OAuth tokens; fine-grained tokens have
+// per-repository permissions the API does not list.
+type TokenInfo struct {
+	Login          string
+	Kind           TokenKind
+	Scopes         []string
+	AcceptedScopes []string
+	// ExpiresAt is zero for tokens without an expiry.
+	ExpiresAt time.Time
+	CheckedAt time.Time
+}
+
+// HasScopes reports whether Scopes is meaningful for this token.
+func (t TokenInfo) HasScopes() bool {
+	return t.Kind == TokenClassic || t.Kind == TokenOAuth || (t.Kind == TokenUnknown && t.Scopes != nil)
+}
+
+// impliedScopes lists the scopes that grant a narrower one.
+var impliedScopes = map[string][]string{
+	"public_repo": {"repo"},
+	"read:org":    {"write:org", "admin:org"},
+	"read:user":   {"user"},
+}
+
+// HasScope reports whether the token grants scope, directly or through a
+// broader scope.
+func (t TokenInfo) HasScope(scope string) bool {
+	if slices.Contains(t.Scopes, scope) {
+		return true
+	}
+	for _, broader := range impliedScopes[scope] {
+		if slices.Contains(t.Scopes, broader) {
+			return true
+		}
+	}
+	return false
+}
+
+// CanStar reports whether starring and unstarring should succeed, with the
+// reason when it will not. Fine-grained tokens are assumed to be able to,
+// since their "Starring" permission cannot be read back.
+func (t TokenInfo) CanStar() (bool, string) {
+	if !t.HasScopes() {
+		return true, ""
+	}
+	if t.HasScope("public_repo") {
+		return true, ""
+	}
+	return false, "starring needs the public_repo or repo scope"
+}
+
+// Warnings lists problems to show before they cause a failed request.
+func (t TokenInfo) Warnings(now time.Time) []string {
+	var warnings []string
+	if !t.ExpiresAt.IsZero() {
+		switch left := t.ExpiresAt.Sub(now); {
+		case left <= 0:
+			warnings = append(warnings, "token expired on "+t.ExpiresAt.Local().Format("2006-01-02"))
+		case left < 7*24*time.Hour:
+			warnings = append(warnings, fmt.Sprintf("token expires in %d days", int(left.Hours()/24)+1))
+		}
+	}
+	if ok, reason := t.CanStar(); !ok {
+		warnings = append(warnings, reason)
+	}
+	if t.HasScopes() && !t.HasScope("repo") {
+		warnings = append(warnings, "private repos are not listed without the repo scope")
+	}
+	if t.Kind == TokenFineGrained {
+		warnings = append(warnings, "fine-grained token: starring needs the Starring (write) user permission")
+	}
+	return warnings
+}
+
+// Summary is a one-line description such as
+// "classic token for octocat · scopes: repo, read:org · expires 2026-01-02".
+func (t TokenInfo) Summary() string {
+	parts := []string{t.Kind.String() + " token"}
+	if t.Login != "" {
+		parts[0] += " for " + t.Login
+	}
+	if t.HasScopes() {
+		if len(t.Scopes) == 0 {
+			parts = append(parts, "no scopes")
+		} else {
+			parts = append(parts, "scopes: "+strings.Join(t.Scopes, ", "))
+		}
+	}
+	if t.ExpiresAt.IsZero() {
+		parts = append(parts, "no expiry")
+	} else {
+		parts = append(parts, "expires "+t.ExpiresAt.Local().Format("2006-01-02"))
+	}
+	return strings.Join(parts, " · ")
+}
@@ -6,47 +6,191 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/url"
+	"strconv"
 	"strings"
+	"sync"
 	"time"
 
+	"github.com/tbxark/gh-stars/internal/app/flight"
 	"github.com/tbxark/gh-stars/internal/domain"
 )
 
 const (
 	defaultBaseURL = "https://api.github.com"
 	userAgent      = "gh-stars-gui"
+
+	defaultStatsRetryDelay  = 2 * time.Second
+	defaultStatsMaxAttempts = 8
+	// DefaultTimeout bounds each request, including reading its body.
+	DefaultTimeout = 20 * time.Second
 )
 
+// ErrStatsPending is returned when GitHub answers 202 Accepted because the
+// requested statistics are still being computed in the background.
+var ErrStatsPending = errors.New("github is still computing statistics, retry later")
+
+// ErrNotModified is returned by conditional requests when the resource still
+// matches the ETag that was sent.
+var ErrNotModified = errors.New("github resource not modified")
+
+// ErrNotFound matches errors for 404 responses and for lookups that found
+// nothing, such as a repo without any tags.
+var ErrNotFound = errors.New("github resource not found")
+
 type Client interface {
 	ListStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error)
 	GetRepoDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error)
+	GetCommitActivity(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error)
+	GetCodeFrequency(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error)
+	// GetLatestRelease and GetLatestTag are conditional: pass the ETag of the
+	// previous response to get ErrNotModified instead of a body when nothing
+	// changed. The returned string is the ETag of this response.
+	GetLatestRelease(ctx context.Context, fullName, token, etag string) (domain.Release, string, error)
+	GetLatestTag(ctx context.Context, fullName, token, etag string) (domain.Release, string, error)
+	ListOrgMembers(ctx context.Context, org, token string) ([]string, error)
+	// GetAuthenticatedUser returns the login the token belongs to.
+	GetAuthenticatedUser(ctx context.Context, token string) (string, error)
+	// CheckToken reports the token's kind, scopes and expiry.
+	CheckToken(ctx context.Context, token string) (domain.TokenInfo, error)
+	// ForgetTokenCheck drops the cached CheckToken result of token.
+	ForgetTokenCheck(token string)
+	RateLimit() domain.RateLimit
 }
 
 type HTTPClient struct {
-	baseURL string
-	http    *http.Client
+	http *http.Client
+
+	// configMu guards the settings that can change while requests run.
+	configMu sync.RWMutex
+	baseURL  string
+	timeout  time.Duration
+	proxy    *url.URL
+
+	statsRetryDelay  time.Duration
+	statsMaxAttempts int
+
+	rateMu    sync.Mutex
+	rateLimit domain.RateLimit
+
+	// tokens caches the result of CheckToken per token, keyed by
+	// tokenKey so the tokens themselves are not kept as map keys.
+	tokenMu sync.Mutex
+	tokens  map[string]domain.TokenInfo
 }
 
+// NewClient uses httpClient, or when it is nil a client of its own whose
+// proxy SetProxy can change.
 func NewClient(httpClient *http.Client) *HTTPClient {
-	if httpClient == nil {
-		httpClient = &http.Client{Timeout: 20 * time.Second}
+	c := &HTTPClient{
+		http:             httpClient,
+		baseURL:          defaultBaseURL,
+		timeout:          DefaultTimeout,
+		statsRetryDelay:  defaultStatsRetryDelay,
+		statsMaxAttempts: defaultStatsMaxAttempts,
+	}
+	if c.http == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		transport.Proxy = c.proxyFor
+		c.http = &http.Client{Transport: transport}
 	}
-	return &HTTPClient{baseURL: defaultBaseURL, http: httpClient}
+	return c
 }
 
+// SetBaseURL points the client at another API root, such as a GitHub
+// Enterprise server or a test server. An empty baseURL restores
+// api.github.com.
+func (c *HTTPClient) SetBaseURL(baseURL string) {
+	baseURL = strings.TrimRight(strings.TrimSpace(baseURL), "/")
+	if baseURL == "" {
+		baseURL = defaultBaseURL
+	}
+	c.configMu.Lock()
+	changed := c.baseURL != baseURL
+	c.baseURL = baseURL
+	c.configMu.Unlock()
+
+	// Token checks were answered by the old server.
+	if changed {
+		c.tokenMu.Lock()
+		c.tokens = nil
+		c.tokenMu.Unlock()
+	}
+}
+
+// SetTimeout bounds every later request by d; 0 means no limit.
+func (c *HTTPClient) SetTimeout(d time.Duration) {
+	c.configMu.Lock()
+	c.timeout = max(d, 0)
+	c.configMu.Unlock()
+}
+
+// SetProxy sends later requests through proxyURL, such as
+// http://proxy:3128 or socks5://localhost:1080. An empty proxyURL goes back
+// to the HTTPS_PROXY and NO_PROXY environment variables. It only applies to
+// a client NewClient created itself.
+func (c *HTTPClient) SetProxy(proxyURL string) error {
+	var proxy *url.URL
+	if proxyURL = strings.TrimSpace(proxyURL); proxyURL != "" {
+		u, err := url.Parse(proxyURL)
+		if err != nil || u.Host == "" {
+			return fmt.Errorf("invalid proxy URL %q", proxyURL)
+		}
+		proxy = u
+	}
+	c.configMu.Lock()
+	c.proxy = proxy
+	c.configMu.Unlock()
+	// Kept-alive connections would still go the old way.
+	c.http.CloseIdleConnections()
+	return nil
+}
+
+func (c *HTTPClient) proxyFor(req *http.Request) (*url.URL, error) {
+	c.configMu.RLock()
+	proxy := c.proxy
+	c.configMu.RUnlock()
+	if proxy != nil {
+		return proxy, nil
+	}
+	return http.ProxyFromEnvironment(req)
+}
+
+func (c *HTTPClient) apiBase() string {
+	c.configMu.RLock()
+	defer c.configMu.RUnlock()
+	return c.baseURL
+}
+
+// ListStarred lists the repos username starred. When username is empty or
+// is the token's own account, it lists /user/starred instead, which also
+// returns private repos the token can see.
 func (c *HTTPClient) ListStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
-	if strings.TrimSpace(username) == "" {
+	username = strings.TrimSpace(username)
+	if username == "" && token == "" {
 		return nil, errors.New("username is required")
 	}
 	if perPage <= 0 || perPage > 100 {
 		perPage = 100
 	}
 
+	apiBase := c.apiBase()
+	base := fmt.Sprintf("%s/users/%s/starred", apiBase, url.PathEscape(username))
+	if token != "" {
+		login, err := c.cachedLogin(ctx, token)
+		switch {
+		case err == nil && (username == "" || strings.EqualFold(username, login)):
+			base = apiBase + "/user/starred"
+		case username == "":
+			return nil, err
+		}
+	}
+
 	var all []domain.Repo
 	for page := 1; ; page++ {
-		endpoint := fmt.Sprintf("%s/users/%s/starred?per_page=%d&page=%d", c.baseURL, url.PathEscape(username), perPage, page)
+		endpoint := fmt.Sprintf("%s?per_page=%d&page=%d", base, perPage, page)
 		var resp []repoResponse
 		_, err := c.getJSON(ctx, endpoint, token, &resp)
 		if err != nil {
@@ -65,6 +209,128 @@ func (c *HTTPClient) ListStarred(ctx context.Context, username, token string, pe
 	return all, nil
 }
 
+// ListOrgMembers returns the logins of org's public members. Members who
+// conceal their membership are left out even when the token could see them.
+func (c *HTTPClient) ListOrgMembers(ctx context.Context, org, token string) ([]string, error) {
+	if strings.TrimSpace(org) == "" {
+		return nil, errors.New("org is required")
+	}
+
+	const perPage = 100
+	apiBase := c.apiBase()
+	var logins []string
+	for page := 1; ; page++ {
+		endpoint := fmt.Sprintf("%s/orgs/%s/public_members?per_page=%d&page=%d", apiBase, url.PathEscape(org), perPage, page)
+		var resp []struct {
+			Login string `json:"login"`
+		}
+		if _, err := c.getJSON(ctx, endpoint, token, &resp); err != nil {
+			return nil, err
+		}
+		for _, member := range resp {
+			logins = append(logins, member.Login)
+		}
+		if len(resp) < perPage {
+			break
+		}
+	}
+	return logins, nil
+}
+
+func (c *HTTPClient) GetAuthenticatedUser(ctx context.Context, token string) (string, error) {
+	info, err := c.CheckToken(ctx, token)
+	if err != nil {
+		return "", err
+	}
+	return info.Login, nil
+}
+
+// CheckToken calls /user and reads the token's scopes and expiry from the
+// response headers, which GitHub sends on every authenticated request.
+func (c *HTTPClient) CheckToken(ctx context.Context, token string) (domain.TokenInfo, error) {
+	if strings.TrimSpace(token) == "" {
+		return domain.TokenInfo{}, errors.New("token is required")
+	}
+	var resp struct {
+		Login string `json:"login"`
+	}
+	header, err := c.getJSON(ctx, c.apiBase()+"/user", token, &resp)
+	if err != nil {
+		return domain.TokenInfo{}, err
+	}
+	if resp.Login == "" {
+		return domain.TokenInfo{}, errors.New("github did not return a login for the token")
+	}
+
+	info := tokenInfoFromHeader(token, header)
+	info.Login = resp.Login
+	c.tokenMu.Lock()
+	if c.tokens == nil {
+		c.tokens = map[string]domain.TokenInfo{}
+	}
+	c.tokens[tokenKey(token)] = info
+	c.tokenMu.Unlock()
+	return info, nil
+}
+
+// ForgetTokenCheck drops the cached check of token, once it is replaced.
+func (c *HTTPClient) ForgetTokenCheck(token string) {
+	c.tokenMu.Lock()
+	delete(c.tokens, tokenKey(token))
+	c.tokenMu.Unlock()
+}
+
+func tokenKey(token string) string {
+	return flight.Key("/user", token)
+}
+
+func (c *HTTPClient) cachedLogin(ctx context.Context, token string) (string, error) {
+	c.tokenMu.Lock()
+	info, ok := c.tokens[tokenKey(token)]
+	c.tokenMu.Unlock()
+	if ok {
+		return info.Login, nil
+	}
+	return c.GetAuthenticatedUser(ctx, token)
+}
+
+// tokenExpiryLayouts are the formats seen in the
+// GitHub-Authentication-Token-Expiration header.
+var tokenExpiryLayouts = []string{"2006-01-02 15:04:05 MST", "2006-01-02 15:04:05 -0700"}
+
+func tokenInfoFromHeader(token string, header http.Header) domain.TokenInfo {
+	info := domain.TokenInfo{
+		Kind:      domain.TokenKindOf(token),
+		CheckedAt: time.Now(),
+	}
+	if _, ok := header["X-Oauth-Scopes"]; ok {
+		info.Scopes = splitScopes(header.Get("X-OAuth-Scopes"))
+		if info.Kind == domain.TokenUnknown {
+			info.Kind = domain.TokenClassic
+		}
+	}
+	info.AcceptedScopes = splitScopes(header.Get("X-Accepted-OAuth-Scopes"))
+	if expiry := header.Get("GitHub-Authentication-Token-Expiration"); expiry != "" {
+		for _, layout := range tokenExpiryLayouts {
+			if t, err := time.Parse(layout, expiry); err == nil {
+				info.ExpiresAt = t
+				break
+			}
+		}
+	}
+	return info
+}
+
+func splitScopes(value string) []string {
+	scopes := []string{}
+	for _, scope := range strings.Split(value, ",") {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			scopes = append(scopes, scope)
+		}
+	}
+	return scopes
+}
+
 func (c *HTTPClient) GetRepoDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
 	if strings.TrimSpace(fullName) == "" {
 		return domain.RepoDetails{}, errors.New("repo full name is required")
@@ -73,7 +339,7 @@ func (c *HTTPClient) GetRepoDetails(ctx context.Context, fullName, token string)
 	if err != nil {
 		return domain.RepoDetails{}, err
 	}
-	endpoint := fmt.Sprintf("%s/repos/%s/%s", c.baseURL, url.PathEscape(owner), url.PathEscape(repo))
+	endpoint := fmt.Sprintf("%s/repos/%s/%s", c.apiBase(), url.PathEscape(owner), url.PathEscape(repo))
 	var resp repoDetailsResponse
 	_, err = c.getJSON(ctx, endpoint, token, &resp)
 	if err != nil {
@@ -82,6 +348,160 @@ func (c *HTTPClient) GetRepoDetails(ctx context.Context, fullName, token string)
 	return resp.toDomain(), nil
 }
 
+func (c *HTTPClient) GetCommitActivity(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error) {
+	endpoint, err := c.statsEndpoint(fullName, "commit_activity")
+	if err != nil {
+		return nil, err
+	}
+	var resp []commitWeekResponse
+	if err := c.getStats(ctx, endpoint, token, &resp); err != nil {
+		return nil, err
+	}
+	weeks := make([]domain.CommitWeek, 0, len(resp))
+	for _, w := range resp {
+		weeks = append(weeks, w.toDomain())
+	}
+	return weeks, nil
+}
+
+func (c *HTTPClient) GetCodeFrequency(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error) {
+	endpoint, err := c.statsEndpoint(fullName, "code_frequency")
+	if err != nil {
+		return nil, err
+	}
+	var resp [][3]int64
+	if err := c.getStats(ctx, endpoint, token, &resp); err != nil {
+		return nil, err
+	}
+	weeks := make([]domain.CodeFrequencyWeek, 0, len(resp))
+	for _, w := range resp {
+		deletions := w[2]
+		if deletions < 0 {
+			deletions = -deletions
+		}
+		weeks = append(weeks, domain.CodeFrequencyWeek{
+			Week:      time.Unix(w[0], 0).UTC(),
+			Additions: int(w[1]),
+			Deletions: int(deletions),
+		})
+	}
+	return weeks, nil
+}
+
+func (c *HTTPClient) GetLatestRelease(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+	endpoint, err := c.repoEndpoint(fullName, "releases/latest")
+	if err != nil {
+		return domain.Release{}, "", err
+	}
+	var resp releaseResponse
+	header, err := c.getConditional(ctx, endpoint, token, etag, &resp)
+	if err != nil {
+		return domain.Release{}, "", err
+	}
+	return resp.toDomain(), header.Get("ETag"), nil
+}
+
+func (c *HTTPClient) GetLatestTag(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+	endpoint, err := c.repoEndpoint(fullName, "tags?per_page=1")
+	if err != nil {
+		return domain.Release{}, "", err
+	}
+	var resp []struct {
+		Name string `json:"name"`
+	}
+	header, err := c.getConditional(ctx, endpoint, token, etag, &resp)
+	if err != nil {
+		return domain.Release{}, "", err
+	}
+	if len(resp) == 0 {
+		return domain.Release{}, header.Get("ETag"), ErrNotFound
+	}
+	owner, repo, _ := splitFullName(fullName)
+	return domain.Release{
+		Tag:     resp[0].Name,
+		Name:    resp[0].Name,
+		HTMLURL: fmt.Sprintf("%s/%s/%s/releases/tag/%s", webBase(c.apiBase()), owner, repo, url.PathEscape(resp[0].Name)),
+		FromTag: true,
+	}, header.Get("ETag"), nil
+}
+
+func (c *HTTPClient) statsEndpoint(fullName, stat string) (string, error) {
+	return c.repoEndpoint(fullName, "stats/"+stat)
+}
+
+func (c *HTTPClient) repoEndpoint(fullName, path string) (string, error) {
+	if strings.TrimSpace(fullName) == "" {
+		return "", errors.New("repo full name is required")
+	}
+	owner, repo, err := splitFullName(fullName)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%s/repos/%s/%s/%s", c.apiBase(), url.PathEscape(owner), url.PathEscape(repo), path), nil
+}
+
+// getStats polls a statistics endpoint until GitHub has finished computing it,
+// the attempt budget runs out, or ctx is done.
+func (c *HTTPClient) getStats(ctx context.Context, endpoint, token string, target any) error {
+	for attempt := 1; ; attempt++ {
+		_, err := c.getJSON(ctx, endpoint, token, target)
+		if !errors.Is(err, ErrStatsPending) || attempt >= c.statsMaxAttempts {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(c.statsRetryDelay):
+		}
+	}
+}
+
+// RateLimit returns the budget reported by the most recent response.
+func (c *HTTPClient) RateLimit() domain.RateLimit {
+	c.rateMu.Lock()
+	defer c.rateMu.Unlock()
+	return c.rateLimit
+}
+
+// Reachable resolves the API host, which fails fast when the machine is
+// offline without spending any of the rate limit.
+func (c *HTTPClient) Reachable(ctx context.Context) error {
+	u, err := url.Parse(c.apiBase())
+	if err != nil {
+		return err
+	}
+	_, err = net.DefaultResolver.LookupHost(ctx, u.Hostname())
+	return err
+}
+
+func (c *HTTPClient) recordRateLimit(header http.Header) {
+	limit, err := strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	if err != nil {
+		return
+	}
+	remaining, _ := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	reset, _ := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64)
+
+	c.rateMu.Lock()
+	c.rateLimit = domain.RateLimit{Limit: limit, Remaining: remaining, Reset: time.Unix(reset, 0)}
+	c.rateMu.Unlock()
+}
+
+// webBase returns the web root that serves the pages of the API at apiBase:
+// https://github.com for api.github.com, and the host itself for a GitHub
+// Enterprise API under /api/v3.
+func webBase(apiBase string) string {
+	u, err := url.Parse(apiBase)
+	if err != nil {
+		return strings.TrimSuffix(apiBase, "/api/v3")
+	}
+	if host, ok := strings.CutPrefix(u.Host, "api."); ok && strings.Trim(u.Path, "/") == "" {
+		u.Host = host
+	}
+	u.Path = strings.TrimSuffix(strings.TrimRight(u.Path, "/"), "/api/v3")
+	return strings.TrimRight(u.String(), "/")
+}
+
 func splitFullName(fullName string) (string, string, error) {
 	parts := strings.SplitN(fullName, "/", 2)
 	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
@@ -91,6 +511,7 @@ func splitFullName(fullName string) (string, string, error) {
 }
 
 type repoResponse struct {
+	ID          int64     `json:"id"`
 	FullName    string    `json:"full_name"`
 	HTMLURL     string    `json:"html_url"`
 	Description string    `json:"description"`
@@ -99,10 +520,12 @@ type repoResponse struct {
 	Forks       int       `json:"forks_count"`
 	UpdatedAt   time.Time `json:"updated_at"`
 	Private     bool      `json:"private"`
+	Archived    bool      `json:"archived"`
 }
 
 func (r repoResponse) toDomain() domain.Repo {
 	return domain.Repo{
+		ID:          r.ID,
 		FullName:    r.FullName,
 		HTMLURL:     r.HTMLURL,
 		Description: r.Description,
@@ -111,6 +534,7 @@ func (r repoResponse) toDomain() domain.Repo {
 		Forks:       r.Forks,
 		UpdatedAt:   r.UpdatedAt,
 		Private:     r.Private,
+		Archived:    r.Archived,
 	}
 }
 
@@ -162,12 +586,79 @@ func (r repoDetailsResponse) toDomain() domain.RepoDetails {
 	}
 }
 
+type commitWeekResponse struct {
+	Week  int64  `json:"week"`
+	Total int    `json:"total"`
+	Days  [7]int `json:"days"`
+}
+
+func (r commitWeekResponse) toDomain() domain.CommitWeek {
+	return domain.CommitWeek{
+		Week:  time.Unix(r.Week, 0).UTC(),
+		Total: r.Total,
+		Days:  r.Days,
+	}
+}
+
+type releaseResponse struct {
+	TagName     string    `json:"tag_name"`
+	Name        string    `json:"name"`
+	Body        string    `json:"body"`
+	HTMLURL     string    `json:"html_url"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+func (r releaseResponse) toDomain() domain.Release {
+	name := r.Name
+	if name == "" {
+		name = r.TagName
+	}
+	return domain.Release{
+		Tag:         r.TagName,
+		Name:        name,
+		Notes:       r.Body,
+		HTMLURL:     r.HTMLURL,
+		PublishedAt: r.PublishedAt,
+	}
+}
+
+// StatusError is returned for non-success responses. It matches ErrNotFound
+// with errors.Is when the status is 404.
+type StatusError struct {
+	StatusCode int
+	Status     string
+	Message    string
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("github api error: %s: %s", e.Status, e.Message)
+}
+
+func (e *StatusError) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
 type apiError struct {
 	Message          string `json:"message"`
 	DocumentationURL string `json:"documentation_url"`
 }
 
 func (c *HTTPClient) getJSON(ctx context.Context, endpoint, token string, target any) (http.Header, error) {
+	return c.getConditional(ctx, endpoint, token, "", target)
+}
+
+// getConditional is getJSON with an optional If-None-Match validator. A 304
+// answer returns ErrNotModified and leaves target untouched.
+func (c *HTTPClient) getConditional(ctx context.Context, endpoint, token, etag string, target any) (http.Header, error) {
+	c.configMu.RLock()
+	timeout := c.timeout
+	c.configMu.RUnlock()
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
 	if err != nil {
 		return nil, err
@@ -177,12 +668,25 @@ func (c *HTTPClient) getJSON(ctx context.Context, endpoint, token string, target
 	if token != "" {
 		req.Header.Set("Authorization", "Bearer "+token)
 	}
+	if etag != "" {
+		req.Header.Set("If-None-Match", etag)
+	}
 
 	resp, err := c.http.Do(req)
 	if err != nil {
 		return nil, err
 	}
 	defer resp.Body.Close()
+	c.recordRateLimit(resp.Header)
+
+	switch resp.StatusCode {
+	case http.StatusAccepted:
+		return resp.Header, ErrStatsPending
+	case http.StatusNoContent:
+		return resp.Header, nil
+	case http.StatusNotModified:
+		return resp.Header, ErrNotModified
+	}
 
 	if resp.StatusCode >= http.StatusMultipleChoices {
 		body, _ := io.ReadAll(io.LimitReader(resp.Body, 64*1024))
@@ -197,7 +701,7 @@ func (c *HTTPClient) getJSON(ctx context.Context, endpoint, token string, target
 		if msg == "" {
 			msg = "unknown error"
 		}
-		return nil, fmt.Errorf("github api error: %s: %s", resp.Status, msg)
+		return nil, &StatusError{StatusCode: resp.StatusCode, Status: resp.Status, Message: msg}
 	}
 
 	decoder := json.NewDecoder(resp.Body)
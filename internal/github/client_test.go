@@ -0,0 +1,330 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/testutil"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *HTTPClient {
+	t.Helper()
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	c := NewClient(srv.Client())
+	c.baseURL = srv.URL
+	c.statsRetryDelay = time.Millisecond
+	return c
+}
+
+func TestHTTPClient_GetCommitActivity_PollsWhileComputing(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, "/repos/golang/go/stats/commit_activity", r.URL.Path)
+		if calls.Add(1) < 3 {
+			w.WriteHeader(http.StatusAccepted)
+			_, _ = w.Write([]byte("{}"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"days":[0,1,2,3,4,5,6],"total":21,"week":1704585600}]`))
+	})
+
+	weeks, err := c.GetCommitActivity(context.Background(), "golang/go", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, int32(3), calls.Load())
+	testutil.AssertEqual(t, 1, len(weeks))
+	testutil.AssertEqual(t, 21, weeks[0].Total)
+	testutil.AssertEqual(t, 6, weeks[0].Days[6])
+	testutil.AssertEqual(t, time.Date(2024, 1, 7, 0, 0, 0, 0, time.UTC), weeks[0].Week)
+}
+
+func TestHTTPClient_GetCommitActivity_GivesUpAfterMaxAttempts(t *testing.T) {
+	var calls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusAccepted)
+	})
+	c.statsMaxAttempts = 2
+
+	_, err := c.GetCommitActivity(context.Background(), "golang/go", "")
+
+	testutil.AssertTrue(t, errors.Is(err, ErrStatsPending), "should report stats as pending")
+	testutil.AssertEqual(t, int32(2), calls.Load())
+}
+
+func TestHTTPClient_GetCommitActivity_StopsOnCancel(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+	})
+	c.statsRetryDelay = time.Hour
+
+	ctx, cancel := testutil.WithTimeout(t, 50*time.Millisecond)
+	defer cancel()
+
+	_, err := c.GetCommitActivity(ctx, "golang/go", "")
+
+	testutil.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "should stop polling when ctx is done")
+}
+
+func TestHTTPClient_GetCodeFrequency_NormalizesDeletions(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, "/repos/golang/go/stats/code_frequency", r.URL.Path)
+		_, _ = w.Write([]byte(`[[1704585600, 120, -45], [1705190400, 0, 0]]`))
+	})
+
+	weeks, err := c.GetCodeFrequency(context.Background(), "golang/go", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 2, len(weeks))
+	testutil.AssertEqual(t, 120, weeks[0].Additions)
+	testutil.AssertEqual(t, 45, weeks[0].Deletions)
+}
+
+func TestHTTPClient_GetCodeFrequency_EmptyRepo(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	weeks, err := c.GetCodeFrequency(context.Background(), "user/empty", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 0, len(weeks))
+}
+
+func TestHTTPClient_RecordsRateLimit(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-RateLimit-Limit", "5000")
+		w.Header().Set("X-RateLimit-Remaining", "4321")
+		w.Header().Set("X-RateLimit-Reset", "1704585600")
+		_, _ = w.Write([]byte(`{"full_name":"golang/go"}`))
+	})
+
+	testutil.AssertEqual(t, 0, c.RateLimit().Limit)
+	_, err := c.GetRepoDetails(context.Background(), "golang/go", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 5000, c.RateLimit().Limit)
+	testutil.AssertEqual(t, 4321, c.RateLimit().Remaining)
+	testutil.AssertEqual(t, int64(1704585600), c.RateLimit().Reset.Unix())
+}
+
+func TestHTTPClient_GetLatestRelease_Conditional(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, "/repos/golang/go/releases/latest", r.URL.Path)
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		_, _ = w.Write([]byte(`{"tag_name":"go1.22.0","name":"","body":"notes","html_url":"https://github.com/golang/go/releases/tag/go1.22.0","published_at":"2024-02-06T00:00:00Z"}`))
+	})
+
+	release, etag, err := c.GetLatestRelease(context.Background(), "golang/go", "", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, `"v1"`, etag)
+	testutil.AssertEqual(t, "go1.22.0", release.Tag)
+	testutil.AssertEqual(t, "go1.22.0", release.Name)
+	testutil.AssertEqual(t, "notes", release.Notes)
+
+	_, _, err = c.GetLatestRelease(context.Background(), "golang/go", "", etag)
+
+	testutil.AssertTrue(t, errors.Is(err, ErrNotModified), "matching ETag should report not modified")
+}
+
+func TestHTTPClient_GetLatestRelease_NotFound(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"message":"Not Found"}`))
+	})
+
+	_, _, err := c.GetLatestRelease(context.Background(), "user/tags-only", "", "")
+
+	testutil.AssertTrue(t, errors.Is(err, ErrNotFound), "404 should match ErrNotFound")
+	testutil.AssertEqual(t, "github api error: 404 Not Found: Not Found", err.Error())
+}
+
+func TestHTTPClient_GetLatestTag(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, "/repos/user/tags-only/tags", r.URL.Path)
+		testutil.AssertEqual(t, "1", r.URL.Query().Get("per_page"))
+		_, _ = w.Write([]byte(`[{"name":"v0.3.1"}]`))
+	})
+
+	release, _, err := c.GetLatestTag(context.Background(), "user/tags-only", "", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "v0.3.1", release.Tag)
+	testutil.AssertTrue(t, release.FromTag, "tag-only version should be flagged")
+	testutil.AssertEqual(t, c.apiBase()+"/user/tags-only/releases/tag/v0.3.1", release.HTMLURL)
+}
+
+func TestWebBase(t *testing.T) {
+	testutil.AssertEqual(t, "https://github.com", webBase("https://api.github.com"))
+	testutil.AssertEqual(t, "https://ghe.example.com", webBase("https://ghe.example.com/api/v3"))
+	testutil.AssertEqual(t, "http://127.0.0.1:8080", webBase("http://127.0.0.1:8080"))
+}
+
+func TestHTTPClient_ListOrgMembers_Paginates(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, "/orgs/golang/public_members", r.URL.Path)
+		if r.URL.Query().Get("page") == "1" {
+			members := make([]string, 100)
+			for i := range members {
+				members[i] = `{"login":"member"}`
+			}
+			_, _ = w.Write([]byte("[" + strings.Join(members, ",") + "]"))
+			return
+		}
+		_, _ = w.Write([]byte(`[{"login":"gopher"}]`))
+	})
+
+	logins, err := c.ListOrgMembers(context.Background(), "golang", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 101, len(logins))
+	testutil.AssertEqual(t, "gopher", logins[100])
+}
+
+func TestHTTPClient_GetAuthenticatedUser(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		testutil.AssertEqual(t, "/user", r.URL.Path)
+		testutil.AssertEqual(t, "Bearer ghp_secret", r.Header.Get("Authorization"))
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	})
+
+	login, err := c.GetAuthenticatedUser(context.Background(), "ghp_secret")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "octocat", login)
+
+	_, err = c.GetAuthenticatedUser(context.Background(), "")
+	testutil.AssertError(t, err)
+}
+
+func TestHTTPClient_ListStarred_MeMode(t *testing.T) {
+	var userCalls atomic.Int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			userCalls.Add(1)
+			_, _ = w.Write([]byte(`{"login":"octocat"}`))
+		case "/user/starred":
+			_, _ = w.Write([]byte(`[{"id":1,"full_name":"octocat/secret","private":true}]`))
+		case "/users/golang/starred":
+			_, _ = w.Write([]byte(`[{"id":2,"full_name":"golang/go"}]`))
+		default:
+			http.NotFound(w, r)
+		}
+	})
+
+	repos, err := c.ListStarred(context.Background(), "", "ghp_secret", 100)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 1, len(repos))
+	testutil.AssertTrue(t, repos[0].Private, "private flag should be kept")
+
+	repos, err = c.ListStarred(context.Background(), "OctoCat", "ghp_secret", 100)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "octocat/secret", repos[0].FullName)
+
+	repos, err = c.ListStarred(context.Background(), "golang", "ghp_secret", 100)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "golang/go", repos[0].FullName)
+	testutil.AssertEqual(t, int32(1), userCalls.Load())
+	for key := range c.tokens {
+		testutil.AssertFalse(t, strings.Contains(key, "ghp_secret"), "tokens should not be kept as map keys")
+	}
+
+	c.ForgetTokenCheck("ghp_secret")
+	_, err = c.ListStarred(context.Background(), "golang", "ghp_secret", 100)
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, int32(2), userCalls.Load())
+
+	_, err = c.ListStarred(context.Background(), "", "", 100)
+	testutil.AssertError(t, err)
+}
+
+func TestHTTPClient_CheckToken_ReadsScopeHeaders(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-OAuth-Scopes", "read:org, public_repo")
+		w.Header().Set("X-Accepted-OAuth-Scopes", "")
+		w.Header().Set("GitHub-Authentication-Token-Expiration", "2026-11-01 09:30:00 UTC")
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	})
+
+	info, err := c.CheckToken(context.Background(), "ghp_classic")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "octocat", info.Login)
+	testutil.AssertEqual(t, domain.TokenClassic, info.Kind)
+	testutil.AssertEqual(t, "read:org,public_repo", strings.Join(info.Scopes, ","))
+	testutil.AssertEqual(t, time.Date(2026, 11, 1, 9, 30, 0, 0, time.UTC), info.ExpiresAt.UTC())
+	testutil.AssertTrue(t, info.HasScope("public_repo"), "public_repo should be granted")
+	testutil.AssertFalse(t, info.HasScope("repo"), "repo should not be granted")
+}
+
+func TestHTTPClient_CheckToken_FineGrained(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"login":"octocat"}`))
+	})
+
+	info, err := c.CheckToken(context.Background(), "github_pat_abc")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, domain.TokenFineGrained, info.Kind)
+	testutil.AssertFalse(t, info.HasScopes(), "fine-grained tokens do not report scopes")
+	testutil.AssertTrue(t, info.ExpiresAt.IsZero(), "no expiry header means no expiry")
+}
+
+func TestHTTPClient_Reachable(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Fatal("Reachable should not send a request")
+	})
+	testutil.AssertNoError(t, c.Reachable(context.Background()))
+
+	c.SetBaseURL("https://gh-stars.invalid")
+	testutil.AssertError(t, c.Reachable(context.Background()))
+}
+
+func TestHTTPClient_SetTimeout(t *testing.T) {
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second):
+		}
+	})
+	c.SetTimeout(20 * time.Millisecond)
+
+	_, err := c.GetRepoDetails(context.Background(), "golang/go", "")
+
+	testutil.AssertTrue(t, errors.Is(err, context.DeadlineExceeded), "slow request should time out")
+}
+
+func TestHTTPClient_SetProxy(t *testing.T) {
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxied.Store(r.URL.String())
+		_, _ = w.Write([]byte(`{"full_name":"golang/go"}`))
+	}))
+	t.Cleanup(proxy.Close)
+
+	c := NewClient(nil)
+	c.SetBaseURL("http://api.github.test/")
+	testutil.AssertError(t, c.SetProxy("not a url"))
+	testutil.AssertNoError(t, c.SetProxy(proxy.URL))
+
+	details, err := c.GetRepoDetails(context.Background(), "golang/go", "")
+
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, "golang/go", details.FullName)
+	testutil.AssertEqual(t, "http://api.github.test/repos/golang/go", proxied.Load())
+}
@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/tbxark/gh-stars/internal/domain"
 )
@@ -15,10 +16,43 @@ type MockClient struct {
 	// GetRepoDetailsFunc allows overriding the behavior in tests
 	GetRepoDetailsFunc func(ctx context.Context, fullName, token string) (domain.RepoDetails, error)
 
+	// GetCommitActivityFunc allows overriding the behavior in tests
+	GetCommitActivityFunc func(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error)
+
+	// GetCodeFrequencyFunc allows overriding the behavior in tests
+	GetCodeFrequencyFunc func(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error)
+
+	// GetLatestReleaseFunc allows overriding the behavior in tests
+	GetLatestReleaseFunc func(ctx context.Context, fullName, token, etag string) (domain.Release, string, error)
+
+	// GetLatestTagFunc allows overriding the behavior in tests
+	GetLatestTagFunc func(ctx context.Context, fullName, token, etag string) (domain.Release, string, error)
+
+	// ListOrgMembersFunc allows overriding the behavior in tests
+	ListOrgMembersFunc func(ctx context.Context, org, token string) ([]string, error)
+
+	// GetAuthenticatedUserFunc allows overriding the behavior in tests
+	GetAuthenticatedUserFunc func(ctx context.Context, token string) (string, error)
+
+	// CheckTokenFunc allows overriding the behavior in tests
+	CheckTokenFunc func(ctx context.Context, token string) (domain.TokenInfo, error)
+
+	// RateLimitFunc allows overriding the behavior in tests
+	RateLimitFunc func() domain.RateLimit
+
 	// CallCounts tracks how many times each method was called
 	CallCounts struct {
-		ListStarred    int
-		GetRepoDetails int
+		mu                   sync.Mutex
+		ListStarred          int
+		GetRepoDetails       int
+		GetCommitActivity    int
+		GetCodeFrequency     int
+		GetLatestRelease     int
+		GetLatestTag         int
+		ListOrgMembers       int
+		GetAuthenticatedUser int
+		CheckToken           int
+		ForgetTokenCheck     int
 	}
 }
 
@@ -31,23 +65,199 @@ func NewMockClient() *MockClient {
 		GetRepoDetailsFunc: func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
 			return domain.RepoDetails{}, fmt.Errorf("mock GetRepoDetails not implemented")
 		},
+		GetCommitActivityFunc: func(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error) {
+			return nil, fmt.Errorf("mock GetCommitActivity not implemented")
+		},
+		GetCodeFrequencyFunc: func(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error) {
+			return nil, fmt.Errorf("mock GetCodeFrequency not implemented")
+		},
+		GetLatestReleaseFunc: func(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+			return domain.Release{}, "", fmt.Errorf("mock GetLatestRelease not implemented")
+		},
+		GetLatestTagFunc: func(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+			return domain.Release{}, "", fmt.Errorf("mock GetLatestTag not implemented")
+		},
+		ListOrgMembersFunc: func(ctx context.Context, org, token string) ([]string, error) {
+			return nil, fmt.Errorf("mock ListOrgMembers not implemented")
+		},
+		GetAuthenticatedUserFunc: func(ctx context.Context, token string) (string, error) {
+			return "", fmt.Errorf("mock GetAuthenticatedUser not implemented")
+		},
+		CheckTokenFunc: func(ctx context.Context, token string) (domain.TokenInfo, error) {
+			return domain.TokenInfo{}, fmt.Errorf("mock CheckToken not implemented")
+		},
+		RateLimitFunc: func() domain.RateLimit {
+			return domain.RateLimit{}
+		},
 	}
 }
 
 // ListStarred implements the Client interface
 func (m *MockClient) ListStarred(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+	m.CallCounts.mu.Lock()
 	m.CallCounts.ListStarred++
+	m.CallCounts.mu.Unlock()
 	return m.ListStarredFunc(ctx, username, token, perPage)
 }
 
 // GetRepoDetails implements the Client interface
 func (m *MockClient) GetRepoDetails(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+	m.CallCounts.mu.Lock()
 	m.CallCounts.GetRepoDetails++
+	m.CallCounts.mu.Unlock()
 	return m.GetRepoDetailsFunc(ctx, fullName, token)
 }
 
+// GetCommitActivity implements the Client interface
+func (m *MockClient) GetCommitActivity(ctx context.Context, fullName, token string) ([]domain.CommitWeek, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.GetCommitActivity++
+	m.CallCounts.mu.Unlock()
+	return m.GetCommitActivityFunc(ctx, fullName, token)
+}
+
+// GetCodeFrequency implements the Client interface
+func (m *MockClient) GetCodeFrequency(ctx context.Context, fullName, token string) ([]domain.CodeFrequencyWeek, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.GetCodeFrequency++
+	m.CallCounts.mu.Unlock()
+	return m.GetCodeFrequencyFunc(ctx, fullName, token)
+}
+
+// GetLatestRelease implements the Client interface
+func (m *MockClient) GetLatestRelease(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.GetLatestRelease++
+	m.CallCounts.mu.Unlock()
+	return m.GetLatestReleaseFunc(ctx, fullName, token, etag)
+}
+
+// GetLatestTag implements the Client interface
+func (m *MockClient) GetLatestTag(ctx context.Context, fullName, token, etag string) (domain.Release, string, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.GetLatestTag++
+	m.CallCounts.mu.Unlock()
+	return m.GetLatestTagFunc(ctx, fullName, token, etag)
+}
+
+// ListOrgMembers implements the Client interface
+func (m *MockClient) ListOrgMembers(ctx context.Context, org, token string) ([]string, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.ListOrgMembers++
+	m.CallCounts.mu.Unlock()
+	return m.ListOrgMembersFunc(ctx, org, token)
+}
+
+// GetAuthenticatedUser implements the Client interface
+func (m *MockClient) GetAuthenticatedUser(ctx context.Context, token string) (string, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.GetAuthenticatedUser++
+	m.CallCounts.mu.Unlock()
+	return m.GetAuthenticatedUserFunc(ctx, token)
+}
+
+// CheckToken implements the Client interface
+func (m *MockClient) CheckToken(ctx context.Context, token string) (domain.TokenInfo, error) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.CheckToken++
+	m.CallCounts.mu.Unlock()
+	return m.CheckTokenFunc(ctx, token)
+}
+
+// ForgetTokenCheck implements the Client interface
+func (m *MockClient) ForgetTokenCheck(token string) {
+	m.CallCounts.mu.Lock()
+	m.CallCounts.ForgetTokenCheck++
+	m.CallCounts.mu.Unlock()
+}
+
+// RateLimit implements the Client interface
+func (m *MockClient) RateLimit() domain.RateLimit {
+	return m.RateLimitFunc()
+}
+
 // ResetCallCounts resets all call counters (useful between test cases)
 func (m *MockClient) ResetCallCounts() {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
 	m.CallCounts.ListStarred = 0
 	m.CallCounts.GetRepoDetails = 0
+	m.CallCounts.GetCommitActivity = 0
+	m.CallCounts.GetCodeFrequency = 0
+	m.CallCounts.GetLatestRelease = 0
+	m.CallCounts.GetLatestTag = 0
+	m.CallCounts.ListOrgMembers = 0
+	m.CallCounts.GetAuthenticatedUser = 0
+	m.CallCounts.CheckToken = 0
+	m.CallCounts.ForgetTokenCheck = 0
+}
+
+// GetListStarredCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetListStarredCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.ListStarred
+}
+
+// GetRepoDetailsCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetRepoDetailsCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.GetRepoDetails
+}
+
+// GetCommitActivityCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetCommitActivityCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.GetCommitActivity
+}
+
+// GetCodeFrequencyCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetCodeFrequencyCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.GetCodeFrequency
+}
+
+// GetLatestReleaseCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetLatestReleaseCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.GetLatestRelease
+}
+
+// GetLatestTagCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetLatestTagCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.GetLatestTag
+}
+
+// GetListOrgMembersCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetListOrgMembersCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.ListOrgMembers
+}
+
+// GetAuthenticatedUserCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetAuthenticatedUserCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.GetAuthenticatedUser
+}
+
+// GetCheckTokenCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetCheckTokenCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.CheckToken
+}
+
+// GetForgetTokenCheckCount returns the current call count in a thread-safe manner
+func (m *MockClient) GetForgetTokenCheckCount() int {
+	m.CallCounts.mu.Lock()
+	defer m.CallCounts.mu.Unlock()
+	return m.CallCounts.ForgetTokenCheck
 }
@@ -101,6 +101,18 @@ func WaitOrTimeout(t *testing.T, ch <-chan struct{}, timeout time.Duration, mess
 	}
 }
 
+// Eventually polls cond until it holds, failing the test after a second
+func Eventually(t *testing.T, cond func() bool, message string) {
+	t.Helper()
+	deadline := time.Now().Add(time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatalf("timeout waiting for %s", message)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
 // AssertSliceLength checks slice length
 func AssertSliceLength(t *testing.T, slice interface{}, expectedLen int) {
 	t.Helper()
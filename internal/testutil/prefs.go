@@ -0,0 +1,57 @@
+package testutil
+
+import "sync"
+
+// Prefs is an in-memory stand-in for the app preferences. It has every
+// method the stores' Prefs interfaces ask for and is safe for concurrent use.
+type Prefs struct {
+	mu      sync.Mutex
+	strings map[string]string
+	ints    map[string]int
+	lists   map[string][]string
+}
+
+// NewPrefs returns empty preferences.
+func NewPrefs() *Prefs {
+	return &Prefs{
+		strings: map[string]string{},
+		ints:    map[string]int{},
+		lists:   map[string][]string{},
+	}
+}
+
+func (p *Prefs) String(key string) string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.strings[key]
+}
+
+func (p *Prefs) SetString(key, value string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.strings[key] = value
+}
+
+func (p *Prefs) Int(key string) int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.ints[key]
+}
+
+func (p *Prefs) SetInt(key string, value int) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ints[key] = value
+}
+
+func (p *Prefs) StringList(key string) []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return append([]string(nil), p.lists[key]...)
+}
+
+func (p *Prefs) SetStringList(key string, value []string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.lists[key] = append([]string(nil), value...)
+}
@@ -0,0 +1,99 @@
+package activity
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+func NewView(vm *VM) fyne.CanvasObject {
+	title := canvas.NewText("Activity", theme.ForegroundColor())
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = theme.TextHeadingSize()
+
+	subtitle := canvas.NewText("Syncs, enrichment and other work running in the background.", theme.DisabledColor())
+	subtitle.TextSize = theme.TextSubHeadingSize()
+
+	cancelAllBtn := widget.NewButtonWithIcon("Cancel All", theme.MediaStopIcon(), vm.CancelAll)
+	clearBtn := widget.NewButtonWithIcon("Clear Finished", theme.ContentClearIcon(), vm.ClearFinished)
+	vm.Running.AddListener(binding.NewDataListener(func() {
+		if running, _ := vm.Running.Get(); running > 0 {
+			cancelAllBtn.Enable()
+		} else {
+			cancelAllBtn.Disable()
+		}
+	}))
+
+	actionBar := container.NewHBox(layout.NewSpacer(), cancelAllBtn, clearBtn)
+	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+
+	list := widget.NewListWithData(vm.Jobs, func() fyne.CanvasObject {
+		name := widget.NewLabel("")
+		name.TextStyle = fyne.TextStyle{Bold: true}
+		name.Wrapping = fyne.TextTruncate
+		state := widget.NewLabel("")
+		state.Importance = widget.LowImportance
+		state.Wrapping = fyne.TextTruncate
+		progress := widget.NewProgressBar()
+		cancelBtn := widget.NewButtonWithIcon("", theme.CancelIcon(), nil)
+		return container.NewBorder(nil, nil, nil, cancelBtn, container.NewVBox(name, state, progress))
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[jobs.Job])
+		if !ok {
+			return
+		}
+		job, err := item.Get()
+		if err != nil {
+			return
+		}
+		row := obj.(*fyne.Container)
+		body := row.Objects[0].(*fyne.Container)
+		body.Objects[0].(*widget.Label).SetText(job.Name)
+		state := body.Objects[1].(*widget.Label)
+		state.SetText(describeJob(job))
+		if job.State == jobs.Failed {
+			state.Importance = widget.DangerImportance
+		} else {
+			state.Importance = widget.LowImportance
+		}
+		state.Refresh()
+
+		progress := body.Objects[2].(*widget.ProgressBar)
+		if job.State == jobs.Running && job.Total > 0 {
+			progress.SetValue(job.Fraction())
+			progress.Show()
+		} else {
+			progress.Hide()
+		}
+
+		cancelBtn := row.Objects[1].(*widget.Button)
+		cancelBtn.OnTapped = func() { vm.Cancel(job.ID) }
+		if job.State == jobs.Running {
+			cancelBtn.Show()
+		} else {
+			cancelBtn.Hide()
+		}
+	})
+
+	statusBar := widgets.NewStatusPanel(vm.Status, binding.NewString(), newRunningFlag(vm.Running))
+	top := container.NewPadded(container.NewVBox(header, widget.NewSeparator()))
+	return container.NewBorder(top, container.NewPadded(statusBar), nil, nil, container.NewPadded(list))
+}
+
+// newRunningFlag follows running and is true while it is above zero, so the
+// status dot blinks while anything runs.
+func newRunningFlag(running binding.Int) binding.Bool {
+	flag := binding.NewBool()
+	running.AddListener(binding.NewDataListener(func() {
+		n, _ := running.Get()
+		_ = flag.Set(n > 0)
+	}))
+	return flag
+}
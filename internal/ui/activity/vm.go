@@ -0,0 +1,123 @@
+package activity
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+)
+
+type VM struct {
+	Jobs    binding.List[jobs.Job]
+	Running binding.Int
+	Status  binding.String
+
+	manager   *jobs.Manager
+	runOnMain func(func())
+
+	mu          sync.Mutex
+	unsubscribe func()
+}
+
+func NewVM(manager *jobs.Manager, runOnMain func(func())) *VM {
+	vm := &VM{
+		Jobs:      binding.NewList(sameJob),
+		Running:   binding.NewInt(),
+		Status:    binding.NewString(),
+		manager:   manager,
+		runOnMain: runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	_ = vm.Status.Set("No activity")
+	return vm
+}
+
+// Load shows the registered jobs and keeps them current until Cleanup.
+func (vm *VM) Load() {
+	vm.mu.Lock()
+	if vm.unsubscribe == nil {
+		vm.unsubscribe = vm.manager.Subscribe(vm.refresh)
+	}
+	vm.mu.Unlock()
+	vm.refresh()
+}
+
+// Cancel stops the job with id.
+func (vm *VM) Cancel(id int) {
+	vm.manager.Cancel(id)
+}
+
+// CancelAll stops every running job.
+func (vm *VM) CancelAll() {
+	for _, job := range vm.manager.List() {
+		if job.State == jobs.Running {
+			vm.manager.Cancel(job.ID)
+		}
+	}
+}
+
+func (vm *VM) ClearFinished() {
+	vm.manager.ClearFinished()
+}
+
+func (vm *VM) Cleanup() {
+	vm.mu.Lock()
+	if vm.unsubscribe != nil {
+		vm.unsubscribe()
+		vm.unsubscribe = nil
+	}
+	vm.mu.Unlock()
+}
+
+func (vm *VM) refresh() {
+	list := vm.manager.List()
+	running := 0
+	for _, job := range list {
+		if job.State == jobs.Running {
+			running++
+		}
+	}
+
+	vm.runOnMain(func() {
+		_ = vm.Jobs.Set(list)
+		_ = vm.Running.Set(running)
+		_ = vm.Status.Set(describeActivity(running, len(list)))
+	})
+}
+
+// describeJob formats the state line shown under a job's name.
+func describeJob(job jobs.Job) string {
+	switch job.State {
+	case jobs.Running:
+		if job.Detail != "" {
+			return job.Detail
+		}
+		return "Running for " + time.Since(job.StartedAt).Round(time.Second).String()
+	case jobs.Failed:
+		return "Failed: " + job.Err.Error()
+	}
+	if job.Result != "" {
+		return job.Result
+	}
+	return job.State.String()
+}
+
+func describeActivity(running, total int) string {
+	switch {
+	case total == 0:
+		return "No activity"
+	case running == 0:
+		return fmt.Sprintf("Nothing running; %d finished", total)
+	}
+	return fmt.Sprintf("%d running, %d finished", running, total-running)
+}
+
+func sameJob(a, b jobs.Job) bool {
+	return a.ID == b.ID && a.State == b.State && a.Done == b.Done && a.Total == b.Total &&
+		a.Detail == b.Detail && a.Result == b.Result
+}
@@ -0,0 +1,77 @@
+package activity_test
+
+import (
+	"context"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/activity"
+)
+
+func TestVM_Load_FollowsManager(t *testing.T) {
+	_ = test.NewApp()
+	manager := &jobs.Manager{}
+	vm := activity.NewVM(manager, func(f func()) { f() })
+	defer vm.Cleanup()
+
+	vm.Load()
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "No activity", status)
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	job := manager.Register("Sync stars", cancel)
+	job.Progress(1, 4, "Page 1 of 4")
+
+	items, _ := vm.Jobs.Get()
+	testutil.AssertEqual(t, 1, len(items))
+	testutil.AssertEqual(t, "Page 1 of 4", items[0].Detail)
+	running, _ := vm.Running.Get()
+	testutil.AssertEqual(t, 1, running)
+
+	job.Finish("Loaded 3 repos", nil)
+	status, _ = vm.Status.Get()
+	testutil.AssertEqual(t, "Nothing running; 1 finished", status)
+}
+
+func TestVM_CancelAll(t *testing.T) {
+	_ = test.NewApp()
+	manager := &jobs.Manager{}
+	vm := activity.NewVM(manager, func(f func()) { f() })
+	defer vm.Cleanup()
+	vm.Load()
+
+	first, cancelFirst := context.WithCancel(context.Background())
+	second, cancelSecond := context.WithCancel(context.Background())
+	manager.Register("Sync stars", cancelFirst)
+	manager.Register("Build team", cancelSecond).Finish("Built", nil)
+
+	vm.CancelAll()
+
+	testutil.AssertContextCancelled(t, first)
+	testutil.AssertContextNotCancelled(t, second)
+	running, _ := vm.Running.Get()
+	testutil.AssertEqual(t, 0, running)
+
+	vm.ClearFinished()
+	items, _ := vm.Jobs.Get()
+	testutil.AssertEqual(t, 0, len(items))
+}
+
+func TestVM_Cleanup_Unsubscribes(t *testing.T) {
+	_ = test.NewApp()
+	manager := &jobs.Manager{}
+	vm := activity.NewVM(manager, func(f func()) { f() })
+	vm.Load()
+	vm.Cleanup()
+
+	_, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	manager.Register("Sync stars", cancel)
+
+	items, _ := vm.Jobs.Get()
+	testutil.AssertEqual(t, 0, len(items))
+}
@@ -0,0 +1,21 @@
+package activity
+
+import (
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+)
+
+func NewActivityWindow(app fyne.App, manager *jobs.Manager) fyne.Window {
+	w := app.NewWindow("Activity")
+	w.Resize(fyne.NewSize(600, 500))
+
+	vm := NewVM(manager, fyne.Do)
+	w.SetContent(NewView(vm))
+	w.SetOnClosed(func() {
+		vm.Cleanup()
+	})
+	vm.Load()
+
+	return w
+}
@@ -0,0 +1,161 @@
+package changes
+
+import (
+	"fmt"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/ui/route"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
+	title := canvas.NewText("Changes: "+vm.Username, theme.ForegroundColor())
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = theme.TextHeadingSize()
+
+	subtitle := canvas.NewText("What changed between two syncs of your stars.", theme.DisabledColor())
+	subtitle.TextSize = theme.TextSubHeadingSize()
+
+	fromSelect := widget.NewSelect(nil, nil)
+	toSelect := widget.NewSelect(nil, nil)
+	fromSelect.PlaceHolder = "From snapshot"
+	toSelect.PlaceHolder = "To snapshot"
+	compareSelected := func(string) {
+		if fromSelect.SelectedIndex() < 0 || toSelect.SelectedIndex() < 0 {
+			return
+		}
+		from, _ := vm.FromIndex.Get()
+		to, _ := vm.ToIndex.Get()
+		if fromSelect.SelectedIndex() == from && toSelect.SelectedIndex() == to {
+			return
+		}
+		vm.Compare(fromSelect.SelectedIndex(), toSelect.SelectedIndex())
+	}
+	fromSelect.OnChanged = compareSelected
+	toSelect.OnChanged = compareSelected
+
+	vm.Snapshots.AddListener(binding.NewDataListener(func() {
+		labels, _ := vm.Snapshots.Get()
+		fromSelect.SetOptions(labels)
+		toSelect.SetOptions(labels)
+	}))
+	syncSelection := func() {
+		from, _ := vm.FromIndex.Get()
+		to, _ := vm.ToIndex.Get()
+		if labels, _ := vm.Snapshots.Get(); from < len(labels) && to < len(labels) {
+			fromSelect.SetSelectedIndex(from)
+			toSelect.SetSelectedIndex(to)
+		}
+	}
+	vm.FromIndex.AddListener(binding.NewDataListener(syncSelection))
+	vm.ToIndex.AddListener(binding.NewDataListener(syncSelection))
+
+	weekBtn := widget.NewButtonWithIcon("Past Week", theme.HistoryIcon(), vm.CompareLastWeek)
+	copyBtn := widget.NewButtonWithIcon("Copy Report", theme.ContentCopyIcon(), func() {
+		if report := vm.Report(); report != "" {
+			w.Clipboard().SetContent(report)
+		}
+	})
+	reloadBtn := widget.NewButtonWithIcon("Reload", theme.ViewRefreshIcon(), vm.Load)
+
+	pickers := container.NewGridWithColumns(2,
+		widget.NewForm(widget.NewFormItem("From", fromSelect)),
+		widget.NewForm(widget.NewFormItem("To", toSelect)),
+	)
+	actionBar := container.NewHBox(layout.NewSpacer(), weekBtn, copyBtn, reloadBtn)
+	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+
+	onOpen := func(repo domain.Repo) {
+		if router != nil {
+			router.ShowRepoDetails(repo.FullName, vm.Token)
+		}
+	}
+	tabs := container.NewAppTabs(
+		container.NewTabItem("Newly Starred", newRepoList(vm.Starred, onOpen)),
+		container.NewTabItem("Unstarred", newRepoList(vm.Unstarred, onOpen)),
+		container.NewTabItem("Renamed", newRenameList(vm.Renamed, onOpen)),
+		container.NewTabItem("Archived", newRepoList(vm.Archived, onOpen)),
+	)
+	for i, data := range []binding.DataList{vm.Starred, vm.Unstarred, vm.Renamed, vm.Archived} {
+		tab := tabs.Items[i]
+		title := tab.Text
+		data.AddListener(binding.NewDataListener(func() {
+			tab.Text = fmt.Sprintf("%s (%d)", title, data.Length())
+			tabs.Refresh()
+		}))
+	}
+
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Loading)
+	top := container.NewVBox(header, widget.NewSeparator(), pickers)
+	return container.NewBorder(
+		container.NewPadded(top),
+		container.NewPadded(statusBar),
+		nil,
+		nil,
+		container.NewPadded(tabs),
+	)
+}
+
+func newRepoList(repos binding.List[domain.Repo], onOpen func(domain.Repo)) *widget.List {
+	list := widget.NewListWithData(repos, newRowLabel, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[domain.Repo])
+		if !ok {
+			return
+		}
+		repo, err := item.Get()
+		if err != nil {
+			return
+		}
+		obj.(*widget.Label).SetText(repoLine(repo))
+	})
+	list.OnSelected = func(id widget.ListItemID) {
+		if repo, err := repos.GetValue(id); err == nil {
+			onOpen(repo)
+		}
+		list.Unselect(id)
+	}
+	return list
+}
+
+func newRenameList(renames binding.List[history.Rename], onOpen func(domain.Repo)) *widget.List {
+	list := widget.NewListWithData(renames, newRowLabel, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[history.Rename])
+		if !ok {
+			return
+		}
+		rename, err := item.Get()
+		if err != nil {
+			return
+		}
+		obj.(*widget.Label).SetText(rename.From.FullName + " → " + rename.To.FullName)
+	})
+	list.OnSelected = func(id widget.ListItemID) {
+		if rename, err := renames.GetValue(id); err == nil {
+			onOpen(rename.To)
+		}
+		list.Unselect(id)
+	}
+	return list
+}
+
+func newRowLabel() fyne.CanvasObject {
+	label := widget.NewLabel("")
+	label.Wrapping = fyne.TextTruncate
+	return label
+}
+
+func repoLine(repo domain.Repo) string {
+	if repo.Description == "" {
+		return repo.FullName
+	}
+	return repo.FullName + " — " + repo.Description
+}
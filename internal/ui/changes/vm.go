@@ -0,0 +1,186 @@
+package changes
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+const snapshotLabelLayout = "2006-01-02 15:04"
+
+type VM struct {
+	Username string
+	Token    string
+
+	Loading binding.Bool
+	Status  binding.String
+	Error   binding.String
+
+	Snapshots binding.StringList
+	FromIndex binding.Int
+	ToIndex   binding.Int
+
+	Starred   binding.List[domain.Repo]
+	Unstarred binding.List[domain.Repo]
+	Renamed   binding.List[history.Rename]
+	Archived  binding.List[domain.Repo]
+
+	store     *history.Store
+	runOnMain func(func())
+
+	mu     sync.Mutex
+	times  []time.Time
+	report string
+}
+
+func NewVM(store *history.Store, username, token string, runOnMain func(func())) *VM {
+	sameRepo := func(a, b domain.Repo) bool { return a == b }
+	vm := &VM{
+		Username:  username,
+		Token:     token,
+		Loading:   binding.NewBool(),
+		Status:    binding.NewString(),
+		Error:     binding.NewString(),
+		Snapshots: binding.NewStringList(),
+		FromIndex: binding.NewInt(),
+		ToIndex:   binding.NewInt(),
+		Starred:   binding.NewList(sameRepo),
+		Unstarred: binding.NewList(sameRepo),
+		Renamed:   binding.NewList(func(a, b history.Rename) bool { return a == b }),
+		Archived:  binding.NewList(sameRepo),
+		store:     store,
+		runOnMain: runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	_ = vm.Status.Set("Ready")
+	return vm
+}
+
+// Load lists the saved snapshots and compares the latest with the one before.
+func (vm *VM) Load() {
+	vm.runOnMain(func() {
+		_ = vm.Loading.Set(true)
+		_ = vm.Error.Set("")
+		_ = vm.Status.Set("Loading snapshots...")
+	})
+
+	go func() {
+		times, err := vm.store.List(vm.Username)
+		if err != nil {
+			vm.fail(err)
+			return
+		}
+
+		vm.mu.Lock()
+		vm.times = times
+		vm.mu.Unlock()
+
+		labels := make([]string, len(times))
+		for i, t := range times {
+			labels[i] = t.Local().Format(snapshotLabelLayout)
+		}
+		vm.runOnMain(func() {
+			_ = vm.Snapshots.Set(labels)
+		})
+
+		if len(times) < 2 {
+			vm.runOnMain(func() {
+				_ = vm.Loading.Set(false)
+				_ = vm.Status.Set(fmt.Sprintf("%d snapshot(s) saved; sync again to see changes", len(times)))
+			})
+			return
+		}
+		vm.Compare(len(times)-2, len(times)-1)
+	}()
+}
+
+// CompareLastWeek compares the latest snapshot with the newest one taken at
+// least seven days earlier, falling back to the oldest snapshot.
+func (vm *VM) CompareLastWeek() {
+	vm.mu.Lock()
+	times := vm.times
+	vm.mu.Unlock()
+	if len(times) < 2 {
+		return
+	}
+
+	latest := len(times) - 1
+	cutoff := times[latest].AddDate(0, 0, -7)
+	from := 0
+	for i, t := range times[:latest] {
+		if !t.After(cutoff) {
+			from = i
+		}
+	}
+	vm.Compare(from, latest)
+}
+
+// Compare diffs the snapshots at the given indexes of Snapshots.
+func (vm *VM) Compare(from, to int) {
+	vm.mu.Lock()
+	times := vm.times
+	vm.mu.Unlock()
+	if from < 0 || to < 0 || from >= len(times) || to >= len(times) {
+		vm.fail(errors.New("select two snapshots to compare"))
+		return
+	}
+
+	vm.runOnMain(func() {
+		_ = vm.FromIndex.Set(from)
+		_ = vm.ToIndex.Set(to)
+		_ = vm.Loading.Set(true)
+		_ = vm.Error.Set("")
+		_ = vm.Status.Set("Comparing...")
+	})
+
+	go func() {
+		older, err := vm.store.Load(vm.Username, times[from])
+		if err != nil {
+			vm.fail(err)
+			return
+		}
+		newer, err := vm.store.Load(vm.Username, times[to])
+		if err != nil {
+			vm.fail(err)
+			return
+		}
+
+		diff := history.Diff(older, newer)
+		vm.mu.Lock()
+		vm.report = diff.Summary(older, newer)
+		vm.mu.Unlock()
+
+		vm.runOnMain(func() {
+			_ = vm.Starred.Set(diff.Starred)
+			_ = vm.Unstarred.Set(diff.Unstarred)
+			_ = vm.Renamed.Set(diff.Renamed)
+			_ = vm.Archived.Set(diff.Archived)
+			_ = vm.Loading.Set(false)
+			_ = vm.Status.Set(fmt.Sprintf("%d starred, %d unstarred, %d renamed, %d archived",
+				len(diff.Starred), len(diff.Unstarred), len(diff.Renamed), len(diff.Archived)))
+		})
+	}()
+}
+
+// Report returns the plain-text summary of the current comparison.
+func (vm *VM) Report() string {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	return vm.report
+}
+
+func (vm *VM) fail(err error) {
+	vm.runOnMain(func() {
+		_ = vm.Loading.Set(false)
+		_ = vm.Error.Set(err.Error())
+		_ = vm.Status.Set("Compare failed")
+	})
+}
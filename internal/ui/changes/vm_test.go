@@ -0,0 +1,82 @@
+package changes_test
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/changes"
+)
+
+func seedSnapshots(t *testing.T, store *history.Store, takenAt ...time.Time) {
+	t.Helper()
+	list := testdata.SampleRepoList()
+	for i, at := range takenAt {
+		// Each snapshot stars one more repo than the one before, since the
+		// store skips snapshots that match the latest one.
+		for len(list) <= i {
+			list = append(list, domain.Repo{ID: int64(1000 + i), FullName: fmt.Sprintf("extra/repo-%d", i)})
+		}
+		repos := list[:i+1]
+		err := store.Save(history.Snapshot{Username: "testuser", TakenAt: at, Repos: repos})
+		testutil.AssertNoError(t, err)
+	}
+}
+
+func TestVM_Load_ComparesLatestTwoSnapshots(t *testing.T) {
+	_ = test.NewApp()
+	store := history.NewStore(t.TempDir())
+	start := time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC)
+	seedSnapshots(t, store, start, start.AddDate(0, 0, 1), start.AddDate(0, 0, 2))
+
+	vm := changes.NewVM(store, "testuser", "", func(f func()) { f() })
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	from, _ := vm.FromIndex.Get()
+	to, _ := vm.ToIndex.Get()
+	starred, _ := vm.Starred.Get()
+	testutil.AssertEqual(t, 1, from)
+	testutil.AssertEqual(t, 2, to)
+	testutil.AssertEqual(t, 1, len(starred))
+	testutil.AssertEqual(t, "microsoft/vscode", starred[0].FullName)
+}
+
+func TestVM_CompareLastWeek(t *testing.T) {
+	_ = test.NewApp()
+	store := history.NewStore(t.TempDir())
+	latest := time.Date(2024, 3, 15, 9, 0, 0, 0, time.UTC)
+	seedSnapshots(t, store, latest.AddDate(0, 0, -10), latest.AddDate(0, 0, -8), latest.AddDate(0, 0, -3), latest)
+
+	vm := changes.NewVM(store, "testuser", "", func(f func()) { f() })
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+	vm.CompareLastWeek()
+	time.Sleep(50 * time.Millisecond)
+
+	from, _ := vm.FromIndex.Get()
+	starred, _ := vm.Starred.Get()
+	testutil.AssertEqual(t, 1, from)
+	testutil.AssertEqual(t, 2, len(starred))
+	testutil.AssertTrue(t, len(vm.Report()) > 0, "report should be available after comparing")
+}
+
+func TestVM_Load_SingleSnapshot(t *testing.T) {
+	_ = test.NewApp()
+	store := history.NewStore(t.TempDir())
+	seedSnapshots(t, store, time.Date(2024, 3, 1, 9, 0, 0, 0, time.UTC))
+
+	vm := changes.NewVM(store, "testuser", "", func(f func()) { f() })
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	status, _ := vm.Status.Get()
+	starred, _ := vm.Starred.Get()
+	testutil.AssertEqual(t, "1 snapshot(s) saved; sync again to see changes", status)
+	testutil.AssertEqual(t, 0, len(starred))
+}
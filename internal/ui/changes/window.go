@@ -0,0 +1,19 @@
+package changes
+
+import (
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/ui/route"
+)
+
+func NewChangesWindow(app fyne.App, store *history.Store, username, token string, router route.Router) fyne.Window {
+	w := app.NewWindow("Changes: " + username)
+	w.Resize(fyne.NewSize(900, 600))
+
+	vm := NewVM(store, username, token, fyne.Do)
+	w.SetContent(NewView(w, vm, router))
+	vm.Load()
+
+	return w
+}
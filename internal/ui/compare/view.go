@@ -0,0 +1,116 @@
+package compare
+
+import (
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/ui/route"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
+	title := canvas.NewText("Compare Stars", theme.ForegroundColor())
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = theme.TextHeadingSize()
+
+	subtitle := canvas.NewText("Find the repos a group of users has in common.", theme.DisabledColor())
+	subtitle.TextSize = theme.TextSubHeadingSize()
+
+	users := widget.NewEntryWithData(vm.Usernames)
+	users.SetPlaceHolder("alice, bob, carol")
+	users.OnSubmitted = func(string) { vm.Load() }
+	compareBtn := widget.NewButtonWithIcon("Compare", theme.SearchIcon(), vm.Load)
+	copyBtn := widget.NewButtonWithIcon("Copy List", theme.ContentCopyIcon(), func() {
+		if report := vm.Report(); report != "" {
+			w.Clipboard().SetContent(report)
+		}
+	})
+
+	vm.Loading.AddListener(binding.NewDataListener(func() {
+		loading, _ := vm.Loading.Get()
+		if loading {
+			compareBtn.Disable()
+		} else {
+			compareBtn.Enable()
+		}
+	}))
+
+	actionBar := container.NewHBox(layout.NewSpacer(), compareBtn, copyBtn)
+	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+	usersRow := widget.NewForm(widget.NewFormItem("Users", users))
+
+	viewSelect := widget.NewSelect(nil, nil)
+	viewSelect.PlaceHolder = "Compare first"
+	viewSelect.OnChanged = func(string) {
+		if index := viewSelect.SelectedIndex(); index >= 0 {
+			if current, _ := vm.ViewIndex.Get(); current != index {
+				vm.SetView(index)
+			}
+		}
+	}
+	vm.Views.AddListener(binding.NewDataListener(func() {
+		views, _ := vm.Views.Get()
+		viewSelect.SetOptions(views)
+	}))
+	vm.ViewIndex.AddListener(binding.NewDataListener(func() {
+		index, _ := vm.ViewIndex.Get()
+		if views, _ := vm.Views.Get(); index < len(views) && viewSelect.SelectedIndex() != index {
+			viewSelect.SetSelectedIndex(index)
+		}
+	}))
+
+	filter := widget.NewEntryWithData(vm.Query)
+	filter.SetPlaceHolder("Filter: words, lang:go, topic:cli, owner:golang")
+	vm.Query.AddListener(binding.NewDataListener(vm.ApplyFilter))
+
+	list := widget.NewListWithData(vm.Entries, func() fyne.CanvasObject {
+		name := widget.NewLabel("")
+		name.Wrapping = fyne.TextTruncate
+		who := widget.NewLabel("")
+		who.Importance = widget.LowImportance
+		return container.NewBorder(nil, nil, nil, who, name)
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[compare.Entry])
+		if !ok {
+			return
+		}
+		entry, err := item.Get()
+		if err != nil {
+			return
+		}
+		row := obj.(*fyne.Container)
+		row.Objects[0].(*widget.Label).SetText(entry.Repo.FullName)
+		row.Objects[1].(*widget.Label).SetText(strings.Join(entry.StarredBy, ", "))
+	})
+	list.OnSelected = func(id widget.ListItemID) {
+		if entry, err := vm.Entries.GetValue(id); err == nil && router != nil {
+			router.ShowRepoDetails(entry.Repo.FullName, vm.Token)
+		}
+		list.Unselect(id)
+	}
+
+	pairs := widget.NewListWithData(vm.Pairs, func() fyne.CanvasObject {
+		return widget.NewLabel("")
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		value, _ := di.(binding.String).Get()
+		obj.(*widget.Label).SetText(value)
+	})
+
+	listCard := widget.NewCard("", "Select a repo to open details.",
+		container.NewBorder(container.NewGridWithColumns(2, viewSelect, filter), nil, nil, nil, list))
+	pairsCard := widget.NewCard("", "Similarity per pair (Jaccard index).", pairs)
+	split := container.NewHSplit(listCard, pairsCard)
+	split.Offset = 0.65
+
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Loading)
+	top := container.NewPadded(container.NewVBox(header, widget.NewSeparator(), usersRow))
+	return container.NewBorder(top, container.NewPadded(statusBar), nil, nil, container.NewPadded(split))
+}
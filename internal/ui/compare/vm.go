@@ -0,0 +1,186 @@
+package compare
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+)
+
+// The first two views are fixed; per-user "only" views follow.
+const (
+	viewEveryone = iota
+	viewAnyone
+	firstUserView
+)
+
+type VM struct {
+	Token string
+
+	// Usernames is a comma-separated list of GitHub users.
+	Usernames binding.String
+
+	Loading binding.Bool
+	Status  binding.String
+	Error   binding.String
+
+	Views     binding.StringList
+	ViewIndex binding.Int
+	Query     binding.String
+	Entries   binding.List[compare.Entry]
+	Pairs     binding.StringList
+
+	svc       compare.Service
+	runOnMain func(func())
+
+	mu     sync.Mutex
+	cancel context.CancelFunc
+	result compare.Result
+}
+
+func NewVM(svc compare.Service, usernames []string, token string, runOnMain func(func())) *VM {
+	vm := &VM{
+		Token:     token,
+		Usernames: binding.NewString(),
+		Loading:   binding.NewBool(),
+		Status:    binding.NewString(),
+		Error:     binding.NewString(),
+		Views:     binding.NewStringList(),
+		ViewIndex: binding.NewInt(),
+		Query:     binding.NewString(),
+		Entries: binding.NewList(func(a, b compare.Entry) bool {
+			return a.Repo == b.Repo && slices.Equal(a.StarredBy, b.StarredBy)
+		}),
+		Pairs:     binding.NewStringList(),
+		svc:       svc,
+		runOnMain: runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	_ = vm.Usernames.Set(strings.Join(usernames, ", "))
+	_ = vm.Status.Set("Enter two or more usernames")
+	return vm
+}
+
+// Load fetches the stars of every listed user and compares them.
+func (vm *VM) Load() {
+	vm.mu.Lock()
+	if vm.cancel != nil {
+		vm.cancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	vm.cancel = cancel
+	vm.mu.Unlock()
+
+	value, _ := vm.Usernames.Get()
+	usernames := strings.Split(value, ",")
+
+	vm.runOnMain(func() {
+		_ = vm.Loading.Set(true)
+		_ = vm.Error.Set("")
+		_ = vm.Status.Set("Loading stars...")
+	})
+
+	go func() {
+		users, err := vm.svc.Load(ctx, usernames, vm.Token, 100)
+		if err != nil {
+			vm.runOnMain(func() {
+				_ = vm.Loading.Set(false)
+				_ = vm.Error.Set(err.Error())
+				_ = vm.Status.Set("Compare failed")
+			})
+			return
+		}
+
+		result := compare.Compare(users)
+		vm.mu.Lock()
+		vm.result = result
+		vm.mu.Unlock()
+
+		views := []string{
+			fmt.Sprintf("Starred by everyone (%d)", len(result.Intersection())),
+			fmt.Sprintf("Starred by anyone (%d)", len(result.Union)),
+		}
+		for _, user := range result.Users {
+			views = append(views, fmt.Sprintf("Only %s (%d)", user, len(result.Only(user))))
+		}
+		pairs := make([]string, len(result.Pairs))
+		for i, pair := range result.Pairs {
+			pairs[i] = fmt.Sprintf("%s ↔ %s: %d shared, similarity %.2f", pair.A, pair.B, pair.Shared, pair.Jaccard)
+		}
+
+		vm.runOnMain(func() {
+			_ = vm.Views.Set(views)
+			_ = vm.Pairs.Set(pairs)
+			_ = vm.Loading.Set(false)
+			_ = vm.Status.Set(fmt.Sprintf("Compared %d users", len(result.Users)))
+			vm.SetView(viewEveryone)
+		})
+	}()
+}
+
+// SetView switches between everyone, anyone and per-user lists.
+func (vm *VM) SetView(index int) {
+	_ = vm.ViewIndex.Set(index)
+	vm.ApplyFilter()
+}
+
+// ApplyFilter shows the current view narrowed by Query, which accepts the
+// same syntax as the stars list filter.
+func (vm *VM) ApplyFilter() {
+	index, _ := vm.ViewIndex.Get()
+	query, _ := vm.Query.Get()
+
+	vm.mu.Lock()
+	result := vm.result
+	vm.mu.Unlock()
+
+	var entries []compare.Entry
+	switch {
+	case index == viewEveryone:
+		entries = result.Intersection()
+	case index == viewAnyone:
+		entries = result.Union
+	case index-firstUserView < len(result.Users):
+		entries = result.Only(result.Users[index-firstUserView])
+	}
+
+	q := stars.ParseQuery(query)
+	if !q.IsEmpty() {
+		var filtered []compare.Entry
+		for _, entry := range entries {
+			if q.Match(entry.Repo, nil) {
+				filtered = append(filtered, entry)
+			}
+		}
+		entries = filtered
+	}
+	_ = vm.Entries.Set(entries)
+}
+
+// Report renders the current view as plain text, one repo per line.
+func (vm *VM) Report() string {
+	entries, _ := vm.Entries.Get()
+	var b strings.Builder
+	for _, entry := range entries {
+		fmt.Fprintf(&b, "- %s %s (%s)\n", entry.Repo.FullName, entry.Repo.HTMLURL, strings.Join(entry.StarredBy, ", "))
+	}
+	return b.String()
+}
+
+func (vm *VM) Cleanup() {
+	vm.mu.Lock()
+	if vm.cancel != nil {
+		vm.cancel()
+		vm.cancel = nil
+	}
+	vm.mu.Unlock()
+}
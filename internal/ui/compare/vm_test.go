@@ -0,0 +1,78 @@
+package compare_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	uicompare "github.com/tbxark/gh-stars/internal/ui/compare"
+)
+
+func newService() compare.Service {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		list := testdata.SampleRepoList()
+		if username == "bob" {
+			return list[1:], nil
+		}
+		return list, nil
+	}
+	return compare.Service{Stars: mockSvc}
+}
+
+func TestVM_Load_ShowsIntersectionFirst(t *testing.T) {
+	_ = test.NewApp()
+	vm := uicompare.NewVM(newService(), []string{"alice"}, "", func(f func()) { f() })
+	_ = vm.Usernames.Set("alice, bob")
+
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	entries, _ := vm.Entries.Get()
+	testutil.AssertEqual(t, 2, len(entries))
+	views, _ := vm.Views.Get()
+	testutil.AssertEqual(t, 4, len(views))
+	testutil.AssertEqual(t, "Only alice (1)", views[2])
+	pairs, _ := vm.Pairs.Get()
+	testutil.AssertEqual(t, 1, len(pairs))
+	testutil.AssertEqual(t, "alice ↔ bob: 2 shared, similarity 0.67", pairs[0])
+}
+
+func TestVM_SetView_AndFilter(t *testing.T) {
+	_ = test.NewApp()
+	vm := uicompare.NewVM(newService(), []string{"alice", "bob"}, "", func(f func()) { f() })
+
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+	vm.SetView(2)
+
+	entries, _ := vm.Entries.Get()
+	testutil.AssertEqual(t, 1, len(entries))
+	testutil.AssertEqual(t, "golang/go", entries[0].Repo.FullName)
+
+	vm.SetView(1)
+	_ = vm.Query.Set("lang:typescript")
+	vm.ApplyFilter()
+
+	entries, _ = vm.Entries.Get()
+	testutil.AssertEqual(t, 1, len(entries))
+	testutil.AssertEqual(t, "microsoft/vscode", entries[0].Repo.FullName)
+}
+
+func TestVM_Load_NeedsTwoUsers(t *testing.T) {
+	_ = test.NewApp()
+	vm := uicompare.NewVM(newService(), []string{"alice"}, "", func(f func()) { f() })
+
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	errMsg, _ := vm.Error.Get()
+	testutil.AssertEqual(t, "enter at least two usernames to compare", errMsg)
+}
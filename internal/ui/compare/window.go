@@ -0,0 +1,21 @@
+package compare
+
+import (
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/ui/route"
+)
+
+func NewCompareWindow(app fyne.App, svc compare.Service, usernames []string, token string, router route.Router) fyne.Window {
+	w := app.NewWindow("Compare Stars")
+	w.Resize(fyne.NewSize(1000, 650))
+
+	vm := NewVM(svc, usernames, token, fyne.Do)
+	w.SetContent(NewView(w, vm, router))
+	w.SetOnClosed(func() {
+		vm.Cleanup()
+	})
+
+	return w
+}
@@ -0,0 +1,239 @@
+package credentials
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+// NewCard shows the username, token and page size with what is known about
+// the token, and the sign-in and remember buttons where they are enabled.
+func NewCard(w fyne.Window, vm *VM) fyne.CanvasObject {
+	var tokenActions []fyne.CanvasObject
+	if vm.HasTokenStore() {
+		tokenActions = newTokenActions(w, vm)
+	}
+	form := widgets.NewCredentialsForm(vm.Username, vm.Token, vm.PerPage, tokenActions...)
+	tokenSource := widget.NewLabelWithData(vm.TokenSource)
+	tokenSource.Importance = widget.LowImportance
+	vm.Token.AddListener(binding.NewDataListener(vm.NoteTokenChanged))
+	tokenCheck := widget.NewLabelWithData(vm.TokenCheck)
+	tokenCheck.Importance = widget.LowImportance
+	tokenWarning := widget.NewLabelWithData(vm.TokenWarning)
+	tokenWarning.Importance = widget.WarningImportance
+	tokenWarning.Wrapping = fyne.TextWrapWord
+	tokenInfo := container.NewVBox(tokenSource, tokenCheck, tokenWarning)
+
+	body := container.NewVBox(form, tokenInfo)
+	if vm.CanSignIn() {
+		body = container.NewVBox(form, container.NewBorder(nil, nil, nil, newSignInButton(w, vm), tokenInfo))
+	}
+	return widget.NewCard(
+		"",
+		"Token is optional but improves rate limits. Leave the username empty to use the token's account.",
+		body,
+	)
+}
+
+// NewProfileBar picks, saves and deletes the saved profiles.
+func NewProfileBar(w fyne.Window, vm *VM) fyne.CanvasObject {
+	profileSelect := widget.NewSelect(nil, nil)
+	profileSelect.PlaceHolder = "Profiles"
+	syncing := false
+	profileSelect.OnChanged = func(name string) {
+		if syncing || name == "" {
+			return
+		}
+		if active, _ := vm.ActiveProfile.Get(); active == name {
+			return
+		}
+		vm.SwitchProfile(name)
+	}
+	vm.Profiles.AddListener(binding.NewDataListener(func() {
+		names, _ := vm.Profiles.Get()
+		syncing = true
+		profileSelect.SetOptions(names)
+		syncing = false
+	}))
+	vm.ActiveProfile.AddListener(binding.NewDataListener(func() {
+		active, _ := vm.ActiveProfile.Get()
+		syncing = true
+		if active == "" {
+			profileSelect.ClearSelected()
+		} else {
+			profileSelect.SetSelected(active)
+		}
+		syncing = false
+	}))
+
+	saveBtn := widget.NewButtonWithIcon("", theme.DocumentSaveIcon(), func() {
+		active, _ := vm.ActiveProfile.Get()
+		name := widget.NewEntry()
+		name.SetText(active)
+		name.SetPlaceHolder("Work")
+		host := widget.NewEntry()
+		if current := vm.host(); current != profile.DefaultHost {
+			host.SetText(current)
+		}
+		host.SetPlaceHolder(profile.DefaultHost)
+		hostItem := widget.NewFormItem("Host", host)
+		hostItem.HintText = "Only for GitHub Enterprise"
+		dialog.ShowForm("Save profile", "Save", "Cancel", []*widget.FormItem{
+			widget.NewFormItem("Name", name),
+			hostItem,
+		}, func(ok bool) {
+			if !ok {
+				return
+			}
+			withUnlockedTokens(w, vm, func() {
+				if err := vm.SaveProfile(name.Text, host.Text); err != nil {
+					dialog.ShowError(err, w)
+				}
+			})
+		}, w)
+	})
+	deleteBtn := widget.NewButtonWithIcon("", theme.DeleteIcon(), func() {
+		active, _ := vm.ActiveProfile.Get()
+		if active == "" {
+			return
+		}
+		dialog.ShowConfirm("Delete profile", fmt.Sprintf("Delete %q and its saved token?", active), func(ok bool) {
+			if ok {
+				withUnlockedTokens(w, vm, vm.DeleteProfile)
+			}
+		}, w)
+	})
+
+	return container.NewHBox(profileSelect, saveBtn, deleteBtn)
+}
+
+func newSignInButton(w fyne.Window, vm *VM) *widget.Button {
+	var codeDialog dialog.Dialog
+	vm.SignInCode.AddListener(binding.NewDataListener(func() {
+		code, _ := vm.SignInCode.Get()
+		if code == "" {
+			if codeDialog != nil {
+				d := codeDialog
+				codeDialog = nil
+				d.Hide()
+			}
+			return
+		}
+
+		codeLabel := widget.NewLabelWithStyle(code, fyne.TextAlignCenter, fyne.TextStyle{Bold: true, Monospace: true})
+		codeLabel.Selectable = true
+		signInURL, _ := vm.SignInURL.Get()
+		openBtn := widget.NewButtonWithIcon("Copy Code and Open GitHub", theme.ComputerIcon(), func() {
+			w.Clipboard().SetContent(code)
+			if parsed, err := url.Parse(signInURL); err == nil {
+				_ = fyne.CurrentApp().OpenURL(parsed)
+			}
+		})
+		openBtn.Importance = widget.HighImportance
+		waiting := widget.NewProgressBarInfinite()
+		content := container.NewVBox(
+			widget.NewLabel("Enter this code on GitHub to sign in:"),
+			codeLabel,
+			widget.NewLabel(signInURL),
+			openBtn,
+			waiting,
+		)
+
+		d := dialog.NewCustom("Sign in with GitHub", "Cancel", content, w)
+		d.SetOnClosed(func() {
+			if codeDialog == d {
+				codeDialog = nil
+				vm.CancelSignIn()
+			}
+		})
+		codeDialog = d
+		d.Show()
+	}))
+
+	btn := widget.NewButtonWithIcon("Sign in with GitHub", theme.LoginIcon(), func() {
+		withUnlockedTokens(w, vm, vm.SignIn)
+	})
+	vm.SigningIn.AddListener(binding.NewDataListener(func() {
+		if signingIn, _ := vm.SigningIn.Get(); signingIn {
+			btn.Disable()
+		} else {
+			btn.Enable()
+		}
+	}))
+	return btn
+}
+
+func newTokenActions(w fyne.Window, vm *VM) []fyne.CanvasObject {
+	remember := widget.NewButtonWithIcon("Remember", theme.DocumentSaveIcon(), func() {
+		withUnlockedTokens(w, vm, func() {
+			if err := vm.RememberToken(); err != nil {
+				dialog.ShowError(err, w)
+			}
+		})
+	})
+	forget := widget.NewButtonWithIcon("Forget", theme.ContentClearIcon(), func() {
+		withUnlockedTokens(w, vm, func() {
+			if err := vm.ForgetToken(); err != nil {
+				dialog.ShowError(err, w)
+			}
+		})
+	})
+	return []fyne.CanvasObject{remember, forget}
+}
+
+// withUnlockedTokens runs then once the token store is unlocked, asking for
+// the passphrase if this session has not provided it yet.
+func withUnlockedTokens(w fyne.Window, vm *VM, then func()) {
+	if !vm.HasTokenStore() || !vm.NeedsUnlock() {
+		then()
+		return
+	}
+	showUnlockDialog(w, vm, then)
+}
+
+func showUnlockDialog(w fyne.Window, vm *VM, then func()) {
+	passphrase := widget.NewPasswordEntry()
+	items := []*widget.FormItem{widget.NewFormItem("Passphrase", passphrase)}
+	title := "Unlock saved tokens"
+	var confirm *widget.Entry
+	if !vm.TokenStoreExists() {
+		title = "Choose a passphrase for saved tokens"
+		confirm = widget.NewPasswordEntry()
+		items = append(items, widget.NewFormItem("Confirm", confirm))
+	}
+
+	dialog.ShowForm(title, "Unlock", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		if confirm != nil && confirm.Text != passphrase.Text {
+			dialog.ShowError(errors.New("passphrases do not match"), w)
+			return
+		}
+		if err := vm.UnlockTokens(passphrase.Text); err != nil {
+			dialog.ShowError(err, w)
+			return
+		}
+		if then != nil {
+			then()
+		}
+	}, w)
+}
+
+// AskToUnlock asks for the passphrase of an existing, locked token store so
+// the remembered token can be filled in.
+func AskToUnlock(w fyne.Window, vm *VM) {
+	if vm.HasTokenStore() && vm.NeedsUnlock() && vm.TokenStoreExists() {
+		showUnlockDialog(w, vm, nil)
+	}
+}
@@ -0,0 +1,745 @@
+package credentials
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/deviceflow"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/app/tokensource"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// VM holds the account the stars list loads with: the username, token and
+// page size, the saved profiles, remembered tokens and the GitHub sign-in.
+type VM struct {
+	Username binding.String
+	Token    binding.String
+	PerPage  binding.String
+
+	// Status and Error report the outcome of the last action; the stars
+	// window shares them with its own.
+	Status binding.String
+	Error  binding.String
+
+	Profiles      binding.StringList
+	ActiveProfile binding.String
+	// TokenSource says where a token that was not typed in came from.
+	TokenSource binding.String
+
+	// TokenCheck describes the token after its first use; TokenWarning
+	// lists what will fail with it.
+	TokenCheck   binding.String
+	TokenWarning binding.String
+
+	// SigningIn is set while a device flow sign-in waits for the user, who
+	// has to enter SignInCode at SignInURL.
+	SigningIn  binding.Bool
+	SignInCode binding.String
+	SignInURL  binding.String
+
+	profiles  *profile.Store
+	tokens    profile.Tokens
+	finder    TokenFinder
+	users     UserLookup
+	login     DeviceLogin
+	checker   TokenChecker
+	onHost    func(host string)
+	runOnMain func(func())
+
+	mu sync.Mutex
+	// defaultPerPage is the page size when none is typed in.
+	defaultPerPage int
+	// filledToken is the token last filled in from TokenSource.
+	filledToken string
+	// checkedToken is the token checkedInfo belongs to. Only the current
+	// token's check is kept; a replaced one is dropped, here and in the
+	// checker.
+	checkedToken string
+	checkedInfo  domain.TokenInfo
+	signInCancel context.CancelFunc
+	onSwitch     func(p profile.Profile)
+}
+
+// Option configures optional VM collaborators.
+type Option func(*VM)
+
+// WithProfiles enables saved accounts. Their tokens are kept in the store
+// given to WithTokens under each profile's TokenRef.
+func WithProfiles(store *profile.Store) Option {
+	return func(vm *VM) {
+		vm.profiles = store
+	}
+}
+
+// TokenVault is a token store that has to be unlocked once per session;
+// credstore.Store implements it.
+type TokenVault interface {
+	profile.Tokens
+	Exists() bool
+	Unlocked() bool
+	Unlock(passphrase string) error
+}
+
+// WithTokens enables remembering tokens. When tokens is a TokenVault the view
+// asks for its passphrase before using it.
+func WithTokens(tokens profile.Tokens) Option {
+	return func(vm *VM) {
+		vm.tokens = tokens
+	}
+}
+
+// TokenFinder looks for a token other tools already have for a host;
+// tokensource.Finder implements it.
+type TokenFinder interface {
+	Find(host string) (tokensource.Found, bool)
+}
+
+// WithTokenFinder fills in an existing token when none is typed or
+// remembered.
+func WithTokenFinder(finder TokenFinder) Option {
+	return func(vm *VM) {
+		vm.finder = finder
+	}
+}
+
+// WithHostChange is told the GitHub host of every profile switched to, and
+// github.com when the active profile is deleted, so API requests can follow
+// the profile to a GitHub Enterprise server.
+func WithHostChange(fn func(host string)) Option {
+	return func(vm *VM) {
+		vm.onHost = fn
+	}
+}
+
+// UserLookup resolves the login a token belongs to; github.Client
+// implements it.
+type UserLookup interface {
+	GetAuthenticatedUser(ctx context.Context, token string) (string, error)
+}
+
+// WithUserLookup fills in an empty username from the token.
+func WithUserLookup(users UserLookup) Option {
+	return func(vm *VM) {
+		vm.users = users
+	}
+}
+
+// DeviceLogin runs the OAuth device flow; deviceflow.Config implements it.
+type DeviceLogin interface {
+	RequestCode(ctx context.Context) (deviceflow.Code, error)
+	PollToken(ctx context.Context, code deviceflow.Code) (deviceflow.Token, error)
+}
+
+// WithDeviceLogin enables "Sign in with GitHub".
+func WithDeviceLogin(login DeviceLogin) Option {
+	return func(vm *VM) {
+		vm.login = login
+	}
+}
+
+// TokenChecker inspects a token's kind, scopes and expiry; github.Client
+// implements it. ForgetTokenCheck drops a cached result.
+type TokenChecker interface {
+	CheckToken(ctx context.Context, token string) (domain.TokenInfo, error)
+	ForgetTokenCheck(token string)
+}
+
+// WithTokenCheck checks each token the first time CheckToken is called
+// with it.
+func WithTokenCheck(checker TokenChecker) Option {
+	return func(vm *VM) {
+		vm.checker = checker
+	}
+}
+
+func NewVM(runOnMain func(func()), opts ...Option) *VM {
+	vm := &VM{
+		Username:       binding.NewString(),
+		Token:          binding.NewString(),
+		PerPage:        binding.NewString(),
+		Status:         binding.NewString(),
+		Error:          binding.NewString(),
+		Profiles:       binding.NewStringList(),
+		ActiveProfile:  binding.NewString(),
+		TokenSource:    binding.NewString(),
+		TokenCheck:     binding.NewString(),
+		TokenWarning:   binding.NewString(),
+		SigningIn:      binding.NewBool(),
+		SignInCode:     binding.NewString(),
+		SignInURL:      binding.NewString(),
+		runOnMain:      runOnMain,
+		defaultPerPage: 100,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	for _, opt := range opts {
+		opt(vm)
+	}
+	_ = vm.PerPage.Set(strconv.Itoa(vm.defaultPerPage))
+	return vm
+}
+
+// SetDefaultPerPage changes the page size used when none is typed in and
+// fills it in.
+func (vm *VM) SetDefaultPerPage(perPage int) {
+	if perPage <= 0 {
+		return
+	}
+	vm.mu.Lock()
+	vm.defaultPerPage = perPage
+	vm.mu.Unlock()
+	_ = vm.PerPage.Set(strconv.Itoa(perPage))
+}
+
+// PageSize returns the typed page size, or the default when none is typed.
+func (vm *VM) PageSize() (int, error) {
+	value, _ := vm.PerPage.Get()
+	vm.mu.Lock()
+	fallback := vm.defaultPerPage
+	vm.mu.Unlock()
+	return parsePerPage(value, fallback)
+}
+
+// OnProfileSwitch registers fn to run whenever a profile is switched to,
+// before its credentials are filled in, so work for the previous account
+// can be dropped.
+func (vm *VM) OnProfileSwitch(fn func(p profile.Profile)) {
+	vm.mu.Lock()
+	vm.onSwitch = fn
+	vm.mu.Unlock()
+}
+
+// ResolveUsername returns the login token belongs to, or "" when no
+// UserLookup is configured.
+func (vm *VM) ResolveUsername(ctx context.Context, token string) (string, error) {
+	if vm.users == nil || token == "" {
+		return "", nil
+	}
+	return vm.users.GetAuthenticatedUser(ctx, token)
+}
+
+// CheckToken runs the token check once per token and shows the result.
+func (vm *VM) CheckToken(token string) {
+	if vm.checker == nil || token == "" {
+		return
+	}
+	vm.mu.Lock()
+	info, done := vm.checkedInfo, vm.checkedToken == token
+	vm.mu.Unlock()
+	if done {
+		vm.showTokenCheck(info)
+		return
+	}
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+		defer cancel()
+		info, err := vm.checker.CheckToken(ctx, token)
+		if err != nil {
+			if !vm.isCurrentToken(token) {
+				return
+			}
+			vm.runOnMain(func() {
+				_ = vm.TokenCheck.Set("")
+				_ = vm.TokenWarning.Set("token check failed: " + err.Error())
+			})
+			return
+		}
+		// The token may have changed while the check ran; then its result
+		// is dropped rather than shown for another token.
+		if !vm.isCurrentToken(token) {
+			vm.checker.ForgetTokenCheck(token)
+			return
+		}
+		vm.mu.Lock()
+		vm.checkedToken, vm.checkedInfo = token, info
+		vm.mu.Unlock()
+		vm.showTokenCheck(info)
+	}()
+}
+
+func (vm *VM) isCurrentToken(token string) bool {
+	current, _ := vm.Token.Get()
+	return current == token
+}
+
+func (vm *VM) showTokenCheck(info domain.TokenInfo) {
+	warnings := info.Warnings(time.Now())
+	vm.runOnMain(func() {
+		_ = vm.TokenCheck.Set(info.Summary())
+		_ = vm.TokenWarning.Set(strings.Join(warnings, "; "))
+	})
+}
+
+// CanSignIn reports whether the device flow is configured.
+func (vm *VM) CanSignIn() bool {
+	return vm.login != nil
+}
+
+// SignIn starts the device flow. Once the user has entered the code, the
+// token is filled in, the username is resolved if empty, and the token is
+// remembered for the active profile when a token store is available.
+func (vm *VM) SignIn() {
+	if vm.login == nil {
+		return
+	}
+	vm.mu.Lock()
+	if vm.signInCancel != nil {
+		vm.signInCancel()
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 15*time.Minute)
+	vm.signInCancel = cancel
+	vm.mu.Unlock()
+
+	vm.runOnMain(func() {
+		_ = vm.SigningIn.Set(true)
+		_ = vm.Error.Set("")
+		_ = vm.Status.Set("Contacting GitHub...")
+	})
+
+	go func() {
+		defer cancel()
+		fail := func(err error) {
+			if errors.Is(ctx.Err(), context.Canceled) {
+				return
+			}
+			vm.runOnMain(func() {
+				_ = vm.SigningIn.Set(false)
+				_ = vm.SignInCode.Set("")
+				_ = vm.Error.Set(err.Error())
+				_ = vm.Status.Set("Sign-in failed")
+			})
+		}
+
+		code, err := vm.login.RequestCode(ctx)
+		if err != nil {
+			fail(err)
+			return
+		}
+		vm.runOnMain(func() {
+			_ = vm.SignInURL.Set(code.VerificationURI)
+			_ = vm.SignInCode.Set(code.UserCode)
+			_ = vm.Status.Set("Waiting for GitHub sign-in...")
+		})
+
+		token, err := vm.login.PollToken(ctx, code)
+		if err != nil {
+			fail(err)
+			return
+		}
+
+		username, _ := vm.Username.Get()
+		if strings.TrimSpace(username) == "" {
+			if login, err := vm.ResolveUsername(ctx, token.AccessToken); err == nil && login != "" {
+				username = login
+			}
+		}
+		vm.mu.Lock()
+		vm.filledToken = token.AccessToken
+		vm.mu.Unlock()
+		vm.runOnMain(func() {
+			_ = vm.Username.Set(username)
+			_ = vm.Token.Set(token.AccessToken)
+			_ = vm.TokenSource.Set("Using token from GitHub sign-in")
+			_ = vm.SigningIn.Set(false)
+			_ = vm.SignInCode.Set("")
+			_ = vm.Status.Set("Signed in")
+		})
+
+		if vm.tokens != nil && !vm.NeedsUnlock() {
+			if err := vm.rememberToken(username, token.AccessToken); err != nil {
+				vm.runOnMain(func() {
+					_ = vm.Error.Set("signed in, but the token was not saved: " + err.Error())
+				})
+			}
+		}
+	}()
+}
+
+// CancelSignIn stops a running sign-in.
+func (vm *VM) CancelSignIn() {
+	vm.mu.Lock()
+	cancel := vm.signInCancel
+	vm.signInCancel = nil
+	vm.mu.Unlock()
+	if cancel == nil {
+		return
+	}
+	cancel()
+	vm.runOnMain(func() {
+		_ = vm.SigningIn.Set(false)
+		_ = vm.SignInCode.Set("")
+	})
+}
+
+// HasProfiles reports whether saved accounts are enabled.
+func (vm *VM) HasProfiles() bool {
+	return vm.profiles != nil
+}
+
+// LoadProfiles lists the saved profiles and switches to the one used last.
+func (vm *VM) LoadProfiles() {
+	if vm.profiles == nil {
+		return
+	}
+	vm.refreshProfiles()
+	if active := vm.profiles.Active(); active != "" {
+		vm.SwitchProfile(active)
+	}
+}
+
+// SwitchProfile cancels a running sign-in and fills the credentials from the
+// profile called name.
+func (vm *VM) SwitchProfile(name string) {
+	if vm.profiles == nil {
+		return
+	}
+	p, ok := vm.profiles.Get(name)
+	if !ok {
+		return
+	}
+	vm.CancelSignIn()
+	vm.mu.Lock()
+	onSwitch := vm.onSwitch
+	vm.mu.Unlock()
+	if onSwitch != nil {
+		onSwitch(p)
+	}
+	vm.profiles.SetActive(p.Name)
+	vm.switchHost(p.HostOrDefault())
+
+	token := ""
+	if vm.tokens != nil {
+		token, _ = vm.tokens.Token(p.TokenRef)
+	}
+	perPage := ""
+	if p.PerPage > 0 {
+		perPage = strconv.Itoa(p.PerPage)
+	}
+
+	vm.runOnMain(func() {
+		_ = vm.ActiveProfile.Set(p.Name)
+		_ = vm.Username.Set(p.Username)
+		_ = vm.Token.Set(token)
+		_ = vm.TokenSource.Set("")
+		_ = vm.PerPage.Set(perPage)
+		_ = vm.Error.Set("")
+	})
+	vm.mu.Lock()
+	vm.filledToken = ""
+	vm.mu.Unlock()
+	if token == "" {
+		vm.discoverToken(p.HostOrDefault())
+	}
+}
+
+// SaveProfile stores the current credentials as the profile called name on
+// host, "" being github.com, and makes it active. An empty token forgets any
+// token saved for it.
+func (vm *VM) SaveProfile(name, host string) error {
+	if vm.profiles == nil {
+		return errors.New("profiles are not available")
+	}
+	username, _ := vm.Username.Get()
+	token, _ := vm.Token.Get()
+	perPage, err := vm.PageSize()
+	if err != nil {
+		return err
+	}
+
+	p := profile.Profile{Name: strings.TrimSpace(name), Username: strings.TrimSpace(username), Host: host, PerPage: perPage}
+	existing, ok := vm.profiles.Get(p.Name)
+	if ok && strings.EqualFold(existing.HostOrDefault(), p.HostOrDefault()) {
+		p.TokenRef = existing.TokenRef
+	}
+	if p.TokenRef == "" {
+		p.TokenRef = p.DefaultTokenRef()
+	}
+	// The token goes first: a locked store then fails the save before a
+	// profile without its token is stored.
+	if vm.tokens != nil {
+		if token == "" {
+			err = vm.tokens.ForgetToken(p.TokenRef)
+		} else {
+			err = vm.tokens.SetToken(p.TokenRef, token)
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if err := vm.profiles.Save(p); err != nil {
+		if vm.tokens != nil && token != "" && p.TokenRef != existing.TokenRef {
+			_ = vm.tokens.ForgetToken(p.TokenRef)
+		}
+		return err
+	}
+	vm.profiles.SetActive(p.Name)
+	vm.switchHost(p.HostOrDefault())
+	vm.refreshProfiles()
+	vm.runOnMain(func() {
+		_ = vm.ActiveProfile.Set(p.Name)
+		_ = vm.Status.Set("Saved profile " + p.Name)
+	})
+	return nil
+}
+
+// DeleteProfile removes the active profile and its saved token. Nothing is
+// deleted while the token store is locked.
+func (vm *VM) DeleteProfile() {
+	if vm.profiles == nil {
+		return
+	}
+	name, _ := vm.ActiveProfile.Get()
+	p, ok := vm.profiles.Get(name)
+	if !ok {
+		return
+	}
+	// Forgetting the token first keeps a locked store from leaving it
+	// behind with no profile pointing at it.
+	if vm.tokens != nil {
+		if err := vm.tokens.ForgetToken(p.TokenRef); err != nil {
+			vm.runOnMain(func() {
+				_ = vm.Error.Set("could not delete the profile's token: " + err.Error())
+			})
+			return
+		}
+	}
+	if err := vm.profiles.Delete(p.Name); err != nil {
+		vm.runOnMain(func() {
+			_ = vm.Error.Set(err.Error())
+		})
+		return
+	}
+	vm.switchHost(profile.DefaultHost)
+	vm.refreshProfiles()
+	vm.runOnMain(func() {
+		_ = vm.ActiveProfile.Set("")
+		_ = vm.Status.Set("Deleted profile " + p.Name)
+	})
+}
+
+// HasTokenStore reports whether tokens can be remembered.
+func (vm *VM) HasTokenStore() bool {
+	return vm.tokens != nil
+}
+
+// NeedsUnlock reports whether the token store has to be unlocked first.
+func (vm *VM) NeedsUnlock() bool {
+	vault, ok := vm.tokens.(TokenVault)
+	return ok && !vault.Unlocked()
+}
+
+// TokenStoreExists reports whether unlocking opens an existing store rather
+// than creating one with a new passphrase.
+func (vm *VM) TokenStoreExists() bool {
+	vault, ok := vm.tokens.(TokenVault)
+	return !ok || vault.Exists()
+}
+
+// UnlockTokens opens the token store and fills in the remembered token for the
+// current account unless a token was typed in.
+func (vm *VM) UnlockTokens(passphrase string) error {
+	vault, ok := vm.tokens.(TokenVault)
+	if !ok {
+		return nil
+	}
+	if err := vault.Unlock(passphrase); err != nil {
+		return err
+	}
+	// A remembered token wins over one that was only discovered.
+	current, _ := vm.Token.Get()
+	vm.mu.Lock()
+	typed := current != "" && current != vm.filledToken
+	vm.mu.Unlock()
+	ref, err := vm.tokenRef()
+	if typed || err != nil {
+		return nil
+	}
+	if token, ok := vault.Token(ref); ok {
+		vm.fillToken(token, "saved tokens")
+	}
+	return nil
+}
+
+// DiscoverToken fills in a token found in the environment, the gh CLI config
+// or ~/.netrc when the token field is empty.
+func (vm *VM) DiscoverToken() {
+	if vm.finder == nil {
+		return
+	}
+	if current, _ := vm.Token.Get(); current != "" {
+		return
+	}
+	vm.discoverToken(vm.host())
+}
+
+func (vm *VM) discoverToken(host string) {
+	if vm.finder == nil {
+		return
+	}
+	if found, ok := vm.finder.Find(host); ok {
+		vm.fillToken(found.Token, found.Source)
+	}
+}
+
+// NoteTokenChanged clears TokenSource once the token no longer is the one
+// that was filled in, and drops the check of a replaced token.
+func (vm *VM) NoteTokenChanged() {
+	token, _ := vm.Token.Get()
+	vm.mu.Lock()
+	changed := vm.filledToken != "" && token != vm.filledToken
+	if changed {
+		vm.filledToken = ""
+	}
+	replaced := ""
+	if vm.checkedToken != token {
+		replaced = vm.checkedToken
+		vm.checkedToken, vm.checkedInfo = "", domain.TokenInfo{}
+	}
+	info, checked := vm.checkedInfo, vm.checkedToken != ""
+	vm.mu.Unlock()
+	if replaced != "" && vm.checker != nil {
+		vm.checker.ForgetTokenCheck(replaced)
+	}
+	if changed {
+		vm.runOnMain(func() {
+			_ = vm.TokenSource.Set("")
+		})
+	}
+	if checked {
+		vm.showTokenCheck(info)
+	} else {
+		vm.runOnMain(func() {
+			_ = vm.TokenCheck.Set("")
+			_ = vm.TokenWarning.Set("")
+		})
+	}
+}
+
+func (vm *VM) fillToken(token, source string) {
+	vm.mu.Lock()
+	vm.filledToken = token
+	vm.mu.Unlock()
+	vm.runOnMain(func() {
+		_ = vm.Token.Set(token)
+		_ = vm.TokenSource.Set("Using token from " + source)
+	})
+}
+
+func (vm *VM) switchHost(host string) {
+	if vm.onHost != nil {
+		vm.onHost(host)
+	}
+}
+
+// host is the GitHub host of the active profile, or "" without one.
+func (vm *VM) host() string {
+	if vm.profiles == nil {
+		return ""
+	}
+	name, _ := vm.ActiveProfile.Get()
+	if p, ok := vm.profiles.Get(name); ok {
+		return p.HostOrDefault()
+	}
+	return ""
+}
+
+// RememberToken saves the current token for the active profile, or for the
+// username when no profile is active.
+func (vm *VM) RememberToken() error {
+	if vm.tokens == nil {
+		return errors.New("token store is not available")
+	}
+	username, _ := vm.Username.Get()
+	token, _ := vm.Token.Get()
+	return vm.rememberToken(username, token)
+}
+
+func (vm *VM) rememberToken(username, token string) error {
+	if strings.TrimSpace(token) == "" {
+		return errors.New("token is empty")
+	}
+	ref, err := vm.tokenRefFor(username)
+	if err != nil {
+		return err
+	}
+	if err := vm.tokens.SetToken(ref, token); err != nil {
+		return err
+	}
+	vm.runOnMain(func() {
+		_ = vm.Status.Set("Token remembered")
+	})
+	return nil
+}
+
+// ForgetToken removes the remembered token and clears the token field.
+func (vm *VM) ForgetToken() error {
+	if vm.tokens == nil {
+		return errors.New("token store is not available")
+	}
+	ref, err := vm.tokenRef()
+	if err != nil {
+		return err
+	}
+	if err := vm.tokens.ForgetToken(ref); err != nil {
+		return err
+	}
+	vm.runOnMain(func() {
+		_ = vm.Token.Set("")
+		_ = vm.Status.Set("Token forgotten")
+	})
+	return nil
+}
+
+func (vm *VM) tokenRef() (string, error) {
+	username, _ := vm.Username.Get()
+	return vm.tokenRefFor(username)
+}
+
+func (vm *VM) tokenRefFor(username string) (string, error) {
+	if vm.profiles != nil {
+		name, _ := vm.ActiveProfile.Get()
+		if p, ok := vm.profiles.Get(name); ok {
+			return p.TokenRef, nil
+		}
+	}
+	username = strings.TrimSpace(username)
+	if username == "" {
+		return "", errors.New("username is required to remember a token")
+	}
+	return profile.Profile{Name: username}.DefaultTokenRef(), nil
+}
+
+func (vm *VM) refreshProfiles() {
+	profiles := vm.profiles.List()
+	names := make([]string, len(profiles))
+	for i, p := range profiles {
+		names[i] = p.Name
+	}
+	vm.runOnMain(func() {
+		_ = vm.Profiles.Set(names)
+	})
+}
+
+func parsePerPage(value string, fallback int) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return fallback, nil
+	}
+	perPage, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.New("per page must be a number")
+	}
+	if perPage < 1 || perPage > 100 {
+		return 0, errors.New("per page must be between 1 and 100")
+	}
+	return perPage, nil
+}
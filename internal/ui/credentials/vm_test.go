@@ -0,0 +1,246 @@
+package credentials_test
+
+import (
+	"context"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/credstore"
+	"github.com/tbxark/gh-stars/internal/app/deviceflow"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/app/tokensource"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/credentials"
+)
+
+func TestVM_PageSize(t *testing.T) {
+	_ = test.NewApp()
+	vm := credentials.NewVM(nil)
+	vm.SetDefaultPerPage(30)
+	_ = vm.PerPage.Set("")
+	perPage, err := vm.PageSize()
+	testutil.AssertNoError(t, err)
+	testutil.AssertEqual(t, 30, perPage)
+
+	_ = vm.PerPage.Set("500")
+	_, err = vm.PageSize()
+	testutil.AssertError(t, err)
+}
+
+func TestVM_SwitchProfile_FollowsHost(t *testing.T) {
+	_ = test.NewApp()
+	profiles := profile.NewStore(testutil.NewPrefs())
+	testutil.AssertNoError(t, profiles.Save(profile.Profile{Name: "Corp", Username: "alice", Host: "ghe.example.com"}))
+	testutil.AssertNoError(t, profiles.Save(profile.Profile{Name: "Home", Username: "bob"}))
+	var hosts []string
+	vm := credentials.NewVM(func(f func()) { f() },
+		credentials.WithProfiles(profiles), credentials.WithHostChange(func(host string) { hosts = append(hosts, host) }))
+
+	vm.SwitchProfile("Corp")
+	vm.SwitchProfile("Home")
+	vm.SwitchProfile("Corp")
+	vm.DeleteProfile()
+
+	testutil.AssertEqual(t, "ghe.example.com,github.com,ghe.example.com,github.com", strings.Join(hosts, ","))
+}
+
+func TestVM_RememberToken_UnlocksVault(t *testing.T) {
+	_ = test.NewApp()
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	vault := credstore.NewStore(path)
+	vault.KDF = credstore.KDFParams{N: 1 << 10, R: 8, P: 1}
+
+	vm := credentials.NewVM(func(f func()) { f() }, credentials.WithTokens(vault))
+	testutil.AssertTrue(t, vm.NeedsUnlock(), "vault should start locked")
+	testutil.AssertFalse(t, vm.TokenStoreExists(), "vault should not exist yet")
+	_ = vm.Username.Set("alice")
+	_ = vm.Token.Set("ghp_secret")
+	testutil.AssertError(t, vm.RememberToken())
+
+	testutil.AssertNoError(t, vm.UnlockTokens("pass"))
+	testutil.AssertNoError(t, vm.RememberToken())
+
+	next := credentials.NewVM(func(f func()) { f() }, credentials.WithTokens(credstore.NewStore(path)))
+	_ = next.Username.Set("alice")
+	testutil.AssertTrue(t, next.TokenStoreExists(), "vault should exist after remembering")
+	testutil.AssertNoError(t, next.UnlockTokens("pass"))
+	token, _ := next.Token.Get()
+	testutil.AssertEqual(t, "ghp_secret", token)
+
+	testutil.AssertNoError(t, next.ForgetToken())
+	token, _ = next.Token.Get()
+	testutil.AssertEqual(t, "", token)
+}
+
+func TestVM_SaveProfile_LockedVaultSavesNothing(t *testing.T) {
+	_ = test.NewApp()
+	vault := credstore.NewStore(filepath.Join(t.TempDir(), "credentials.json"))
+	vault.KDF = credstore.KDFParams{N: 1 << 10, R: 8, P: 1}
+	profiles := profile.NewStore(testutil.NewPrefs())
+	vm := credentials.NewVM(func(f func()) { f() }, credentials.WithProfiles(profiles), credentials.WithTokens(vault))
+	_ = vm.Username.Set("alice")
+	_ = vm.Token.Set("ghp_secret")
+
+	testutil.AssertError(t, vm.SaveProfile("Work", ""))
+	_, ok := profiles.Get("Work")
+	testutil.AssertFalse(t, ok, "profile should not be saved without its token")
+
+	testutil.AssertNoError(t, vm.UnlockTokens("pass"))
+	testutil.AssertNoError(t, vm.SaveProfile("Work", ""))
+	p, ok := profiles.Get("Work")
+	testutil.AssertTrue(t, ok, "profile should be saved")
+	token, _ := vault.Token(p.TokenRef)
+	testutil.AssertEqual(t, "ghp_secret", token)
+}
+
+func TestVM_DeleteProfile_KeepsProfileWhileVaultIsLocked(t *testing.T) {
+	_ = test.NewApp()
+	path := filepath.Join(t.TempDir(), "credentials.json")
+	vault := credstore.NewStore(path)
+	vault.KDF = credstore.KDFParams{N: 1 << 10, R: 8, P: 1}
+	profiles := profile.NewStore(testutil.NewPrefs())
+	vm := credentials.NewVM(func(f func()) { f() }, credentials.WithProfiles(profiles), credentials.WithTokens(vault))
+	testutil.AssertNoError(t, vm.UnlockTokens("pass"))
+	_ = vm.Username.Set("alice")
+	_ = vm.Token.Set("ghp_secret")
+	testutil.AssertNoError(t, vm.SaveProfile("Work", ""))
+	saved, _ := profiles.Get("Work")
+
+	locked := credstore.NewStore(path)
+	next := credentials.NewVM(func(f func()) { f() }, credentials.WithProfiles(profiles), credentials.WithTokens(locked))
+	next.SwitchProfile("Work")
+	next.DeleteProfile()
+	_, ok := profiles.Get("Work")
+	testutil.AssertTrue(t, ok, "profile should stay while its token cannot be deleted")
+	errMsg, _ := next.Error.Get()
+	testutil.AssertTrue(t, strings.Contains(errMsg, "locked"), errMsg)
+
+	testutil.AssertNoError(t, next.UnlockTokens("pass"))
+	next.DeleteProfile()
+	_, ok = profiles.Get("Work")
+	testutil.AssertFalse(t, ok, "profile should be deleted")
+	_, ok = locked.Token(saved.TokenRef)
+	testutil.AssertFalse(t, ok, "token should be deleted")
+}
+
+func TestVM_DiscoverToken_ShowsSource(t *testing.T) {
+	_ = test.NewApp()
+	finder := tokensource.Finder{Getenv: func(key string) string {
+		if key == "GH_TOKEN" {
+			return "ghp_env"
+		}
+		return ""
+	}}
+	vm := credentials.NewVM(func(f func()) { f() }, credentials.WithTokenFinder(finder))
+
+	vm.DiscoverToken()
+
+	token, _ := vm.Token.Get()
+	testutil.AssertEqual(t, "ghp_env", token)
+	source, _ := vm.TokenSource.Get()
+	testutil.AssertEqual(t, "Using token from GH_TOKEN", source)
+
+	_ = vm.Token.Set("ghp_typed")
+	vm.NoteTokenChanged()
+	source, _ = vm.TokenSource.Get()
+	testutil.AssertEqual(t, "", source)
+
+	vm.DiscoverToken()
+	token, _ = vm.Token.Get()
+	testutil.AssertEqual(t, "ghp_typed", token)
+}
+
+// fakeLogin completes the device flow as soon as the code has been shown.
+type fakeLogin struct{}
+
+func (fakeLogin) RequestCode(ctx context.Context) (deviceflow.Code, error) {
+	return deviceflow.Code{UserCode: "ABCD-1234", VerificationURI: "https://github.com/login/device"}, nil
+}
+
+func (fakeLogin) PollToken(ctx context.Context, code deviceflow.Code) (deviceflow.Token, error) {
+	return deviceflow.Token{AccessToken: "gho_signed_in"}, nil
+}
+
+func TestVM_CheckToken_DropsResultOfReplacedToken(t *testing.T) {
+	_ = test.NewApp()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	client := github.NewMockClient()
+	client.CheckTokenFunc = func(ctx context.Context, token string) (domain.TokenInfo, error) {
+		close(started)
+		<-release
+		return domain.TokenInfo{Login: "octocat", Kind: domain.TokenClassic}, nil
+	}
+
+	vm := credentials.NewVM(func(f func()) { f() }, credentials.WithTokenCheck(client))
+	_ = vm.Token.Set("ghp_old")
+	vm.CheckToken("ghp_old")
+	<-started
+	_ = vm.Token.Set("ghp_new")
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	check, _ := vm.TokenCheck.Get()
+	testutil.AssertEqual(t, "", check)
+	testutil.AssertEqual(t, 1, client.GetForgetTokenCheckCount())
+}
+
+func TestVM_NoteTokenChanged_ForgetsReplacedCheck(t *testing.T) {
+	_ = test.NewApp()
+	client := github.NewMockClient()
+	client.CheckTokenFunc = func(ctx context.Context, token string) (domain.TokenInfo, error) {
+		return domain.TokenInfo{Login: "octocat", Kind: domain.TokenClassic}, nil
+	}
+	vm := credentials.NewVM(func(f func()) { f() }, credentials.WithTokenCheck(client))
+	_ = vm.Token.Set("ghp_old")
+	vm.CheckToken("ghp_old")
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if check, _ := vm.TokenCheck.Get(); check != "" {
+			break
+		}
+	}
+
+	_ = vm.Token.Set("ghp_new")
+	vm.NoteTokenChanged()
+
+	testutil.AssertEqual(t, 1, client.GetForgetTokenCheckCount())
+	check, _ := vm.TokenCheck.Get()
+	testutil.AssertEqual(t, "", check)
+}
+
+func TestVM_SignIn_FillsAndRemembersToken(t *testing.T) {
+	_ = test.NewApp()
+	profiles := profile.NewStore(testutil.NewPrefs())
+	testutil.AssertNoError(t, profiles.Save(profile.Profile{Name: "Work"}))
+	tokens := &profile.MemoryTokens{}
+	client := github.NewMockClient()
+	client.GetAuthenticatedUserFunc = func(ctx context.Context, token string) (string, error) {
+		return "octocat", nil
+	}
+
+	vm := credentials.NewVM(func(f func()) { f() },
+		credentials.WithProfiles(profiles), credentials.WithTokens(tokens),
+		credentials.WithUserLookup(client), credentials.WithDeviceLogin(fakeLogin{}))
+	testutil.AssertTrue(t, vm.CanSignIn(), "sign-in should be enabled")
+	vm.LoadProfiles()
+	vm.SwitchProfile("Work")
+
+	vm.SignIn()
+	time.Sleep(50 * time.Millisecond)
+
+	token, _ := vm.Token.Get()
+	testutil.AssertEqual(t, "gho_signed_in", token)
+	username, _ := vm.Username.Get()
+	testutil.AssertEqual(t, "octocat", username)
+	signingIn, _ := vm.SigningIn.Get()
+	testutil.AssertFalse(t, signingIn, "sign-in should be finished")
+	saved, ok := tokens.Token("work@github.com")
+	testutil.AssertTrue(t, ok, "token should be saved to the profile")
+	testutil.AssertEqual(t, "gho_signed_in", saved)
+}
@@ -0,0 +1,60 @@
+package details
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/repos"
+)
+
+// Pane shows one repo at a time next to another view, such as the stars
+// list in split layout. It keeps a single VM and view and switches them to
+// each repo it is asked to show.
+type Pane struct {
+	vm          *VM
+	view        fyne.CanvasObject
+	placeholder fyne.CanvasObject
+	content     *fyne.Container
+}
+
+func NewPane(w fyne.Window, svc repos.Loader, opts ...Option) *Pane {
+	vm := NewVM(svc, "", "", fyne.Do, opts...)
+	hint := widget.NewLabel("Select a repo to see its details here.")
+	hint.Importance = widget.LowImportance
+	p := &Pane{
+		vm:          vm,
+		view:        NewView(w, vm),
+		placeholder: container.NewCenter(hint),
+	}
+	p.view.Hide()
+	p.content = container.NewStack(p.placeholder, p.view)
+	return p
+}
+
+func (p *Pane) Object() fyne.CanvasObject {
+	return p.content
+}
+
+// Shown returns the repo on display, or "" before the first Show.
+func (p *Pane) Shown() string {
+	p.vm.mu.Lock()
+	defer p.vm.mu.Unlock()
+	return p.vm.FullName
+}
+
+// Show switches the pane to fullName.
+func (p *Pane) Show(fullName, token string) {
+	if fullName == "" {
+		return
+	}
+	p.placeholder.Hide()
+	p.view.Show()
+	p.vm.Show(fullName, token)
+	p.vm.StartAutoRefresh()
+}
+
+// Cleanup cancels loads and scheduled checks once the pane is gone.
+func (p *Pane) Cleanup() {
+	p.vm.Cleanup()
+}
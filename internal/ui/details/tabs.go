@@ -0,0 +1,209 @@
+package details
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/tabstack"
+)
+
+// TabsWindow shows repo details in a single window, one tab per repo, with
+// back/forward history across the tabs. Its methods must be called on the
+// main thread.
+type TabsWindow struct {
+	// OnChanged is called with the tabs after every change, so they can be
+	// saved.
+	OnChanged func(tabstack.State)
+
+	w     fyne.Window
+	svc   repos.Loader
+	opts  []Option
+	stack tabstack.Stack
+	tabs  *container.DocTabs
+	open  map[string]*detailsTab
+	// tokens keeps the token each repo was opened with, for reopening it.
+	tokens  map[string]string
+	syncing bool
+
+	back, forward, reopen, closeOthers *widget.Button
+}
+
+type detailsTab struct {
+	item *container.TabItem
+	vm   *VM
+	// loaded is set once the tab was first shown; restored tabs wait until
+	// then so they do not all load at once.
+	loaded bool
+}
+
+func NewTabsWindow(app fyne.App, svc repos.Loader, opts ...Option) *TabsWindow {
+	t := &TabsWindow{
+		w:      app.NewWindow("Repo Details"),
+		svc:    svc,
+		opts:   opts,
+		open:   map[string]*detailsTab{},
+		tokens: map[string]string{},
+	}
+	t.w.Resize(fyne.NewSize(900, 650))
+
+	t.tabs = container.NewDocTabs()
+	t.tabs.CloseIntercept = func(item *container.TabItem) { t.Close(item.Text) }
+	t.tabs.OnSelected = func(item *container.TabItem) {
+		if !t.syncing {
+			t.stack.Open(item.Text)
+			t.sync()
+		}
+	}
+
+	t.back = widget.NewButtonWithIcon("", theme.NavigateBackIcon(), t.Back)
+	t.forward = widget.NewButtonWithIcon("", theme.NavigateNextIcon(), t.Forward)
+	t.reopen = widget.NewButtonWithIcon("Reopen Closed", theme.HistoryIcon(), t.ReopenClosed)
+	t.closeOthers = widget.NewButtonWithIcon("Close Others", theme.ContentClearIcon(), t.CloseOthers)
+	toolbar := container.NewHBox(t.back, t.forward, layout.NewSpacer(), t.closeOthers, t.reopen)
+
+	t.w.SetContent(container.NewBorder(container.NewPadded(toolbar), nil, nil, nil, t.tabs))
+	t.addShortcuts()
+	t.w.SetOnClosed(func() {
+		for _, tab := range t.open {
+			tab.vm.Cleanup()
+		}
+	})
+	t.sync()
+	return t
+}
+
+func (t *TabsWindow) Window() fyne.Window {
+	return t.w
+}
+
+// Open shows fullName, adding a tab for it when it is not open.
+func (t *TabsWindow) Open(fullName, token string) {
+	t.tokens[fullName] = token
+	t.stack.Open(fullName)
+	t.sync()
+}
+
+// Close closes the tab of fullName.
+func (t *TabsWindow) Close(fullName string) {
+	t.stack.Close(fullName)
+	t.sync()
+}
+
+// CloseActive closes the active tab.
+func (t *TabsWindow) CloseActive() {
+	if active := t.stack.Active(); active != "" {
+		t.Close(active)
+	}
+}
+
+// CloseOthers closes every tab but the active one.
+func (t *TabsWindow) CloseOthers() {
+	t.stack.CloseOthers(t.stack.Active())
+	t.sync()
+}
+
+// ReopenClosed reopens the tab closed last.
+func (t *TabsWindow) ReopenClosed() {
+	if _, ok := t.stack.ReopenClosed(); ok {
+		t.sync()
+	}
+}
+
+func (t *TabsWindow) Back() {
+	if _, ok := t.stack.Back(); ok {
+		t.sync()
+	}
+}
+
+func (t *TabsWindow) Forward() {
+	if _, ok := t.stack.Forward(); ok {
+		t.sync()
+	}
+}
+
+// State returns the open tabs and their history.
+func (t *TabsWindow) State() tabstack.State {
+	return t.stack.State()
+}
+
+// Restore reopens the tabs in state, using token for all of them. Only the
+// active tab loads until the others are shown.
+func (t *TabsWindow) Restore(state tabstack.State, token string) {
+	t.stack.Restore(state)
+	for _, name := range t.stack.Tabs() {
+		t.tokens[name] = token
+	}
+	t.sync()
+}
+
+// sync makes the tabs and buttons match the stack.
+func (t *TabsWindow) sync() {
+	names := t.stack.Tabs()
+	keep := make(map[string]bool, len(names))
+	items := make([]*container.TabItem, len(names))
+	for i, name := range names {
+		keep[name] = true
+		tab, ok := t.open[name]
+		if !ok {
+			vm := NewVM(t.svc, name, t.tokens[name], fyne.Do, t.opts...)
+			tab = &detailsTab{item: container.NewTabItem(name, NewView(t.w, vm)), vm: vm}
+			t.open[name] = tab
+		}
+		items[i] = tab.item
+	}
+	for name, tab := range t.open {
+		if !keep[name] {
+			tab.vm.Cleanup()
+			delete(t.open, name)
+		}
+	}
+
+	t.syncing = true
+	t.tabs.SetItems(items)
+	if active, ok := t.open[t.stack.Active()]; ok {
+		t.tabs.Select(active.item)
+		if !active.loaded {
+			active.loaded = true
+			active.vm.Load()
+			active.vm.StartAutoRefresh()
+		}
+		t.w.SetTitle("Repo Details: " + t.stack.Active())
+	} else {
+		t.w.SetTitle("Repo Details")
+	}
+	t.syncing = false
+
+	enable(t.back, t.stack.CanGoBack())
+	enable(t.forward, t.stack.CanGoForward())
+	enable(t.reopen, t.stack.CanReopen())
+	enable(t.closeOthers, len(names) > 1)
+
+	if t.OnChanged != nil {
+		t.OnChanged(t.stack.State())
+	}
+}
+
+// addShortcuts binds the browser-like keys: Alt+Left and Alt+Right for
+// history, Ctrl+W to close a tab and Ctrl+Shift+T to reopen one.
+func (t *TabsWindow) addShortcuts() {
+	bind := func(key fyne.KeyName, mod fyne.KeyModifier, run func()) {
+		t.w.Canvas().AddShortcut(&desktop.CustomShortcut{KeyName: key, Modifier: mod}, func(fyne.Shortcut) { run() })
+	}
+	bind(fyne.KeyLeft, fyne.KeyModifierAlt, t.Back)
+	bind(fyne.KeyRight, fyne.KeyModifierAlt, t.Forward)
+	bind(fyne.KeyW, fyne.KeyModifierShortcutDefault, t.CloseActive)
+	bind(fyne.KeyT, fyne.KeyModifierShortcutDefault|fyne.KeyModifierShift, t.ReopenClosed)
+}
+
+func enable(btn *widget.Button, on bool) {
+	if on {
+		btn.Enable()
+	} else {
+		btn.Disable()
+	}
+}
@@ -0,0 +1,65 @@
+package details_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/tabstack"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/details"
+)
+
+// idleRepos never answers, so no background result touches the window
+// while the test drives it.
+func idleRepos() *repos.MockService {
+	svc := repos.NewMockService()
+	svc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		<-ctx.Done()
+		return domain.RepoDetails{}, ctx.Err()
+	}
+	svc.LoadActivityFunc = func(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+		<-ctx.Done()
+		return domain.RepoActivity{}, ctx.Err()
+	}
+	return svc
+}
+
+func TestTabsWindow_HistoryAndClosedTabs(t *testing.T) {
+	tabs := details.NewTabsWindow(test.NewApp(), idleRepos())
+	defer tabs.Window().Close()
+	var saved tabstack.State
+	tabs.OnChanged = func(state tabstack.State) { saved = state }
+
+	tabs.Open("golang/go", "")
+	tabs.Open("fyne-io/fyne", "")
+	tabs.Open("microsoft/vscode", "")
+	tabs.Back()
+
+	testutil.AssertEqual(t, 3, len(saved.Tabs))
+	testutil.AssertEqual(t, "fyne-io/fyne", saved.Active)
+	testutil.AssertEqual(t, "Repo Details: fyne-io/fyne", tabs.Window().Title())
+
+	tabs.CloseOthers()
+	testutil.AssertEqual(t, 1, len(saved.Tabs))
+	tabs.ReopenClosed()
+	testutil.AssertEqual(t, 2, len(saved.Tabs))
+	testutil.AssertEqual(t, "microsoft/vscode", saved.Active)
+}
+
+func TestTabsWindow_RestoreLoadsOnlyActiveTab(t *testing.T) {
+	app := test.NewApp()
+	svc := idleRepos()
+	tabs := details.NewTabsWindow(app, svc)
+	defer tabs.Window().Close()
+
+	tabs.Restore(tabstack.State{Tabs: []string{"golang/go", "fyne-io/fyne"}, Active: "fyne-io/fyne"}, "token")
+
+	time.Sleep(50 * time.Millisecond)
+	testutil.AssertEqual(t, "fyne-io/fyne", tabs.State().Active)
+	testutil.AssertEqual(t, 1, svc.GetLoadDetailsCount())
+}
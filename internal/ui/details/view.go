@@ -15,7 +15,7 @@ import (
 )
 
 func NewView(w fyne.Window, vm *VM) fyne.CanvasObject {
-	refresh := widget.NewButtonWithIcon("Refresh", theme.ViewRefreshIcon(), vm.Load)
+	refresh := widget.NewButtonWithIcon("Refresh", theme.ViewRefreshIcon(), vm.Refresh)
 
 	vm.Loading.AddListener(binding.NewDataListener(func() {
 		loading, _ := vm.Loading.Get()
@@ -45,6 +45,10 @@ func NewView(w fyne.Window, vm *VM) fyne.CanvasObject {
 	name := widget.NewLabelWithData(vm.Name)
 	name.Wrapping = fyne.TextTruncate
 
+	cacheBadge := widget.NewLabelWithData(vm.CacheInfo)
+	cacheBadge.Importance = widget.LowImportance
+	cacheBadge.TextStyle = fyne.TextStyle{Italic: true}
+
 	newWrapLabel := func(value binding.String) *widget.Label {
 		label := widget.NewLabelWithData(value)
 		label.Wrapping = fyne.TextWrapBreak
@@ -76,13 +80,76 @@ func NewView(w fyne.Window, vm *VM) fyne.CanvasObject {
 		nil,
 		nil,
 		container.NewHBox(layout.NewSpacer(), openBtn, refresh),
-		container.NewVBox(title, name),
+		container.NewVBox(title, container.NewHBox(name, cacheBadge)),
 	)
-	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Loading)
+	autoRefresh := widget.NewLabelWithData(vm.AutoRefresh)
+	autoRefresh.Importance = widget.LowImportance
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Loading, autoRefresh)
 
 	detailsCard := widget.NewCard("", "Overview and metadata.", form)
-	content := container.NewVScroll(container.NewPadded(detailsCard))
+	activityCard := widget.NewCard("", "Activity over the last year.", newActivityPanel(vm))
+	cards := container.NewVBox(detailsCard)
+	if vm.HasTrends() {
+		cards.Add(widget.NewCard("", "Star growth across your syncs.", newGrowthPanel(vm)))
+	}
+	cards.Add(activityCard)
+	content := container.NewVScroll(container.NewPadded(cards))
 	top := container.NewPadded(container.NewVBox(header, widget.NewSeparator()))
 
 	return container.NewBorder(top, container.NewPadded(statusBar), nil, nil, content)
 }
+
+func newActivityPanel(vm *VM) fyne.CanvasObject {
+	summary := widget.NewLabelWithData(vm.ActivityStatus)
+	summary.Wrapping = fyne.TextWrapWord
+
+	commits := widgets.NewBarChart(theme.ColorNamePrimary, theme.ColorNamePrimary)
+	churn := widgets.NewBarChart(theme.ColorNameSuccess, theme.ColorNameError)
+	churn.Height = 90
+
+	vm.Activity.AddListener(binding.NewDataListener(func() {
+		activity, _ := vm.Activity.Get()
+
+		weekly := make([]float64, len(activity.Commits))
+		for i, week := range activity.Commits {
+			weekly[i] = float64(week.Total)
+		}
+		commits.SetData(weekly, nil)
+
+		additions := make([]float64, len(activity.CodeFrequency))
+		deletions := make([]float64, len(activity.CodeFrequency))
+		for i, week := range activity.CodeFrequency {
+			additions[i] = float64(week.Additions)
+			deletions[i] = float64(week.Deletions)
+		}
+		churn.SetData(additions, deletions)
+	}))
+
+	return container.NewVBox(
+		summary,
+		widget.NewLabelWithStyle("Weekly commits", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		commits,
+		widget.NewLabelWithStyle("Additions / deletions", fyne.TextAlignLeading, fyne.TextStyle{Bold: true}),
+		churn,
+	)
+}
+
+func newGrowthPanel(vm *VM) fyne.CanvasObject {
+	summary := widget.NewLabelWithData(vm.GrowthStatus)
+	summary.Wrapping = fyne.TextWrapWord
+
+	chart := widgets.NewLineChart(theme.ColorNamePrimary)
+
+	vm.Growth.AddListener(binding.NewDataListener(func() {
+		samples, _ := vm.Growth.Get()
+		xs := make([]float64, len(samples))
+		ys := make([]float64, len(samples))
+		for i, sample := range samples {
+			xs[i] = float64(sample.At.Unix())
+			ys[i] = float64(sample.Stars)
+		}
+		chart.SetData(xs, ys)
+	}))
+
+	return container.NewVBox(summary, chart)
+}
@@ -2,7 +2,9 @@ package details
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strconv"
 	"strings"
 	"sync"
@@ -11,10 +13,13 @@ import (
 	"fyne.io/fyne/v2/data/binding"
 
 	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
+	"github.com/tbxark/gh-stars/internal/app/trends"
 	"github.com/tbxark/gh-stars/internal/domain"
 )
 
 type VM struct {
+	// FullName and Token change only through Show, on the main thread.
 	FullName string
 	Token    string
 
@@ -40,14 +45,46 @@ type VM struct {
 	Private       binding.String
 	HTMLURL       binding.String
 
+	CacheInfo binding.String
+
+	Activity       binding.Item[domain.RepoActivity]
+	ActivityStatus binding.String
+
+	Growth       binding.Item[[]trends.Sample]
+	GrowthStatus binding.String
+
+	// AutoRefresh describes the last scheduled check.
+	AutoRefresh binding.String
+
 	svc       repos.Loader
+	growth    *trends.Store
+	scheduler *schedule.Scheduler
 	runOnMain func(func())
 
-	mu     sync.Mutex
-	cancel context.CancelFunc
+	mu         sync.Mutex
+	cancel     context.CancelFunc
+	removeTask func()
+}
+
+// Option configures optional VM collaborators.
+type Option func(*VM)
+
+// WithTrends enables the star growth chart backed by recorded samples.
+func WithTrends(store *trends.Store) Option {
+	return func(vm *VM) {
+		vm.growth = store
+	}
+}
+
+// WithScheduler refetches the details on the scheduler's details interval
+// once StartAutoRefresh is called.
+func WithScheduler(scheduler *schedule.Scheduler) Option {
+	return func(vm *VM) {
+		vm.scheduler = scheduler
+	}
 }
 
-func NewVM(svc repos.Loader, fullName, token string, runOnMain func(func())) *VM {
+func NewVM(svc repos.Loader, fullName, token string, runOnMain func(func()), opts ...Option) *VM {
 	vm := &VM{
 		FullName:      fullName,
 		Token:         token,
@@ -71,34 +108,93 @@ func NewVM(svc repos.Loader, fullName, token string, runOnMain func(func())) *VM
 		PushedAt:      binding.NewString(),
 		Private:       binding.NewString(),
 		HTMLURL:       binding.NewString(),
-		svc:           svc,
-		runOnMain:     runOnMain,
+		CacheInfo:     binding.NewString(),
+		// Activity series are slices, so every Set is treated as a change.
+		Activity:       binding.NewItem(func(a, b domain.RepoActivity) bool { return false }),
+		ActivityStatus: binding.NewString(),
+		Growth:         binding.NewItem(func(a, b []trends.Sample) bool { return false }),
+		GrowthStatus:   binding.NewString(),
+		AutoRefresh:    binding.NewString(),
+		svc:            svc,
+		runOnMain:      runOnMain,
 	}
 	if vm.runOnMain == nil {
 		vm.runOnMain = func(f func()) { f() }
 	}
+	for _, opt := range opts {
+		opt(vm)
+	}
 	_ = vm.Status.Set("Ready")
 	_ = vm.Name.Set(fullName)
 	return vm
 }
 
+// Load shows cached details right away when the service keeps a cache, then
+// fetches through the service, which may answer from cache while fresh.
 func (vm *VM) Load() {
+	vm.load(false)
+}
+
+// Refresh is like Load but always goes to the network.
+func (vm *VM) Refresh() {
+	vm.load(true)
+}
+
+func (vm *VM) load(revalidate bool) {
 	vm.mu.Lock()
 	if vm.cancel != nil {
 		vm.cancel()
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
 	vm.cancel = cancel
+	fullName, token := vm.FullName, vm.Token
 	vm.mu.Unlock()
 
+	cache, hasCache := vm.svc.(repos.Cache)
+	var (
+		cached    domain.RepoDetails
+		fetchedAt time.Time
+		hasCached bool
+	)
+	if hasCache {
+		cached, fetchedAt, hasCached = cache.Cached(fullName, token)
+	}
+
+	var samples []trends.Sample
+	if vm.growth != nil {
+		samples = vm.growth.Samples(fullName)
+	}
+
 	vm.runOnMain(func() {
+		if vm.growth != nil {
+			_ = vm.Growth.Set(samples)
+			_ = vm.GrowthStatus.Set(summarizeGrowth(samples))
+		}
+		if hasCached {
+			vm.apply(cached)
+			_ = vm.CacheInfo.Set(cacheBadge(fetchedAt))
+		}
 		_ = vm.Loading.Set(true)
 		_ = vm.Error.Set("")
 		_ = vm.Status.Set("Loading...")
+		_ = vm.ActivityStatus.Set("Loading activity...")
 	})
 
+	go vm.loadActivity(ctx, fullName, token)
 	go func() {
-		details, err := vm.svc.LoadDetails(ctx, vm.FullName, vm.Token)
+		var (
+			details domain.RepoDetails
+			err     error
+		)
+		if revalidate && hasCache {
+			details, err = cache.Revalidate(ctx, fullName, token)
+		} else {
+			details, err = vm.svc.LoadDetails(ctx, fullName, token)
+		}
+		if errors.Is(ctx.Err(), context.Canceled) {
+			// Superseded by another load or Show, or cleaned up.
+			return
+		}
 		if err != nil {
 			vm.runOnMain(func() {
 				_ = vm.Loading.Set(false)
@@ -108,21 +204,164 @@ func (vm *VM) Load() {
 			return
 		}
 
+		badge := ""
+		if hasCache {
+			if _, at, ok := cache.Cached(fullName, token); ok {
+				badge = cacheBadge(at)
+			}
+		}
 		vm.runOnMain(func() {
 			vm.apply(details)
+			_ = vm.CacheInfo.Set(badge)
 			_ = vm.Loading.Set(false)
 			_ = vm.Status.Set("Loaded")
 		})
 	}()
 }
 
+func (vm *VM) loadActivity(ctx context.Context, fullName, token string) {
+	activity, err := vm.svc.LoadActivity(ctx, fullName, token)
+	if errors.Is(ctx.Err(), context.Canceled) {
+		return
+	}
+	if err != nil {
+		vm.runOnMain(func() {
+			_ = vm.ActivityStatus.Set("Activity unavailable: " + err.Error())
+		})
+		return
+	}
+
+	activity.CodeFrequency = sameWeeks(activity)
+	vm.runOnMain(func() {
+		_ = vm.Activity.Set(activity)
+		_ = vm.ActivityStatus.Set(summarizeActivity(activity))
+	})
+}
+
+// activityWeeks is how many weeks GitHub reports commit activity for.
+const activityWeeks = 52
+
+// sameWeeks trims the code frequency, which covers the repo's whole history,
+// to the weeks of the commit activity so both charts share a timescale.
+func sameWeeks(activity domain.RepoActivity) []domain.CodeFrequencyWeek {
+	weeks := activity.CodeFrequency
+	if len(activity.Commits) == 0 {
+		return weeks[max(len(weeks)-activityWeeks, 0):]
+	}
+	start := activity.Commits[0].Week
+	i := sort.Search(len(weeks), func(i int) bool { return !weeks[i].Week.Before(start) })
+	return weeks[i:]
+}
+
+// StartAutoRefresh adds this repo to the scheduled details checks until
+// Cleanup.
+func (vm *VM) StartAutoRefresh() {
+	if vm.scheduler == nil {
+		return
+	}
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if vm.removeTask != nil {
+		return
+	}
+	vm.removeTask = vm.scheduler.Add(schedule.Task{
+		Kind: schedule.DetailsCheck,
+		Name: "Check " + vm.FullName,
+		Run:  vm.checkScheduled,
+		OnResult: func(r schedule.Result) {
+			vm.runOnMain(func() {
+				_ = vm.AutoRefresh.Set(r.Describe())
+			})
+		},
+	})
+}
+
+// checkScheduled refetches the details, bypassing a fresh cache entry, and
+// shows them without touching Loading or Status.
+func (vm *VM) checkScheduled(ctx context.Context) (string, error) {
+	vm.mu.Lock()
+	fullName, token := vm.FullName, vm.Token
+	vm.mu.Unlock()
+
+	var (
+		details domain.RepoDetails
+		err     error
+	)
+	if cache, ok := vm.svc.(repos.Cache); ok {
+		details, err = cache.Revalidate(ctx, fullName, token)
+	} else {
+		details, err = vm.svc.LoadDetails(ctx, fullName, token)
+	}
+	if err != nil {
+		return "", err
+	}
+	vm.runOnMain(func() {
+		vm.mu.Lock()
+		current := vm.FullName == fullName
+		vm.mu.Unlock()
+		if !current {
+			return
+		}
+		vm.apply(details)
+		_ = vm.CacheInfo.Set("")
+	})
+	return fmt.Sprintf("%d stars", details.Stars), nil
+}
+
+// Show switches the VM to another repo, so one details view can follow the
+// selection of a list. The current load is canceled and the fields are
+// cleared before fullName loads; a scheduled check moves along with it.
+// Showing the repo already shown does nothing.
+func (vm *VM) Show(fullName, token string) {
+	vm.mu.Lock()
+	if vm.FullName == fullName && vm.Token == token {
+		vm.mu.Unlock()
+		return
+	}
+	vm.FullName, vm.Token = fullName, token
+	scheduled := vm.removeTask != nil
+	remove := vm.removeTask
+	vm.removeTask = nil
+	vm.mu.Unlock()
+
+	if remove != nil {
+		remove()
+	}
+	vm.runOnMain(func() {
+		vm.clear(fullName)
+	})
+	vm.Load()
+	if scheduled {
+		vm.StartAutoRefresh()
+	}
+}
+
+func (vm *VM) clear(fullName string) {
+	for _, field := range []binding.String{
+		vm.Description, vm.Language, vm.Homepage, vm.DefaultBranch, vm.License,
+		vm.Topics, vm.Stars, vm.Forks, vm.Watchers, vm.OpenIssues, vm.Size,
+		vm.UpdatedAt, vm.CreatedAt, vm.PushedAt, vm.Private, vm.HTMLURL,
+		vm.CacheInfo, vm.AutoRefresh, vm.GrowthStatus,
+	} {
+		_ = field.Set("")
+	}
+	_ = vm.Name.Set(fullName)
+	_ = vm.Activity.Set(domain.RepoActivity{})
+	_ = vm.Growth.Set(nil)
+}
+
 func (vm *VM) Cleanup() {
 	vm.mu.Lock()
 	if vm.cancel != nil {
 		vm.cancel()
 		vm.cancel = nil
 	}
+	remove := vm.removeTask
+	vm.removeTask = nil
 	vm.mu.Unlock()
+	if remove != nil {
+		remove()
+	}
 }
 
 func (vm *VM) apply(repo domain.RepoDetails) {
@@ -145,6 +384,66 @@ func (vm *VM) apply(repo domain.RepoDetails) {
 	_ = vm.HTMLURL.Set(valueOrDash(repo.HTMLURL))
 }
 
+func summarizeActivity(activity domain.RepoActivity) string {
+	if len(activity.Commits) == 0 {
+		return "No commit activity reported"
+	}
+	commits := 0
+	var lastActive time.Time
+	for _, week := range activity.Commits {
+		commits += week.Total
+		if week.Total > 0 {
+			lastActive = week.Week
+		}
+	}
+	summary := fmt.Sprintf("%d commits in the last %d weeks", commits, len(activity.Commits))
+	if lastActive.IsZero() {
+		return summary + ", none recently"
+	}
+	summary += ", last active week of " + lastActive.Format("2006-01-02")
+
+	additions, deletions := 0, 0
+	for _, week := range activity.CodeFrequency {
+		additions += week.Additions
+		deletions += week.Deletions
+	}
+	if additions > 0 || deletions > 0 {
+		summary += fmt.Sprintf(" (+%d / -%d lines)", additions, deletions)
+	}
+	return summary
+}
+
+// HasTrends reports whether star growth samples are available.
+func (vm *VM) HasTrends() bool {
+	return vm.growth != nil
+}
+
+func summarizeGrowth(samples []trends.Sample) string {
+	if len(samples) < 2 {
+		return "Star growth appears after the repo has been seen in two syncs on different days"
+	}
+	first, last := samples[0], samples[len(samples)-1]
+	days := int(last.At.Sub(first.At).Hours() / 24)
+	return fmt.Sprintf("%+d stars and %+d forks over %d days (%d → %d stars)",
+		last.Stars-first.Stars, last.Forks-first.Forks, days, first.Stars, last.Stars)
+}
+
+// cacheBadge describes how old cached details are, or returns "" when they
+// were fetched moments ago.
+func cacheBadge(fetchedAt time.Time) string {
+	age := time.Since(fetchedAt)
+	switch {
+	case age < time.Minute:
+		return ""
+	case age < time.Hour:
+		return fmt.Sprintf("cached %dm ago", int(age.Minutes()))
+	case age < 48*time.Hour:
+		return fmt.Sprintf("cached %dh ago", int(age.Hours()))
+	default:
+		return fmt.Sprintf("cached %dd ago", int(age.Hours()/24))
+	}
+}
+
 func formatTime(t time.Time) string {
 	if t.IsZero() {
 		return "-"
@@ -0,0 +1,84 @@
+package details_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/details"
+)
+
+func TestVM_Show_SwitchesRepo(t *testing.T) {
+	_ = test.NewApp()
+	svc := repos.NewMockService()
+	release := make(chan struct{})
+	svc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		if fullName == "slow/repo" {
+			select {
+			case <-release:
+			case <-ctx.Done():
+			}
+		}
+		return domain.RepoDetails{FullName: fullName, Description: "about " + fullName}, nil
+	}
+	vm := details.NewVM(svc, "", "", func(f func()) { f() })
+	defer vm.Cleanup()
+
+	vm.Show("slow/repo", "")
+	vm.Show("golang/go", "token")
+	close(release)
+	time.Sleep(50 * time.Millisecond)
+
+	name, _ := vm.Name.Get()
+	description, _ := vm.Description.Get()
+	testutil.AssertEqual(t, "golang/go", name)
+	testutil.AssertEqual(t, "about golang/go", description)
+	errorMsg, _ := vm.Error.Get()
+	testutil.AssertEqual(t, "", errorMsg)
+
+	count := svc.GetLoadDetailsCount()
+	vm.Show("golang/go", "token")
+	time.Sleep(20 * time.Millisecond)
+	testutil.AssertEqual(t, count, svc.GetLoadDetailsCount())
+}
+
+func TestVM_Show_TrimsCodeFrequencyToCommitWeeks(t *testing.T) {
+	_ = test.NewApp()
+	start := time.Date(2020, 1, 5, 0, 0, 0, 0, time.UTC)
+	var activity domain.RepoActivity
+	for i := range 200 {
+		week := start.AddDate(0, 0, 7*i)
+		activity.CodeFrequency = append(activity.CodeFrequency, domain.CodeFrequencyWeek{Week: week, Additions: 1})
+		if i >= 200-52 {
+			activity.Commits = append(activity.Commits, domain.CommitWeek{Week: week, Total: 1})
+		}
+	}
+	svc := repos.NewMockService()
+	svc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		return domain.RepoDetails{FullName: fullName}, nil
+	}
+	svc.LoadActivityFunc = func(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+		return activity, nil
+	}
+	vm := details.NewVM(svc, "", "", func(f func()) { f() })
+	defer vm.Cleanup()
+
+	vm.Show("golang/go", "")
+	var shown domain.RepoActivity
+	for deadline := time.Now().Add(time.Second); time.Now().Before(deadline); time.Sleep(5 * time.Millisecond) {
+		if shown, _ = vm.Activity.Get(); len(shown.Commits) > 0 {
+			break
+		}
+	}
+
+	testutil.AssertEqual(t, 52, len(shown.CodeFrequency))
+	testutil.AssertEqual(t, activity.Commits[0].Week, shown.CodeFrequency[0].Week)
+	status, _ := vm.ActivityStatus.Get()
+	testutil.AssertTrue(t, strings.HasSuffix(status, "(+52 / -0 lines)"), status)
+}
@@ -6,16 +6,17 @@ import (
 	"github.com/tbxark/gh-stars/internal/app/repos"
 )
 
-func NewRepoDetailsWindow(app fyne.App, svc repos.Loader, fullName, token string) fyne.Window {
+func NewRepoDetailsWindow(app fyne.App, svc repos.Loader, fullName, token string, opts ...Option) fyne.Window {
 	w := app.NewWindow("Repo Details: " + fullName)
 	w.Resize(fyne.NewSize(900, 600))
 
-	vm := NewVM(svc, fullName, token, fyne.Do)
+	vm := NewVM(svc, fullName, token, fyne.Do, opts...)
 	w.SetContent(NewView(w, vm))
 	w.SetOnClosed(func() {
 		vm.Cleanup()
 	})
 	vm.Load()
+	vm.StartAutoRefresh()
 
 	return w
 }
@@ -1,24 +1,97 @@
 package nav
 
 import (
+	"strings"
 	"sync"
 
 	"fyne.io/fyne/v2"
 
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/enrich"
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/app/recent"
+	"github.com/tbxark/gh-stars/internal/app/releases"
 	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
+	"github.com/tbxark/gh-stars/internal/app/session"
+	"github.com/tbxark/gh-stars/internal/app/settings"
 	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/team"
+	"github.com/tbxark/gh-stars/internal/app/trends"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/ui/activity"
+	"github.com/tbxark/gh-stars/internal/ui/changes"
+	compareui "github.com/tbxark/gh-stars/internal/ui/compare"
+	"github.com/tbxark/gh-stars/internal/ui/credentials"
 	"github.com/tbxark/gh-stars/internal/ui/details"
+	settingsui "github.com/tbxark/gh-stars/internal/ui/settings"
 	starsui "github.com/tbxark/gh-stars/internal/ui/stars"
+	teamui "github.com/tbxark/gh-stars/internal/ui/team"
+	"github.com/tbxark/gh-stars/internal/ui/updates"
 )
 
 type AppNavigator struct {
 	App      fyne.App
 	RepoSvc  repos.Loader
 	StarsSvc stars.Loader
+	Enricher enrich.Runner
+	History  *history.Store
+	Trends   *trends.Store
+	Releases *releases.Poller
+	// CompareSvc loads other users' stars. It should bypass the recording
+	// decorators on StarsSvc; when unset StarsSvc is used.
+	CompareSvc compare.Service
+	Team       *team.Builder
+	Profiles   *profile.Store
+	Tokens     profile.Tokens
+	// TokenFinder and Users fill in a token and username the user did not
+	// type.
+	TokenFinder  credentials.TokenFinder
+	Users        credentials.UserLookup
+	TokenChecker credentials.TokenChecker
+	// DeviceLogin enables "Sign in with GitHub" when set.
+	DeviceLogin credentials.DeviceLogin
+	// OnHostChange is told the GitHub host of each profile switched to.
+	OnHostChange func(host string)
+	// Jobs collects the long-running work of every window for the
+	// activity panel.
+	Jobs *jobs.Manager
+	// Scheduler refreshes the stars and open details windows on a schedule.
+	Scheduler *schedule.Scheduler
+	// Recent remembers opened repos for the tray menu.
+	Recent *recent.Store
+	// Keymap holds the rebindable keyboard shortcuts.
+	Keymap *keymap.Store
+	// Layout says whether details open in windows or next to the stars list.
+	Layout *viewmode.Store
+	// Session remembers the open windows when the stars window closes.
+	Session *session.Store
+	// Settings holds the app-wide preferences; the settings window edits
+	// them together with Layout.
+	Settings *settings.Store
+	// Cache is the on-disk cache the settings window measures and clears.
+	Cache settingsui.Cache
 
 	mu          sync.Mutex
 	starsWindow fyne.Window
+	starsVM     *starsui.VM
+	starsView   fyne.CanvasObject
+	pane        *details.Pane
+	tabs        *details.TabsWindow
+	layoutMenu  *fyne.Menu
+	layoutItems []*fyne.MenuItem
+	quickSearch fyne.Window
+	tray        bool
+	updates     fyne.Window
+	compare     fyne.Window
+	team        fyne.Window
+	activity    fyne.Window
+	settings    fyne.Window
 	details     map[string]fyne.Window
+	changes     map[string]fyne.Window
 }
 
 func (n *AppNavigator) ShowStars() {
@@ -31,21 +104,114 @@ func (n *AppNavigator) ShowStars() {
 	}
 	n.mu.Unlock()
 
-	w := starsui.NewStarsWindow(n.App, n.StarsSvc, n)
+	opts := []starsui.Option{starsui.WithCredentials(n.newCredentials())}
+	if n.Enricher != nil {
+		opts = append(opts, starsui.WithEnricher(n.Enricher))
+	}
+	if n.Trends != nil {
+		opts = append(opts, starsui.WithTrends(n.Trends))
+	}
+	if n.Jobs != nil {
+		opts = append(opts, starsui.WithJobs(n.Jobs))
+	}
+	if n.Scheduler != nil {
+		opts = append(opts, starsui.WithScheduler(n.Scheduler))
+	}
+	if n.Keymap != nil {
+		opts = append(opts, starsui.WithKeymap(n.Keymap))
+	}
+	if n.Settings != nil {
+		s := n.Settings.Load()
+		opts = append(opts, starsui.WithListDefaults(s.DefaultSort, s.PerPage))
+	}
+	if n.Profiles != nil && n.History != nil {
+		opts = append(opts, starsui.WithCatalogCache(n.History))
+	}
+	w, vm := starsui.NewStarsWindow(n.App, n.StarsSvc, n, opts...)
+	n.addMenu(w)
 
 	n.mu.Lock()
 	n.starsWindow = w
+	n.starsVM = vm
+	n.starsView = w.Content()
 	n.mu.Unlock()
+	n.applyLayout(n.Layout.Mode())
+	unwatchLayout := n.watchLayout()
 
-	w.SetOnClosed(func() {
+	// Not trackClose: with a tray, closing only hides the window, and
+	// otherwise the list's state is saved first.
+	w.SetCloseIntercept(func() {
 		n.mu.Lock()
+		tray := n.tray
+		n.mu.Unlock()
+		if tray && n.keepInTray() {
+			w.Hide()
+			return
+		}
+
+		n.SaveSession()
+		unwatchLayout()
+		n.mu.Lock()
+		pane := n.pane
 		n.starsWindow = nil
+		n.starsVM = nil
+		n.starsView = nil
+		n.pane = nil
 		n.mu.Unlock()
+		if pane != nil {
+			pane.Cleanup()
+		}
+		w.Close()
+		// With a tray the app would otherwise outlive its main window.
+		if tray {
+			n.App.Quit()
+		}
 	})
 	w.Show()
 }
 
+// newCredentials builds the account form of the stars window.
+func (n *AppNavigator) newCredentials() *credentials.VM {
+	var opts []credentials.Option
+	if n.Profiles != nil {
+		opts = append(opts, credentials.WithProfiles(n.Profiles))
+	}
+	if n.Tokens != nil {
+		opts = append(opts, credentials.WithTokens(n.Tokens))
+	}
+	if n.TokenFinder != nil {
+		opts = append(opts, credentials.WithTokenFinder(n.TokenFinder))
+	}
+	if n.Users != nil {
+		opts = append(opts, credentials.WithUserLookup(n.Users))
+	}
+	if n.TokenChecker != nil {
+		opts = append(opts, credentials.WithTokenCheck(n.TokenChecker))
+	}
+	if n.DeviceLogin != nil {
+		opts = append(opts, credentials.WithDeviceLogin(n.DeviceLogin))
+	}
+	if n.OnHostChange != nil {
+		opts = append(opts, credentials.WithHostChange(n.OnHostChange))
+	}
+	return credentials.NewVM(fyne.Do, opts...)
+}
+
 func (n *AppNavigator) ShowRepoDetails(fullName, token string) {
+	if n.Recent != nil {
+		n.Recent.Add(fullName)
+		n.refreshTray()
+	}
+	n.showDetails(fullName, token)
+}
+
+// showDetails opens fullName the way the layout mode asks for and returns
+// its window, or nil when it went to the split pane or a tab.
+func (n *AppNavigator) showDetails(fullName, token string) fyne.Window {
+	if n.showInPane(fullName, token) || n.showInTab(fullName, token) {
+		return nil
+	}
+
 	n.mu.Lock()
 	if n.details == nil {
 		n.details = map[string]fyne.Window{}
@@ -54,20 +220,222 @@ func (n *AppNavigator) ShowRepoDetails(fullName, token string) {
 		n.mu.Unlock()
 		w.RequestFocus()
 		w.Show()
-		return
+		return w
 	}
 	n.mu.Unlock()
 
-	w := details.NewRepoDetailsWindow(n.App, n.RepoSvc, fullName, token)
+	w := details.NewRepoDetailsWindow(n.App, n.RepoSvc, fullName, token, n.detailsOptions()...)
+	n.addMenu(w)
 
 	n.mu.Lock()
 	n.details[fullName] = w
 	n.mu.Unlock()
 
-	w.SetOnClosed(func() {
+	n.trackClose(w, func() { delete(n.details, fullName) })
+	w.Show()
+	return w
+}
+
+func (n *AppNavigator) detailsOptions() []details.Option {
+	var opts []details.Option
+	if n.Trends != nil {
+		opts = append(opts, details.WithTrends(n.Trends))
+	}
+	if n.Scheduler != nil {
+		opts = append(opts, details.WithScheduler(n.Scheduler))
+	}
+	return opts
+}
+
+func (n *AppNavigator) ShowChanges(username, token string) {
+	if n.History == nil || strings.TrimSpace(username) == "" {
+		return
+	}
+	key := strings.ToLower(username)
+
+	n.mu.Lock()
+	if n.changes == nil {
+		n.changes = map[string]fyne.Window{}
+	}
+	if w, ok := n.changes[key]; ok {
+		n.mu.Unlock()
+		w.RequestFocus()
+		w.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	w := changes.NewChangesWindow(n.App, n.History, username, token, n)
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.changes[key] = w
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { delete(n.changes, key) })
+	w.Show()
+}
+
+func (n *AppNavigator) ShowUpdates() {
+	if n.Releases == nil {
+		return
+	}
+	n.mu.Lock()
+	if n.updates != nil {
+		n.mu.Unlock()
+		n.updates.RequestFocus()
+		n.updates.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	w := updates.NewUpdatesWindow(n.App, n.Releases)
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.updates = w
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { n.updates = nil })
+	w.Show()
+}
+
+// ShowCompare opens the compare window seeded with usernames, or focuses it
+// when it is already open.
+func (n *AppNavigator) ShowCompare(usernames []string, token string) {
+	n.mu.Lock()
+	if n.compare != nil {
+		n.mu.Unlock()
+		n.compare.RequestFocus()
+		n.compare.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	svc := n.CompareSvc
+	if svc.Stars == nil {
+		svc.Stars = n.StarsSvc
+	}
+	w := compareui.NewCompareWindow(n.App, svc, usernames, token, n)
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.compare = w
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { n.compare = nil })
+	w.Show()
+}
+
+func (n *AppNavigator) ShowTeam(token string) {
+	if n.Team == nil {
+		return
+	}
+	n.mu.Lock()
+	if n.team != nil {
+		n.mu.Unlock()
+		n.team.RequestFocus()
+		n.team.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	var opts []teamui.Option
+	if n.Jobs != nil {
+		opts = append(opts, teamui.WithJobs(n.Jobs))
+	}
+	w := teamui.NewTeamWindow(n.App, n.Team, token, n, opts...)
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.team = w
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { n.team = nil })
+	w.Show()
+}
+
+// ShowActivity opens the activity panel listing the jobs of every window, or
+// focuses it when it is already open.
+func (n *AppNavigator) ShowActivity() {
+	if n.Jobs == nil {
+		return
+	}
+	n.mu.Lock()
+	if n.activity != nil {
+		n.mu.Unlock()
+		n.activity.RequestFocus()
+		n.activity.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	w := activity.NewActivityWindow(n.App, n.Jobs)
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.activity = w
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { n.activity = nil })
+	w.Show()
+}
+
+// ShowSettings opens the settings window, or focuses it when it is already
+// open.
+func (n *AppNavigator) ShowSettings() {
+	if n.Settings == nil {
+		return
+	}
+	n.mu.Lock()
+	if n.settings != nil {
+		n.mu.Unlock()
+		n.settings.RequestFocus()
+		n.settings.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	w := settingsui.NewSettingsWindow(n.App, n.Settings, n.Layout, n.Keymap, n.Cache)
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.settings = w
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { n.settings = nil })
+	w.Show()
+}
+
+// trackClose calls forget, under n.mu, when the user closes w, so the window
+// is no longer reused. It intercepts the close instead of setting OnClosed,
+// which would replace the handler each window sets for itself to cancel its
+// loads and subscriptions.
+func (n *AppNavigator) trackClose(w fyne.Window, forget func()) {
+	w.SetCloseIntercept(func() {
 		n.mu.Lock()
-		delete(n.details, fullName)
+		forget()
 		n.mu.Unlock()
+		w.Close()
 	})
-	w.Show()
+}
+
+// addMenu gives w a Window menu so the stars, activity and settings windows
+// are reachable from every window.
+func (n *AppNavigator) addMenu(w fyne.Window) {
+	items := []*fyne.MenuItem{fyne.NewMenuItem("Stars", n.ShowStars)}
+	if n.Releases != nil {
+		items = append(items, fyne.NewMenuItem("Updates", n.ShowUpdates))
+	}
+	if n.Jobs != nil {
+		items = append(items, fyne.NewMenuItem("Activity", n.ShowActivity))
+	}
+	if n.Settings != nil {
+		items = append(items, fyne.NewMenuItem("Settings", n.ShowSettings))
+	}
+	menus := []*fyne.Menu{fyne.NewMenu("Window", items...)}
+	if n.Layout != nil {
+		menus = append(menus, n.viewMenu())
+	}
+	w.SetMainMenu(fyne.NewMainMenu(menus...))
 }
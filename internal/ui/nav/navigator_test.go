@@ -1,10 +1,26 @@
 package nav_test
 
 import (
+	"context"
+	"slices"
 	"testing"
+	"time"
 
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/app/instance"
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/recent"
 	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/session"
+	"github.com/tbxark/gh-stars/internal/app/settings"
 	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
 	"github.com/tbxark/gh-stars/internal/testutil"
 	"github.com/tbxark/gh-stars/internal/ui/nav"
 )
@@ -32,3 +48,226 @@ func TestAppNavigator_ThreadSafety(t *testing.T) {
 
 	testutil.AssertTrue(t, navigator != nil, "Navigator with mutex protection initialized")
 }
+
+// idleRepos never answers. The test driver runs fyne.Do on the calling
+// goroutine, so an answer would update the windows while the test drives
+// them.
+func idleRepos() *repos.MockService {
+	svc := repos.NewMockService()
+	svc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		<-ctx.Done()
+		return domain.RepoDetails{}, ctx.Err()
+	}
+	svc.LoadActivityFunc = func(ctx context.Context, fullName, token string) (domain.RepoActivity, error) {
+		<-ctx.Done()
+		return domain.RepoActivity{}, ctx.Err()
+	}
+	return svc
+}
+
+// closeAll closes every window, which cancels their loads.
+func closeAll(app fyne.App) {
+	// AllWindows is the driver's own slice, which Close shrinks.
+	for _, w := range slices.Clone(app.Driver().AllWindows()) {
+		w.Close()
+	}
+}
+
+func TestAppNavigator_ShowRepoDetails_RemembersRecent(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+		Recent:   recent.NewStore(app.Preferences()),
+	}
+	navigator.ShowRepoDetails("golang/go", "")
+	navigator.ShowRepoDetails("fyne-io/fyne", "")
+	navigator.ShowRepoDetails("golang/go", "")
+
+	list := navigator.Recent.List()
+	testutil.AssertEqual(t, 2, len(list))
+	testutil.AssertEqual(t, "golang/go", list[0])
+	testutil.AssertFalse(t, navigator.InstallTray(), "the test driver has no system tray")
+}
+
+func TestAppNavigator_SplitLayout_ShowsDetailsInStarsWindow(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+		Layout:   viewmode.NewStore(app.Preferences()),
+	}
+	navigator.SetLayoutMode(viewmode.Split)
+	navigator.ShowRepoDetails("golang/go", "")
+
+	stars := findWindow(app, "GitHub Stars")
+	testutil.AssertTrue(t, stars != nil, "split mode should open the stars window")
+	testutil.AssertTrue(t, findWindow(app, "Repo Details: golang/go") == nil, "split mode should not open a details window")
+	_, split := stars.Content().(*container.Split)
+	testutil.AssertTrue(t, split, "stars window should hold the split layout")
+
+	navigator.SetLayoutMode(viewmode.Windows)
+	_, split = stars.Content().(*container.Split)
+	testutil.AssertFalse(t, split, "windows mode should show the list alone")
+	navigator.ShowRepoDetails("golang/go", "")
+	testutil.AssertTrue(t, findWindow(app, "Repo Details: golang/go") != nil, "windows mode should open a details window")
+}
+
+func findWindow(app fyne.App, title string) fyne.Window {
+	for _, w := range app.Driver().AllWindows() {
+		if w.Title() == title {
+			return w
+		}
+	}
+	return nil
+}
+
+func TestAppNavigator_TabsLayout_OpensReposAsTabs(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+		Layout:   viewmode.NewStore(app.Preferences()),
+	}
+	_, open := navigator.TabsState()
+	testutil.AssertFalse(t, open, "no tabs window yet")
+
+	navigator.SetLayoutMode(viewmode.Tabs)
+	navigator.ShowRepoDetails("golang/go", "")
+	navigator.ShowRepoDetails("fyne-io/fyne", "")
+
+	state, open := navigator.TabsState()
+	testutil.AssertTrue(t, open, "tabs mode should open the tabs window")
+	testutil.AssertEqual(t, 2, len(state.Tabs))
+	testutil.AssertEqual(t, "fyne-io/fyne", state.Active)
+	testutil.AssertTrue(t, findWindow(app, "Repo Details: fyne-io/fyne") != nil, "one window titled after the active tab")
+}
+
+func TestAppNavigator_RestoreSession_SkipsUnstarredRepos(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	snapshots := history.NewStore(t.TempDir())
+	testutil.AssertNoError(t, snapshots.Save(history.Snapshot{
+		Username: "octocat",
+		TakenAt:  time.Now(),
+		Repos:    testdata.SampleRepoList(),
+	}))
+	store := session.NewStore(app.Preferences())
+	testutil.AssertNoError(t, store.Save(session.State{
+		Username: "octocat",
+		Query:    "lang:go",
+		Stars:    session.Size{Width: 1300, Height: 800},
+		Windows: []session.Window{
+			{Route: session.Details, Name: "golang/go", Size: session.Size{Width: 700, Height: 500}},
+			{Route: session.Details, Name: "gone/repo"},
+			// Without a releases poller there is no updates window to reopen.
+			{Route: session.Updates},
+		},
+	}))
+
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+		History:  snapshots,
+		Session:  store,
+	}
+	navigator.RestoreSession()
+
+	starsWindow := findWindow(app, "GitHub Stars")
+	testutil.AssertTrue(t, starsWindow != nil, "restore should open the stars window")
+	testutil.AssertEqual(t, fyne.NewSize(1300, 800), starsWindow.Canvas().Size())
+	details := findWindow(app, "Repo Details: golang/go")
+	testutil.AssertTrue(t, details != nil, "starred repo should be reopened")
+	testutil.AssertEqual(t, fyne.NewSize(700, 500), details.Canvas().Size())
+	testutil.AssertTrue(t, findWindow(app, "Repo Details: gone/repo") == nil, "unstarred repo should be skipped")
+
+	navigator.SaveSession()
+	saved, ok := store.Load()
+	testutil.AssertTrue(t, ok, "session should be saved")
+	testutil.AssertEqual(t, "lang:go", saved.Query)
+	testutil.AssertEqual(t, 1, len(saved.Windows))
+	testutil.AssertEqual(t, "golang/go", saved.Windows[0].Name)
+}
+
+func TestAppNavigator_Handle_RoutesForwardedArgs(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	store := session.NewStore(app.Preferences())
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+		Session:  store,
+	}
+	navigator.Handle(instance.Request{Action: instance.Open, Value: "golang/go"})
+	testutil.AssertTrue(t, findWindow(app, "Repo Details: golang/go") != nil, "open should show the repo")
+
+	navigator.Handle(instance.Request{Action: instance.Search, Value: "lang:go"})
+	testutil.AssertTrue(t, findWindow(app, "GitHub Stars") != nil, "search should open the stars window")
+	navigator.SaveSession()
+	saved, _ := store.Load()
+	testutil.AssertEqual(t, "lang:go", saved.Query)
+}
+
+func TestAppNavigator_ShowSettings(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+	}
+	navigator.ShowSettings()
+	testutil.AssertTrue(t, findWindow(app, "Settings") == nil, "no settings window without a store")
+
+	navigator.Settings = settings.NewStore(app.Preferences())
+	navigator.Layout = viewmode.NewStore(app.Preferences())
+	// Cache is left without a dir, so its size is not measured in the
+	// background while the test closes the window.
+	navigator.ShowSettings()
+	navigator.ShowSettings()
+	count := 0
+	for _, w := range app.Driver().AllWindows() {
+		if w.Title() == "Settings" {
+			count++
+		}
+	}
+	testutil.AssertEqual(t, 1, count)
+}
+
+func TestAppNavigator_ShowActivity_HasWindowMenu(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	defer closeAll(app)
+
+	navigator := &nav.AppNavigator{
+		App:      app,
+		StarsSvc: stars.NewMockService(),
+		RepoSvc:  idleRepos(),
+		Jobs:     &jobs.Manager{},
+	}
+	navigator.ShowActivity()
+
+	w := findWindow(app, "Activity")
+	testutil.AssertTrue(t, w != nil, "activity window should open")
+	testutil.AssertTrue(t, w.MainMenu() != nil, "activity window should have the Window menu")
+}
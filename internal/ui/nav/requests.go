@@ -0,0 +1,22 @@
+package nav
+
+import "github.com/tbxark/gh-stars/internal/app/instance"
+
+// Handle carries out a command line, either this launch's own or one
+// forwarded by a later launch. Call it on the main thread.
+func (n *AppNavigator) Handle(req instance.Request) {
+	switch req.Action {
+	case instance.Open:
+		n.ShowRepoDetails(req.Value, n.currentToken())
+	case instance.Search:
+		n.ShowStars()
+		n.mu.Lock()
+		vm := n.starsVM
+		n.mu.Unlock()
+		if vm != nil {
+			_ = vm.Query.Set(req.Value)
+		}
+	default:
+		n.ShowStars()
+	}
+}
@@ -0,0 +1,207 @@
+package nav
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/session"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	starsui "github.com/tbxark/gh-stars/internal/ui/stars"
+)
+
+// SaveSession remembers the stars window's filter and selection and every
+// open window with its size. It does nothing once the stars window has
+// closed, since that already saved the session. Call it on the main thread.
+func (n *AppNavigator) SaveSession() {
+	if n.Session == nil {
+		return
+	}
+	if state, ok := n.sessionState(); ok {
+		_ = n.Session.Save(state)
+	}
+}
+
+func (n *AppNavigator) sessionState() (session.State, bool) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.starsWindow == nil {
+		return session.State{}, false
+	}
+
+	view := n.starsVM.ViewState()
+	username, _ := n.starsVM.Username.Get()
+	state := session.State{
+		Username:    username,
+		Query:       view.Query,
+		Sort:        view.Sort,
+		HidePrivate: view.HidePrivate,
+		Selected:    view.Selected,
+		Stars:       sizeOf(n.starsWindow),
+	}
+	if n.pane != nil {
+		state.Pane = n.pane.Shown()
+	}
+
+	for _, name := range sortedKeys(n.details) {
+		state.Windows = append(state.Windows, session.Window{Route: session.Details, Name: name, Size: sizeOf(n.details[name])})
+	}
+	if n.tabs != nil {
+		tabs := n.tabs.State()
+		state.Windows = append(state.Windows, session.Window{Route: session.Tabs, Size: sizeOf(n.tabs.Window()), Tabs: &tabs})
+	}
+	for _, name := range sortedKeys(n.changes) {
+		state.Windows = append(state.Windows, session.Window{Route: session.Changes, Name: name, Size: sizeOf(n.changes[name])})
+	}
+	for _, single := range []struct {
+		route session.Route
+		w     fyne.Window
+	}{
+		{session.Updates, n.updates},
+		{session.Compare, n.compare},
+		{session.Team, n.team},
+		{session.Activity, n.activity},
+		{session.Settings, n.settings},
+	} {
+		if single.w != nil {
+			state.Windows = append(state.Windows, session.Window{Route: single.route, Size: sizeOf(single.w)})
+		}
+	}
+	return state, true
+}
+
+// RestoreSession opens the stars window and reopens what was open when the
+// app last closed. Repos that the last synced catalog no longer has are
+// skipped, and the status bar says how many.
+func (n *AppNavigator) RestoreSession() {
+	n.ShowStars()
+	state, ok := n.Session.Load()
+	if !ok {
+		return
+	}
+	n.mu.Lock()
+	w, vm := n.starsWindow, n.starsVM
+	n.mu.Unlock()
+	if w == nil {
+		return
+	}
+
+	resize(w, state.Stars)
+	state, dropped := n.pruneUnstarred(state)
+	vm.RestoreView(starsui.ViewState{
+		Query:       state.Query,
+		Sort:        state.Sort,
+		HidePrivate: state.HidePrivate,
+		Selected:    state.Selected,
+	})
+	switch len(dropped) {
+	case 0:
+	case 1:
+		_ = vm.Status.Set(dropped[0] + " is no longer starred and was not reopened")
+	default:
+		_ = vm.Status.Set(fmt.Sprintf("%d repos are no longer starred and were not reopened", len(dropped)))
+	}
+
+	token, _ := vm.Token.Get()
+	if state.Pane != "" {
+		n.showInPane(state.Pane, token)
+	}
+	for _, win := range state.Windows {
+		n.restoreWindow(win, token, state.Username)
+	}
+}
+
+// restoreWindow reopens one saved window. Repos go through the current
+// layout mode, which may differ from the one they were saved in.
+func (n *AppNavigator) restoreWindow(win session.Window, token, username string) {
+	switch win.Route {
+	case session.Details:
+		resize(n.showDetails(win.Name, token), win.Size)
+	case session.Tabs:
+		if win.Tabs == nil {
+			return
+		}
+		if n.Layout.Mode() != viewmode.Tabs {
+			n.showDetails(win.Tabs.Active, token)
+			return
+		}
+		n.RestoreTabs(*win.Tabs, token)
+		n.mu.Lock()
+		tabs := n.tabs
+		n.mu.Unlock()
+		if tabs != nil {
+			resize(tabs.Window(), win.Size)
+		}
+	case session.Changes:
+		n.ShowChanges(win.Name, token)
+		resize(n.openWindow(func() fyne.Window { return n.changes[strings.ToLower(win.Name)] }), win.Size)
+	case session.Updates:
+		n.ShowUpdates()
+		resize(n.openWindow(func() fyne.Window { return n.updates }), win.Size)
+	case session.Compare:
+		var usernames []string
+		if username != "" {
+			usernames = append(usernames, username)
+		}
+		n.ShowCompare(usernames, token)
+		resize(n.openWindow(func() fyne.Window { return n.compare }), win.Size)
+	case session.Team:
+		n.ShowTeam(token)
+		resize(n.openWindow(func() fyne.Window { return n.team }), win.Size)
+	case session.Activity:
+		n.ShowActivity()
+		resize(n.openWindow(func() fyne.Window { return n.activity }), win.Size)
+	case session.Settings:
+		n.ShowSettings()
+		resize(n.openWindow(func() fyne.Window { return n.settings }), win.Size)
+	}
+}
+
+// pruneUnstarred drops the saved repos missing from the last synced catalog
+// of the saved user. Without a catalog everything is kept.
+func (n *AppNavigator) pruneUnstarred(state session.State) (session.State, []string) {
+	if n.History == nil || state.Username == "" {
+		return state, nil
+	}
+	snapshot, ok, err := n.History.Latest(state.Username)
+	if err != nil || !ok {
+		return state, nil
+	}
+	starred := make(map[string]bool, len(snapshot.Repos))
+	for _, repo := range snapshot.Repos {
+		starred[strings.ToLower(repo.FullName)] = true
+	}
+	return state.Prune(func(fullName string) bool {
+		return starred[strings.ToLower(fullName)]
+	})
+}
+
+// openWindow reads a window field under n.mu.
+func (n *AppNavigator) openWindow(get func() fyne.Window) fyne.Window {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	return get()
+}
+
+func sizeOf(w fyne.Window) session.Size {
+	size := w.Canvas().Size()
+	return session.Size{Width: size.Width, Height: size.Height}
+}
+
+// resize gives w a saved size; a missing window or size is left alone.
+func resize(w fyne.Window, size session.Size) {
+	if w != nil && !size.IsZero() {
+		w.Resize(fyne.NewSize(size.Width, size.Height))
+	}
+}
+
+func sortedKeys(windows map[string]fyne.Window) []string {
+	keys := make([]string, 0, len(windows))
+	for key := range windows {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
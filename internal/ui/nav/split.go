@@ -0,0 +1,149 @@
+package nav
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/ui/details"
+)
+
+// splitWidth is the stars window width the split layout grows it to, so the
+// list keeps the room it has on its own.
+const splitWidth = 1500
+
+// LayoutMode returns how repo details are laid out.
+func (n *AppNavigator) LayoutMode() viewmode.Mode {
+	return n.Layout.Mode()
+}
+
+// SetLayoutMode saves mode and rearranges the stars window to match. Call it
+// on the main thread.
+func (n *AppNavigator) SetLayoutMode(mode viewmode.Mode) {
+	n.Layout.SetMode(mode)
+}
+
+// watchLayout follows the layout mode and the list selection while the stars
+// window is open. The returned func stops it.
+func (n *AppNavigator) watchLayout() func() {
+	unsubscribe := n.Layout.Subscribe(func(mode viewmode.Mode) {
+		n.applyLayout(mode)
+		n.refreshViewMenu()
+	})
+
+	n.mu.Lock()
+	vm := n.starsVM
+	n.mu.Unlock()
+	follow := binding.NewDataListener(func() {
+		n.mu.Lock()
+		pane := n.pane
+		n.mu.Unlock()
+		if pane == nil {
+			return
+		}
+		index, _ := vm.Selected.Get()
+		if repo, ok := vm.RepoAt(index); ok {
+			token, _ := vm.Token.Get()
+			pane.Show(repo.FullName, token)
+		}
+	})
+	vm.Selected.AddListener(follow)
+
+	return func() {
+		unsubscribe()
+		vm.Selected.RemoveListener(follow)
+	}
+}
+
+// applyLayout puts a details pane next to the stars list in split mode, or
+// shows the list alone.
+func (n *AppNavigator) applyLayout(mode viewmode.Mode) {
+	n.mu.Lock()
+	w, view, pane := n.starsWindow, n.starsView, n.pane
+	if mode != viewmode.Split {
+		n.pane = nil
+	}
+	n.mu.Unlock()
+	if w == nil {
+		return
+	}
+
+	if mode != viewmode.Split {
+		if pane != nil {
+			pane.Cleanup()
+			w.SetContent(view)
+		}
+		return
+	}
+	if pane != nil {
+		return
+	}
+
+	pane = details.NewPane(w, n.RepoSvc, n.detailsOptions()...)
+	split := container.NewHSplit(view, pane.Object())
+	split.Offset = 0.6
+	w.SetContent(split)
+	if size := w.Canvas().Size(); size.Width < splitWidth {
+		w.Resize(fyne.NewSize(splitWidth, size.Height))
+	}
+
+	n.mu.Lock()
+	n.pane = pane
+	n.mu.Unlock()
+}
+
+// showInPane shows fullName in the split layout's details pane, opening the
+// stars window first if needed. It reports false outside split mode.
+func (n *AppNavigator) showInPane(fullName, token string) bool {
+	if n.Layout.Mode() != viewmode.Split {
+		return false
+	}
+	n.ShowStars()
+	n.mu.Lock()
+	pane := n.pane
+	n.mu.Unlock()
+	if pane == nil {
+		return false
+	}
+	pane.Show(fullName, token)
+	return true
+}
+
+// viewMenu lets every window switch the layout mode. One menu is shared by
+// all windows so a single Refresh updates their check marks.
+func (n *AppNavigator) viewMenu() *fyne.Menu {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+	if n.layoutMenu != nil {
+		return n.layoutMenu
+	}
+	items := make([]*fyne.MenuItem, len(viewmode.Modes))
+	for i, mode := range viewmode.Modes {
+		items[i] = fyne.NewMenuItem(mode.String(), func() { n.SetLayoutMode(mode) })
+	}
+	n.layoutMenu = fyne.NewMenu("View", items...)
+	n.layoutItems = items
+	n.checkLayoutItems()
+	return n.layoutMenu
+}
+
+func (n *AppNavigator) refreshViewMenu() {
+	n.mu.Lock()
+	menu := n.layoutMenu
+	if menu != nil {
+		n.checkLayoutItems()
+	}
+	n.mu.Unlock()
+	if menu != nil {
+		menu.Refresh()
+	}
+}
+
+// checkLayoutItems marks the current mode. n.mu must be held.
+func (n *AppNavigator) checkLayoutItems() {
+	current := n.Layout.Mode()
+	for i, mode := range viewmode.Modes {
+		n.layoutItems[i].Checked = mode == current
+	}
+}
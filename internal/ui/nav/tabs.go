@@ -0,0 +1,63 @@
+package nav
+
+import (
+	"github.com/tbxark/gh-stars/internal/app/tabstack"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/ui/details"
+)
+
+// showInTab opens fullName as a tab of the details window in tabs mode,
+// opening the window first if needed. It reports false in other modes.
+func (n *AppNavigator) showInTab(fullName, token string) bool {
+	if n.Layout.Mode() != viewmode.Tabs {
+		return false
+	}
+	tabs := n.tabsWindow()
+	tabs.Open(fullName, token)
+	tabs.Window().Show()
+	tabs.Window().RequestFocus()
+	return true
+}
+
+// tabsWindow returns the tabbed details window, creating it when closed.
+func (n *AppNavigator) tabsWindow() *details.TabsWindow {
+	n.mu.Lock()
+	tabs := n.tabs
+	n.mu.Unlock()
+	if tabs != nil {
+		return tabs
+	}
+
+	tabs = details.NewTabsWindow(n.App, n.RepoSvc, n.detailsOptions()...)
+	w := tabs.Window()
+	n.addMenu(w)
+
+	n.mu.Lock()
+	n.tabs = tabs
+	n.mu.Unlock()
+
+	n.trackClose(w, func() { n.tabs = nil })
+	return tabs
+}
+
+// TabsState returns the tabs and history of the tabbed details window, for
+// saving them; ok is false while the window is closed.
+func (n *AppNavigator) TabsState() (tabstack.State, bool) {
+	n.mu.Lock()
+	tabs := n.tabs
+	n.mu.Unlock()
+	if tabs == nil {
+		return tabstack.State{}, false
+	}
+	return tabs.State(), true
+}
+
+// RestoreTabs reopens saved tabs in the tabbed details window.
+func (n *AppNavigator) RestoreTabs(state tabstack.State, token string) {
+	if len(state.Tabs) == 0 {
+		return
+	}
+	tabs := n.tabsWindow()
+	tabs.Restore(state, token)
+	tabs.Window().Show()
+}
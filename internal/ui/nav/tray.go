@@ -0,0 +1,128 @@
+package nav
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/driver/desktop"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/ui/quicksearch"
+)
+
+const keepInTrayKey = "tray.keep_running"
+
+// InstallTray adds the tray or menu-bar menu on desktops that have one and
+// reports whether it did.
+func (n *AppNavigator) InstallTray() bool {
+	if _, ok := n.App.(desktop.App); !ok {
+		return false
+	}
+	n.mu.Lock()
+	n.tray = true
+	n.mu.Unlock()
+	n.refreshTray()
+	return true
+}
+
+// SyncNow opens the stars window if needed and reloads it.
+func (n *AppNavigator) SyncNow() {
+	n.ShowStars()
+	n.mu.Lock()
+	vm := n.starsVM
+	n.mu.Unlock()
+	if vm != nil {
+		vm.Load()
+	}
+}
+
+// ShowQuickSearch opens a small window that searches the loaded stars and
+// opens the chosen repo's details.
+func (n *AppNavigator) ShowQuickSearch() {
+	n.mu.Lock()
+	if n.quickSearch != nil {
+		n.mu.Unlock()
+		n.quickSearch.RequestFocus()
+		n.quickSearch.Show()
+		return
+	}
+	n.mu.Unlock()
+
+	search := func(query string, limit int) []domain.Repo {
+		n.mu.Lock()
+		vm := n.starsVM
+		n.mu.Unlock()
+		if vm == nil {
+			return nil
+		}
+		return vm.Search(query, limit)
+	}
+	// The window closes itself once a repo is opened, which trackClose
+	// does not see, so opening forgets it too.
+	forget := func() { n.quickSearch = nil }
+	open := func(fullName string) {
+		n.mu.Lock()
+		forget()
+		n.mu.Unlock()
+		n.ShowRepoDetails(fullName, n.currentToken())
+	}
+	w := quicksearch.NewQuickSearchWindow(n.App, search, open)
+
+	n.mu.Lock()
+	n.quickSearch = w
+	n.mu.Unlock()
+
+	n.trackClose(w, forget)
+	w.Show()
+}
+
+// refreshTray rebuilds the tray menu, for instance after a repo was opened.
+func (n *AppNavigator) refreshTray() {
+	desk, ok := n.App.(desktop.App)
+	n.mu.Lock()
+	installed := n.tray
+	n.mu.Unlock()
+	if !ok || !installed {
+		return
+	}
+
+	items := []*fyne.MenuItem{
+		fyne.NewMenuItem("Show Stars", n.ShowStars),
+		fyne.NewMenuItem("Quick Search...", n.ShowQuickSearch),
+		fyne.NewMenuItem("Sync Now", n.SyncNow),
+	}
+	if n.Recent != nil {
+		if repos := n.Recent.List(); len(repos) > 0 {
+			items = append(items, fyne.NewMenuItemSeparator())
+			for _, fullName := range repos {
+				items = append(items, fyne.NewMenuItem(fullName, func() {
+					n.ShowRepoDetails(fullName, n.currentToken())
+				}))
+			}
+		}
+	}
+
+	keep := fyne.NewMenuItem("Keep Running in Tray", func() {
+		n.App.Preferences().SetBool(keepInTrayKey, !n.keepInTray())
+		n.refreshTray()
+	})
+	keep.Checked = n.keepInTray()
+	items = append(items, fyne.NewMenuItemSeparator(), keep)
+
+	desk.SetSystemTrayMenu(fyne.NewMenu("GitHub Stars", items...))
+}
+
+// keepInTray reports whether closing the stars window only hides it.
+func (n *AppNavigator) keepInTray() bool {
+	return n.App.Preferences().Bool(keepInTrayKey)
+}
+
+// currentToken is the token typed into the stars window, if it is open.
+func (n *AppNavigator) currentToken() string {
+	n.mu.Lock()
+	vm := n.starsVM
+	n.mu.Unlock()
+	if vm == nil {
+		return ""
+	}
+	token, _ := vm.Token.Get()
+	return token
+}
@@ -0,0 +1,65 @@
+package palette
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// Show opens the palette as a dialog over w. The dialog closes before the
+// chosen command runs, so commands can open dialogs of their own.
+func Show(w fyne.Window, vm *VM) {
+	var d dialog.Dialog
+	run := func(index int) {
+		d.Hide()
+		vm.Run(index)
+	}
+
+	entry := widget.NewEntryWithData(vm.Query)
+	entry.SetPlaceHolder("Type a command or a repo name")
+	entry.OnSubmitted = func(string) { run(0) }
+	vm.Query.AddListener(binding.NewDataListener(vm.Search))
+
+	list := widget.NewListWithData(vm.Matches, func() fyne.CanvasObject {
+		icon := widget.NewIcon(theme.MediaPlayIcon())
+		title := widget.NewLabel("")
+		title.Wrapping = fyne.TextTruncate
+		detail := widget.NewLabel("")
+		detail.Importance = widget.LowImportance
+		return container.NewBorder(nil, nil, icon, detail, title)
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[Item])
+		if !ok {
+			return
+		}
+		match, err := item.Get()
+		if err != nil {
+			return
+		}
+		row := obj.(*fyne.Container)
+		row.Objects[0].(*widget.Label).SetText(match.Title)
+		icon := row.Objects[1].(*widget.Icon)
+		if match.Repo {
+			icon.SetResource(theme.FolderOpenIcon())
+		} else {
+			icon.SetResource(theme.MediaPlayIcon())
+		}
+		row.Objects[2].(*widget.Label).SetText(match.Detail)
+	})
+	list.OnSelected = func(id widget.ListItemID) {
+		list.UnselectAll()
+		run(id)
+	}
+
+	status := widget.NewLabelWithData(vm.Status)
+	status.Importance = widget.LowImportance
+
+	content := container.NewBorder(entry, status, nil, nil, list)
+	d = dialog.NewCustom("Command Palette", "Close", content, w)
+	d.Resize(fyne.NewSize(560, 420))
+	d.Show()
+	w.Canvas().Focus(entry)
+}
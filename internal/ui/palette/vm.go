@@ -0,0 +1,138 @@
+package palette
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/fuzzy"
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+const maxMatches = 30
+
+// Command is an action the palette can run.
+type Command struct {
+	Title string
+	// Keys is the shortcut shown next to the title, if any.
+	Keys string
+	Run  func()
+}
+
+// Item is one row of the palette: a command or a repo to open.
+type Item struct {
+	Title  string
+	Detail string
+	Repo   bool
+}
+
+type VM struct {
+	Query   binding.String
+	Matches binding.List[Item]
+	Status  binding.String
+
+	commands  []Command
+	catalog   func() []domain.Repo
+	open      func(fullName string)
+	runOnMain func(func())
+
+	mu   sync.Mutex
+	runs []func()
+}
+
+// NewVM matches commands and the repos catalog returns; a chosen repo is
+// handed to open.
+func NewVM(commands []Command, catalog func() []domain.Repo, open func(fullName string), runOnMain func(func())) *VM {
+	vm := &VM{
+		Query:     binding.NewString(),
+		Matches:   binding.NewList(func(a, b Item) bool { return a == b }),
+		Status:    binding.NewString(),
+		commands:  commands,
+		catalog:   catalog,
+		open:      open,
+		runOnMain: runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	return vm
+}
+
+// Search ranks commands and repo names against Query. Commands come first
+// on equal scores; an empty query lists every command.
+func (vm *VM) Search() {
+	query, _ := vm.Query.Get()
+	query = strings.TrimSpace(query)
+
+	var repos []domain.Repo
+	if query != "" && vm.catalog != nil {
+		repos = vm.catalog()
+	}
+	candidates := make([]string, 0, len(vm.commands)+len(repos))
+	for _, cmd := range vm.commands {
+		candidates = append(candidates, cmd.Title)
+	}
+	for _, repo := range repos {
+		candidates = append(candidates, repo.FullName)
+	}
+
+	var positions []int
+	if query == "" {
+		for i := range vm.commands {
+			positions = append(positions, i)
+		}
+	} else {
+		for _, m := range fuzzy.Rank(query, candidates, maxMatches) {
+			positions = append(positions, m.Index)
+		}
+	}
+
+	items := make([]Item, len(positions))
+	runs := make([]func(), len(positions))
+	for i, pos := range positions {
+		if pos < len(vm.commands) {
+			cmd := vm.commands[pos]
+			items[i] = Item{Title: cmd.Title, Detail: cmd.Keys}
+			runs[i] = cmd.Run
+			continue
+		}
+		repo := repos[pos-len(vm.commands)]
+		items[i] = Item{Title: repo.FullName, Detail: repo.Language, Repo: true}
+		runs[i] = func() { vm.open(repo.FullName) }
+	}
+
+	vm.mu.Lock()
+	vm.runs = runs
+	vm.mu.Unlock()
+
+	vm.runOnMain(func() {
+		_ = vm.Matches.Set(items)
+		switch {
+		case len(items) == 0:
+			_ = vm.Status.Set("No matching commands or repos")
+		case query == "":
+			_ = vm.Status.Set("Type to find a command or a starred repo")
+		case len(items) == maxMatches:
+			_ = vm.Status.Set(fmt.Sprintf("Best %d matches", maxMatches))
+		default:
+			_ = vm.Status.Set(fmt.Sprintf("%d match(es)", len(items)))
+		}
+	})
+}
+
+// Run runs the match at index and reports whether there was one.
+func (vm *VM) Run(index int) bool {
+	vm.mu.Lock()
+	var run func()
+	if index >= 0 && index < len(vm.runs) {
+		run = vm.runs[index]
+	}
+	vm.mu.Unlock()
+	if run == nil {
+		return false
+	}
+	run()
+	return true
+}
@@ -0,0 +1,57 @@
+package palette_test
+
+import (
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/palette"
+)
+
+func TestVM_EmptyQueryListsCommands(t *testing.T) {
+	_ = test.NewApp()
+	vm := palette.NewVM([]palette.Command{
+		{Title: "Reload Stars", Keys: "Ctrl+R"},
+		{Title: "Focus Search", Keys: "Ctrl+F"},
+	}, func() []domain.Repo {
+		t.Fatal("an empty query should not list repos")
+		return nil
+	}, nil, func(f func()) { f() })
+
+	vm.Search()
+
+	items, _ := vm.Matches.Get()
+	testutil.AssertEqual(t, 2, len(items))
+	testutil.AssertEqual(t, palette.Item{Title: "Reload Stars", Detail: "Ctrl+R"}, items[0])
+}
+
+func TestVM_MatchesCommandsAndRepos(t *testing.T) {
+	_ = test.NewApp()
+	reloads := 0
+	var opened []string
+	vm := palette.NewVM([]palette.Command{
+		{Title: "Reload Stars", Run: func() { reloads++ }},
+		{Title: "Open Activity", Run: func() {}},
+	}, testdata.SampleRepoList, func(fullName string) { opened = append(opened, fullName) }, func(f func()) { f() })
+
+	_ = vm.Query.Set("rld")
+	vm.Search()
+	items, _ := vm.Matches.Get()
+	testutil.AssertTrue(t, len(items) > 0, "rld should match Reload Stars")
+	testutil.AssertEqual(t, "Reload Stars", items[0].Title)
+	testutil.AssertTrue(t, vm.Run(0), "first match should run")
+	testutil.AssertEqual(t, 1, reloads)
+
+	_ = vm.Query.Set("vscode")
+	vm.Search()
+	items, _ = vm.Matches.Get()
+	testutil.AssertEqual(t, 1, len(items))
+	testutil.AssertTrue(t, items[0].Repo, "match should be a repo")
+	testutil.AssertTrue(t, vm.Run(0), "repo should open")
+	testutil.AssertFalse(t, vm.Run(1), "there is no second match")
+	testutil.AssertEqual(t, 1, len(opened))
+	testutil.AssertEqual(t, "microsoft/vscode", opened[0])
+}
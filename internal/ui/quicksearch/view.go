@@ -0,0 +1,55 @@
+package quicksearch
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+// NewView lays out the search entry and matches; onOpened runs after a repo
+// was opened so the window can close itself.
+func NewView(w fyne.Window, vm *VM, onOpened func()) fyne.CanvasObject {
+	open := func(index int) {
+		if vm.Open(index) && onOpened != nil {
+			onOpened()
+		}
+	}
+
+	entry := widget.NewEntryWithData(vm.Query)
+	entry.SetPlaceHolder("Search stars: words, lang:go, topic:cli, owner:golang")
+	entry.OnSubmitted = func(string) { open(0) }
+	vm.Query.AddListener(binding.NewDataListener(vm.Search))
+
+	list := widget.NewListWithData(vm.Matches, func() fyne.CanvasObject {
+		name := widget.NewLabel("")
+		name.Wrapping = fyne.TextTruncate
+		lang := widget.NewLabel("")
+		lang.Importance = widget.LowImportance
+		return container.NewBorder(nil, nil, nil, lang, name)
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[domain.Repo])
+		if !ok {
+			return
+		}
+		repo, err := item.Get()
+		if err != nil {
+			return
+		}
+		row := obj.(*fyne.Container)
+		row.Objects[0].(*widget.Label).SetText(repo.FullName)
+		row.Objects[1].(*widget.Label).SetText(repo.Language)
+	})
+	list.OnSelected = func(id widget.ListItemID) {
+		list.UnselectAll()
+		open(id)
+	}
+
+	status := widget.NewLabelWithData(vm.Status)
+	status.Importance = widget.LowImportance
+
+	w.Canvas().Focus(entry)
+	return container.NewBorder(entry, status, nil, nil, list)
+}
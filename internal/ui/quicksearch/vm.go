@@ -0,0 +1,74 @@
+package quicksearch
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+)
+
+const maxMatches = 20
+
+// SearchFunc returns up to limit repos matching query.
+type SearchFunc func(query string, limit int) []domain.Repo
+
+type VM struct {
+	Query   binding.String
+	Matches binding.List[domain.Repo]
+	Status  binding.String
+
+	search    SearchFunc
+	open      func(fullName string)
+	runOnMain func(func())
+}
+
+// NewVM searches with search and hands the chosen repo to open.
+func NewVM(search SearchFunc, open func(fullName string), runOnMain func(func())) *VM {
+	vm := &VM{
+		Query:     binding.NewString(),
+		Matches:   binding.NewList(func(a, b domain.Repo) bool { return a.FullName == b.FullName }),
+		Status:    binding.NewString(),
+		search:    search,
+		open:      open,
+		runOnMain: runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	_ = vm.Status.Set("Type to search your stars")
+	return vm
+}
+
+// Search lists the repos matching Query; an empty query lists nothing.
+func (vm *VM) Search() {
+	query, _ := vm.Query.Get()
+	var matches []domain.Repo
+	if strings.TrimSpace(query) != "" {
+		matches = vm.search(query, maxMatches)
+	}
+	vm.runOnMain(func() {
+		_ = vm.Matches.Set(matches)
+		switch {
+		case strings.TrimSpace(query) == "":
+			_ = vm.Status.Set("Type to search your stars")
+		case len(matches) == 0:
+			_ = vm.Status.Set("No matches; load your stars first if the list is empty")
+		case len(matches) == maxMatches:
+			_ = vm.Status.Set(fmt.Sprintf("First %d matches", maxMatches))
+		default:
+			_ = vm.Status.Set(fmt.Sprintf("%d match(es)", len(matches)))
+		}
+	})
+}
+
+// Open opens the match at index and reports whether there was one.
+func (vm *VM) Open(index int) bool {
+	repo, err := vm.Matches.GetValue(index)
+	if err != nil {
+		return false
+	}
+	vm.open(repo.FullName)
+	return true
+}
@@ -0,0 +1,57 @@
+package quicksearch_test
+
+import (
+	"strings"
+	"testing"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/quicksearch"
+)
+
+func search(query string, limit int) []domain.Repo {
+	var matches []domain.Repo
+	for _, repo := range testdata.SampleRepoList() {
+		if strings.Contains(repo.FullName, query) && len(matches) < limit {
+			matches = append(matches, repo)
+		}
+	}
+	return matches
+}
+
+func TestVM_SearchAndOpen(t *testing.T) {
+	_ = test.NewApp()
+	var opened []string
+	vm := quicksearch.NewVM(search, func(fullName string) { opened = append(opened, fullName) }, func(f func()) { f() })
+
+	_ = vm.Query.Set("o")
+	vm.Search()
+	matches, _ := vm.Matches.Get()
+	testutil.AssertTrue(t, len(matches) > 1, "query should match several repos")
+
+	_ = vm.Query.Set("vscode")
+	vm.Search()
+	testutil.AssertTrue(t, vm.Open(0), "first match should open")
+	testutil.AssertFalse(t, vm.Open(1), "there is no second match")
+	testutil.AssertEqual(t, 1, len(opened))
+	testutil.AssertEqual(t, "microsoft/vscode", opened[0])
+}
+
+func TestVM_EmptyQueryListsNothing(t *testing.T) {
+	_ = test.NewApp()
+	vm := quicksearch.NewVM(func(string, int) []domain.Repo {
+		t.Fatal("empty query should not search")
+		return nil
+	}, func(string) {}, func(f func()) { f() })
+
+	_ = vm.Query.Set("   ")
+	vm.Search()
+
+	matches, _ := vm.Matches.Get()
+	testutil.AssertEqual(t, 0, len(matches))
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "Type to search your stars", status)
+}
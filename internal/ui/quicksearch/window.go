@@ -0,0 +1,15 @@
+package quicksearch
+
+import (
+	"fyne.io/fyne/v2"
+)
+
+func NewQuickSearchWindow(app fyne.App, search SearchFunc, open func(fullName string)) fyne.Window {
+	w := app.NewWindow("Quick Search")
+	w.Resize(fyne.NewSize(500, 400))
+
+	vm := NewVM(search, open, fyne.Do)
+	w.SetContent(NewView(w, vm, w.Close))
+
+	return w
+}
@@ -2,4 +2,10 @@ package route
 
 type Router interface {
 	ShowRepoDetails(fullName, token string)
+	ShowChanges(username, token string)
+	ShowUpdates()
+	ShowCompare(usernames []string, token string)
+	ShowTeam(token string)
+	ShowActivity()
+	ShowSettings()
 }
@@ -0,0 +1,33 @@
+package settings
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/theme"
+
+	"github.com/tbxark/gh-stars/internal/app/settings"
+)
+
+// ApplyTheme switches every window of app to t. Call it on the main thread.
+func ApplyTheme(app fyne.App, t settings.Theme) {
+	switch t {
+	case settings.Light:
+		app.Settings().SetTheme(variantTheme{Theme: theme.DefaultTheme(), variant: theme.VariantLight})
+	case settings.Dark:
+		app.Settings().SetTheme(variantTheme{Theme: theme.DefaultTheme(), variant: theme.VariantDark})
+	default:
+		app.Settings().SetTheme(theme.DefaultTheme())
+	}
+}
+
+// variantTheme is the default theme with the light or dark variant forced,
+// whatever the desktop asks for.
+type variantTheme struct {
+	fyne.Theme
+	variant fyne.ThemeVariant
+}
+
+func (t variantTheme) Color(name fyne.ThemeColorName, _ fyne.ThemeVariant) color.Color {
+	return t.Theme.Color(name, t.variant)
+}
@@ -0,0 +1,156 @@
+package settings
+
+import (
+	"slices"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/settings"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+func NewView(vm *VM) fyne.CanvasObject {
+	title := canvas.NewText("Settings", theme.ForegroundColor())
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = theme.TextHeadingSize()
+
+	subtitle := canvas.NewText("Saved settings apply right away, except the cache location.", theme.DisabledColor())
+	subtitle.TextSize = theme.TextSubHeadingSize()
+
+	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), vm.Save)
+	saveBtn.Importance = widget.HighImportance
+	defaultsBtn := widget.NewButtonWithIcon("Restore Defaults", theme.ContentUndoIcon(), vm.RestoreDefaults)
+	actionBar := container.NewHBox(layout.NewSpacer(), defaultsBtn, saveBtn)
+	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+
+	themeLabels := make([]string, len(settings.Themes))
+	for i, t := range settings.Themes {
+		themeLabels[i] = t.String()
+	}
+	themeSelect := newChoice(themeLabels, vm.Theme, func() int {
+		value, _ := vm.Theme.Get()
+		return slices.Index(settings.Themes, settings.Theme(value))
+	}, func(i int) { _ = vm.Theme.Set(string(settings.Themes[i])) })
+
+	layoutLabels := make([]string, len(viewmode.Modes))
+	for i, mode := range viewmode.Modes {
+		layoutLabels[i] = mode.String()
+	}
+	layoutSelect := newChoice(layoutLabels, vm.Layout, func() int {
+		value, _ := vm.Layout.Get()
+		return slices.Index(viewmode.Modes, viewmode.Mode(value))
+	}, func(i int) { _ = vm.Layout.Set(string(viewmode.Modes[i])) })
+
+	sortLabels := make([]string, len(stars.SortKeys))
+	for i, key := range stars.SortKeys {
+		sortLabels[i] = key.String()
+	}
+	sortSelect := newChoice(sortLabels, vm.DefaultSort, func() int {
+		index, _ := vm.DefaultSort.Get()
+		return index
+	}, func(i int) { _ = vm.DefaultSort.Set(i) })
+
+	perPage := widget.NewEntryWithData(vm.PerPage)
+	perPage.SetPlaceHolder("1-100, used when a profile sets none")
+	timeout := widget.NewEntryWithData(vm.Timeout)
+	timeout.SetPlaceHolder("Seconds per API request")
+	baseURL := widget.NewEntryWithData(vm.BaseURL)
+	baseURL.SetPlaceHolder("https://api.github.com, or https://HOST/api/v3 for Enterprise")
+	proxy := widget.NewEntryWithData(vm.Proxy)
+	proxy.SetPlaceHolder("http://proxy:3128 or socks5://host:1080; empty uses HTTPS_PROXY")
+
+	appearance := widget.NewForm(
+		widget.NewFormItem("Theme", themeSelect),
+		widget.NewFormItem("Details layout", layoutSelect),
+		widget.NewFormItem("Default sort", sortSelect),
+	)
+	network := widget.NewForm(
+		widget.NewFormItem("Per page", perPage),
+		widget.NewFormItem("Request timeout", timeout),
+		widget.NewFormItem("API base URL", baseURL),
+		widget.NewFormItem("Proxy", proxy),
+	)
+
+	cacheDir := widget.NewEntryWithData(vm.CacheDir)
+	cacheDir.SetPlaceHolder(vm.cache.Dir)
+	cacheTTL := widget.NewEntryWithData(vm.CacheTTL)
+	cacheTTL.SetPlaceHolder("Hours before cached details are fetched again")
+	cacheSize := widget.NewLabelWithData(vm.CacheSize)
+	clearBtn := widget.NewButtonWithIcon("Clear Cache", theme.DeleteIcon(), vm.ClearCache)
+	if !vm.CanClearCache() {
+		clearBtn.Disable()
+	}
+	vm.Clearing.AddListener(binding.NewDataListener(func() {
+		clearing, _ := vm.Clearing.Get()
+		if clearing || !vm.CanClearCache() {
+			clearBtn.Disable()
+		} else {
+			clearBtn.Enable()
+		}
+	}))
+	cache := widget.NewForm(
+		widget.NewFormItem("Location", cacheDir),
+		widget.NewFormItem("Refresh after", cacheTTL),
+		widget.NewFormItem("Size", container.NewBorder(nil, nil, nil, clearBtn, cacheSize)),
+	)
+
+	body := container.NewVBox(
+		widget.NewCard("Appearance", "", appearance),
+		widget.NewCard("Network", "", network),
+		widget.NewCard("Cache", "Repo details and enrichment results, fetched again when cleared.", cache),
+	)
+	if vm.CanEditShortcuts() {
+		body.Add(widget.NewCard("Keyboard Shortcuts", "Combine Ctrl, Alt, Shift or Super with a key, for example Ctrl+Shift+K.", newShortcutsForm(vm)))
+	}
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Clearing)
+
+	top := container.NewVBox(header, widget.NewSeparator())
+	return container.NewBorder(
+		container.NewPadded(top),
+		container.NewPadded(statusBar),
+		nil,
+		nil,
+		container.NewVScroll(container.NewPadded(body)),
+	)
+}
+
+// newShortcutsForm has an entry per keymap action. An empty entry shows, and
+// saves, the default keys.
+func newShortcutsForm(vm *VM) *widget.Form {
+	form := widget.NewForm()
+	for _, b := range keymap.Defaults {
+		entry := widget.NewEntryWithData(vm.Shortcuts[b.Action])
+		entry.SetPlaceHolder(b.Keys)
+		form.Append(b.Title, entry)
+	}
+	return form
+}
+
+// newChoice is a select over labels that follows data; index reads the
+// position data holds and set stores a picked one.
+func newChoice(labels []string, data binding.DataItem, index func() int, set func(int)) *widget.Select {
+	choice := widget.NewSelect(labels, nil)
+	syncing := false
+	choice.OnChanged = func(string) {
+		if !syncing {
+			set(choice.SelectedIndex())
+		}
+	}
+	data.AddListener(binding.NewDataListener(func() {
+		syncing = true
+		if i := index(); i >= 0 {
+			choice.SetSelectedIndex(i)
+		}
+		syncing = false
+	}))
+	return choice
+}
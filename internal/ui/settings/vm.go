@@ -0,0 +1,253 @@
+package settings
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/settings"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+)
+
+// Cache is the on-disk cache the window reports on and clears.
+type Cache struct {
+	// Dir is the cache dir in use, which can differ from the saved one
+	// until the next launch.
+	Dir   string
+	Clear func() error
+}
+
+type VM struct {
+	Theme       binding.String
+	DefaultSort binding.Int
+	PerPage     binding.String
+	// Timeout is in seconds.
+	Timeout binding.String
+	// CacheTTL is in hours.
+	CacheTTL binding.String
+	CacheDir binding.String
+	BaseURL  binding.String
+	Proxy    binding.String
+	Layout   binding.String
+	// Shortcuts holds the keys of each keymap action; an empty value
+	// stands for the default.
+	Shortcuts map[keymap.Action]binding.String
+
+	CacheSize binding.String
+	Clearing  binding.Bool
+	Status    binding.String
+	Error     binding.String
+
+	store     *settings.Store
+	layout    *viewmode.Store
+	keys      *keymap.Store
+	cache     Cache
+	runOnMain func(func())
+}
+
+func NewVM(store *settings.Store, layout *viewmode.Store, keys *keymap.Store, cache Cache, runOnMain func(func())) *VM {
+	vm := &VM{
+		Theme:       binding.NewString(),
+		DefaultSort: binding.NewInt(),
+		PerPage:     binding.NewString(),
+		Timeout:     binding.NewString(),
+		CacheTTL:    binding.NewString(),
+		CacheDir:    binding.NewString(),
+		BaseURL:     binding.NewString(),
+		Proxy:       binding.NewString(),
+		Layout:      binding.NewString(),
+		Shortcuts:   make(map[keymap.Action]binding.String, len(keymap.Defaults)),
+		CacheSize:   binding.NewString(),
+		Clearing:    binding.NewBool(),
+		Status:      binding.NewString(),
+		Error:       binding.NewString(),
+		store:       store,
+		layout:      layout,
+		keys:        keys,
+		cache:       cache,
+		runOnMain:   runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	for _, b := range keymap.Defaults {
+		vm.Shortcuts[b.Action] = binding.NewString()
+	}
+	_ = vm.Status.Set("Ready")
+	return vm
+}
+
+// Load fills the form with the saved settings and measures the cache.
+func (vm *VM) Load() {
+	vm.show(vm.store.Load())
+	_ = vm.Layout.Set(string(vm.layout.Mode()))
+	for _, b := range vm.keys.Bindings() {
+		_ = vm.Shortcuts[b.Action].Set(b.Keys)
+	}
+	vm.RefreshCacheSize()
+}
+
+// RestoreDefaults fills the form with the defaults; Save applies them.
+func (vm *VM) RestoreDefaults() {
+	vm.show(settings.Defaults())
+	_ = vm.Layout.Set(string(viewmode.Windows))
+	for _, b := range keymap.Defaults {
+		_ = vm.Shortcuts[b.Action].Set(b.Keys)
+	}
+	_ = vm.Status.Set("Defaults restored; save to apply them")
+}
+
+func (vm *VM) show(s settings.Settings) {
+	_ = vm.Theme.Set(string(s.Theme))
+	_ = vm.DefaultSort.Set(s.DefaultSort)
+	_ = vm.PerPage.Set(strconv.Itoa(s.PerPage))
+	_ = vm.Timeout.Set(strconv.Itoa(int(s.Timeout / time.Second)))
+	_ = vm.CacheTTL.Set(strconv.Itoa(int(s.CacheTTL / time.Hour)))
+	_ = vm.CacheDir.Set(s.CacheDir)
+	_ = vm.BaseURL.Set(s.BaseURL)
+	_ = vm.Proxy.Set(s.Proxy)
+}
+
+// Save validates and stores the form, which applies it. The cache location
+// only changes on the next launch.
+func (vm *VM) Save() {
+	s, err := vm.read()
+	if err == nil {
+		err = s.Validate()
+	}
+	if err == nil && vm.CanEditShortcuts() {
+		err = vm.keys.SetAll(vm.readShortcuts())
+	}
+	if err == nil {
+		err = vm.store.Save(s)
+	}
+	if err != nil {
+		_ = vm.Error.Set(err.Error())
+		return
+	}
+	mode, _ := vm.Layout.Get()
+	vm.layout.SetMode(viewmode.Mode(mode))
+
+	_ = vm.Error.Set("")
+	if s.CacheRoot() != vm.cache.Dir {
+		_ = vm.Status.Set("Saved; the new cache location is used after a restart")
+		return
+	}
+	_ = vm.Status.Set("Saved")
+}
+
+func (vm *VM) read() (settings.Settings, error) {
+	theme, _ := vm.Theme.Get()
+	sort, _ := vm.DefaultSort.Get()
+	perPageStr, _ := vm.PerPage.Get()
+	timeoutStr, _ := vm.Timeout.Get()
+	ttlStr, _ := vm.CacheTTL.Get()
+	cacheDir, _ := vm.CacheDir.Get()
+	baseURL, _ := vm.BaseURL.Get()
+	proxy, _ := vm.Proxy.Get()
+
+	perPage, err := strconv.Atoi(strings.TrimSpace(perPageStr))
+	if err != nil {
+		return settings.Settings{}, errors.New("per page must be a number")
+	}
+	seconds, err := strconv.Atoi(strings.TrimSpace(timeoutStr))
+	if err != nil {
+		return settings.Settings{}, errors.New("timeout must be a number of seconds")
+	}
+	hours, err := strconv.Atoi(strings.TrimSpace(ttlStr))
+	if err != nil {
+		return settings.Settings{}, errors.New("cache lifetime must be a number of hours")
+	}
+	return settings.Settings{
+		Theme:       settings.Theme(theme),
+		DefaultSort: sort,
+		PerPage:     perPage,
+		Timeout:     time.Duration(seconds) * time.Second,
+		CacheTTL:    time.Duration(hours) * time.Hour,
+		CacheDir:    strings.TrimSpace(cacheDir),
+		BaseURL:     strings.TrimSpace(baseURL),
+		Proxy:       strings.TrimSpace(proxy),
+	}, nil
+}
+
+func (vm *VM) readShortcuts() map[keymap.Action]string {
+	keys := make(map[keymap.Action]string, len(vm.Shortcuts))
+	for action, data := range vm.Shortcuts {
+		keys[action], _ = data.Get()
+	}
+	return keys
+}
+
+// CanEditShortcuts reports whether there is a keymap to rebind.
+func (vm *VM) CanEditShortcuts() bool {
+	return vm.keys != nil
+}
+
+// RefreshCacheSize measures the cache dir in the background.
+func (vm *VM) RefreshCacheSize() {
+	dir := vm.cache.Dir
+	if dir == "" {
+		_ = vm.CacheSize.Set("in memory only")
+		return
+	}
+	go func() {
+		size, err := settings.DirSize(dir)
+		vm.runOnMain(func() {
+			if err != nil {
+				_ = vm.CacheSize.Set("unknown")
+				return
+			}
+			_ = vm.CacheSize.Set(formatSize(size))
+		})
+	}()
+}
+
+// ClearCache empties the cache in the background. Cached details are
+// fetched again when next shown.
+func (vm *VM) ClearCache() {
+	if vm.cache.Clear == nil {
+		return
+	}
+	_ = vm.Clearing.Set(true)
+	go func() {
+		err := vm.cache.Clear()
+		size, sizeErr := settings.DirSize(vm.cache.Dir)
+		vm.runOnMain(func() {
+			_ = vm.Clearing.Set(false)
+			if sizeErr == nil {
+				_ = vm.CacheSize.Set(formatSize(size))
+			}
+			if err != nil {
+				_ = vm.Error.Set("could not clear the cache: " + err.Error())
+				return
+			}
+			_ = vm.Error.Set("")
+			_ = vm.Status.Set("Cache cleared")
+		})
+	}()
+}
+
+// CanClearCache reports whether there is a cache to clear.
+func (vm *VM) CanClearCache() bool {
+	return vm.cache.Clear != nil
+}
+
+func formatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	value, suffix := float64(bytes)/unit, "KB"
+	for _, next := range []string{"MB", "GB"} {
+		if value < unit {
+			break
+		}
+		value, suffix = value/unit, next
+	}
+	return fmt.Sprintf("%.1f %s", value, suffix)
+}
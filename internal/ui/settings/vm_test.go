@@ -0,0 +1,145 @@
+package settings_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/settings"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	uisettings "github.com/tbxark/gh-stars/internal/ui/settings"
+)
+
+// onMain runs background results in order and signals each one, so tests
+// can wait for them.
+func onMain(done chan<- struct{}) func(func()) {
+	return func(f func()) {
+		f()
+		done <- struct{}{}
+	}
+}
+
+func wait(t *testing.T, done <-chan struct{}) {
+	t.Helper()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("background work did not finish")
+	}
+}
+
+func TestVM_SaveAppliesSettings(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	store := settings.NewStore(app.Preferences())
+	layout := viewmode.NewStore(app.Preferences())
+	var applied []settings.Settings
+	store.Subscribe(func(s settings.Settings) { applied = append(applied, s) })
+
+	done := make(chan struct{}, 4)
+	vm := uisettings.NewVM(store, layout, nil, uisettings.Cache{Dir: t.TempDir()}, onMain(done))
+	vm.Load()
+	wait(t, done)
+	perPage, _ := vm.PerPage.Get()
+	testutil.AssertEqual(t, "100", perPage)
+	size, _ := vm.CacheSize.Get()
+	testutil.AssertEqual(t, "0 B", size)
+
+	_ = vm.PerPage.Set("many")
+	vm.Save()
+	errMsg, _ := vm.Error.Get()
+	testutil.AssertEqual(t, "per page must be a number", errMsg)
+	testutil.AssertEqual(t, 0, len(applied))
+
+	_ = vm.PerPage.Set("50")
+	_ = vm.Timeout.Set("60")
+	_ = vm.CacheTTL.Set("24")
+	_ = vm.Theme.Set(string(settings.Dark))
+	_ = vm.Layout.Set(string(viewmode.Tabs))
+	vm.Save()
+	errMsg, _ = vm.Error.Get()
+	testutil.AssertEqual(t, "", errMsg)
+	testutil.AssertEqual(t, 1, len(applied))
+	testutil.AssertEqual(t, 50, store.Load().PerPage)
+	testutil.AssertEqual(t, time.Minute, store.Load().Timeout)
+	testutil.AssertEqual(t, 24*time.Hour, store.Load().CacheTTL)
+	testutil.AssertEqual(t, settings.Dark, store.Load().Theme)
+	testutil.AssertEqual(t, viewmode.Tabs, layout.Mode())
+
+	_ = vm.CacheDir.Set(filepath.Join(t.TempDir(), "elsewhere"))
+	vm.Save()
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "Saved; the new cache location is used after a restart", status)
+
+	vm.RestoreDefaults()
+	perPage, _ = vm.PerPage.Get()
+	testutil.AssertEqual(t, "100", perPage)
+	testutil.AssertEqual(t, 50, store.Load().PerPage)
+}
+
+func TestVM_ClearCache(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	dir := t.TempDir()
+	testutil.AssertNoError(t, os.WriteFile(filepath.Join(dir, "enriched.json"), make([]byte, 2048), 0o644))
+
+	done := make(chan struct{}, 4)
+	cache := uisettings.Cache{Dir: dir, Clear: func() error { return os.Remove(filepath.Join(dir, "enriched.json")) }}
+	vm := uisettings.NewVM(settings.NewStore(app.Preferences()), nil, nil, cache, onMain(done))
+	vm.Load()
+	wait(t, done)
+	size, _ := vm.CacheSize.Get()
+	testutil.AssertEqual(t, "2.0 KB", size)
+
+	vm.ClearCache()
+	wait(t, done)
+	size, _ = vm.CacheSize.Get()
+	testutil.AssertEqual(t, "0 B", size)
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "Cache cleared", status)
+}
+
+func TestVM_SaveRebindsShortcuts(t *testing.T) {
+	app := test.NewApp()
+	defer app.Quit()
+	store := settings.NewStore(app.Preferences())
+	keys := keymap.NewStore(testutil.NewPrefs())
+	changes := 0
+	keys.Subscribe(func() { changes++ })
+
+	done := make(chan struct{}, 4)
+	vm := uisettings.NewVM(store, nil, keys, uisettings.Cache{}, onMain(done))
+	vm.Load()
+	reload, _ := vm.Shortcuts[keymap.Reload].Get()
+	testutil.AssertEqual(t, "Ctrl+R", reload)
+
+	_ = vm.Shortcuts[keymap.Reload].Set("Ctrl+F")
+	vm.Save()
+	errMsg, _ := vm.Error.Get()
+	testutil.AssertEqual(t, "Ctrl+F is bound to both Focus Search and Reload Stars", errMsg)
+	testutil.AssertEqual(t, 0, changes)
+
+	_ = vm.Shortcuts[keymap.FocusSearch].Set("Ctrl+R")
+	vm.Save()
+	errMsg, _ = vm.Error.Get()
+	testutil.AssertEqual(t, "", errMsg)
+	testutil.AssertEqual(t, 1, changes)
+	testutil.AssertEqual(t, "Ctrl+R", keys.Keys(keymap.FocusSearch))
+	testutil.AssertEqual(t, "Ctrl+F", keys.Keys(keymap.Reload))
+
+	_ = vm.PerPage.Set("500")
+	_ = vm.Shortcuts[keymap.Reload].Set("F5")
+	vm.Save()
+	testutil.AssertEqual(t, "Ctrl+F", keys.Keys(keymap.Reload))
+
+	vm.RestoreDefaults()
+	_ = vm.Shortcuts[keymap.FocusSearch].Set("")
+	vm.Save()
+	testutil.AssertEqual(t, "Ctrl+F", keys.Keys(keymap.FocusSearch))
+	testutil.AssertEqual(t, "Ctrl+R", keys.Keys(keymap.Reload))
+}
@@ -0,0 +1,20 @@
+package settings
+
+import (
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/settings"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
+)
+
+func NewSettingsWindow(app fyne.App, store *settings.Store, layout *viewmode.Store, keys *keymap.Store, cache Cache) fyne.Window {
+	w := app.NewWindow("Settings")
+	w.Resize(fyne.NewSize(640, 620))
+
+	vm := NewVM(store, layout, keys, cache, fyne.Do)
+	w.SetContent(NewView(vm))
+	vm.Load()
+
+	return w
+}
@@ -12,90 +12,134 @@ import (
 	"github.com/tbxark/gh-stars/internal/domain"
 )
 
-func NewRepoList(vm *VM, onOpen func(domain.Repo)) fyne.CanvasObject {
-	headers := container.NewGridWithColumns(5,
+// newRepoList lays out the repo list. Clicking a row selects and opens it;
+// the arrow keys only move the selection, which follows vm.Selected.
+func newRepoList(vm *VM, onOpen func(domain.Repo)) (fyne.CanvasObject, *keyedList) {
+	columns := []fyne.CanvasObject{
 		headerLabel("Name", fyne.TextAlignLeading),
 		headerLabel("Description", fyne.TextAlignLeading),
 		headerLabel("Language", fyne.TextAlignLeading),
 		headerLabel("Stars", fyne.TextAlignTrailing),
-		headerLabel("Updated", fyne.TextAlignTrailing),
-	)
+	}
+	if vm.HasTrends() {
+		columns = append(columns, headerLabel("Δ Stars / 30d", fyne.TextAlignTrailing))
+	}
+	columns = append(columns, headerLabel("Updated", fyne.TextAlignTrailing))
+	headers := container.NewGridWithColumns(len(columns), columns...)
 
-	list := widget.NewListWithData(vm.Repos, func() fyne.CanvasObject {
-		return newRepoRowWidget()
-	}, func(di binding.DataItem, obj fyne.CanvasObject) {
-		repo, err := repoFromItem(di)
-		if err != nil {
+	list := &keyedList{}
+	list.Length = vm.Len
+	list.CreateItem = func() fyne.CanvasObject {
+		return newRepoRowWidget(vm.HasTrends())
+	}
+	list.UpdateItem = func(id widget.ListItemID, obj fyne.CanvasObject) {
+		repo, ok := vm.RepoAt(id)
+		if !ok {
 			return
 		}
-		updateRepoRow(obj, repo)
-	})
+		updateRepoRow(obj, repo, vm.StarDelta(repo))
+	}
+	list.ExtendBaseWidget(list)
+	// Only the rows on screen are rebuilt, however long the list is.
+	vm.ListVersion.AddListener(binding.NewDataListener(func() {
+		list.UnselectAll()
+		list.Refresh()
+	}))
 
+	following := false
+	vm.Selected.AddListener(binding.NewDataListener(func() {
+		selected, _ := vm.Selected.Get()
+		following = true
+		if selected < 0 {
+			list.UnselectAll()
+		} else {
+			list.Select(selected)
+			list.ScrollTo(selected)
+		}
+		following = false
+	}))
 	list.OnSelected = func(id widget.ListItemID) {
+		if following {
+			return
+		}
+		vm.Select(id)
 		repo, ok := vm.RepoAt(id)
 		if ok && onOpen != nil {
 			onOpen(repo)
 		}
-		list.Unselect(id)
 	}
 
 	header := container.NewVBox(headers, widget.NewSeparator())
-	return container.NewBorder(header, nil, nil, nil, list)
+	return container.NewBorder(header, nil, nil, nil, list), list
 }
 
 func headerLabel(text string, align fyne.TextAlign) *widget.Label {
 	return widget.NewLabelWithStyle(text, align, fyne.TextStyle{Bold: true})
 }
 
-func updateRepoRow(obj fyne.CanvasObject, repo domain.Repo) {
+func updateRepoRow(obj fyne.CanvasObject, repo domain.Repo, delta string) {
 	row, ok := obj.(*repoRowWidget)
 	if !ok {
 		return
 	}
 	row.name.SetText(repo.FullName)
+	if repo.Private {
+		row.private.Show()
+	} else {
+		row.private.Hide()
+	}
 	row.desc.SetText(valueOrDash(repo.Description))
 	row.lang.SetText(valueOrDash(repo.Language))
 	row.stars.SetText(fmt.Sprintf("%d", repo.Stars))
+	row.delta.SetText(delta)
 	row.updated.SetText(formatDate(repo.UpdatedAt))
 }
 
 type repoRowWidget struct {
 	widget.BaseWidget
 	name    *widget.Label
+	private *widget.Label
 	desc    *widget.Label
 	lang    *widget.Label
 	stars   *widget.Label
+	delta   *widget.Label
 	updated *widget.Label
+
+	showDelta bool
 }
 
-func newRepoRowWidget() *repoRowWidget {
+func newRepoRowWidget(showDelta bool) *repoRowWidget {
 	row := &repoRowWidget{
-		name:    widget.NewLabel(""),
-		desc:    widget.NewLabel(""),
-		lang:    widget.NewLabel(""),
-		stars:   widget.NewLabel(""),
-		updated: widget.NewLabel(""),
+		name:      widget.NewLabel(""),
+		private:   widget.NewLabelWithStyle("Private", fyne.TextAlignTrailing, fyne.TextStyle{Bold: true}),
+		desc:      widget.NewLabel(""),
+		lang:      widget.NewLabel(""),
+		stars:     widget.NewLabel(""),
+		delta:     widget.NewLabel(""),
+		updated:   widget.NewLabel(""),
+		showDelta: showDelta,
 	}
 	row.name.Wrapping = fyne.TextTruncate
+	row.private.Importance = widget.WarningImportance
+	row.private.Hide()
 	row.desc.Wrapping = fyne.TextTruncate
 	row.lang.Wrapping = fyne.TextTruncate
 	row.stars.Alignment = fyne.TextAlignTrailing
+	row.delta.Alignment = fyne.TextAlignTrailing
 	row.updated.Alignment = fyne.TextAlignTrailing
 	row.ExtendBaseWidget(row)
 	return row
 }
 
 func (row *repoRowWidget) CreateRenderer() fyne.WidgetRenderer {
-	grid := container.NewGridWithColumns(5, row.name, row.desc, row.lang, row.stars, row.updated)
-	return widget.NewSimpleRenderer(grid)
-}
-
-func repoFromItem(di binding.DataItem) (domain.Repo, error) {
-	item, ok := di.(binding.Item[domain.Repo])
-	if !ok {
-		return domain.Repo{}, fmt.Errorf("invalid list item")
+	name := container.NewBorder(nil, nil, nil, row.private, row.name)
+	cells := []fyne.CanvasObject{name, row.desc, row.lang, row.stars}
+	if row.showDelta {
+		cells = append(cells, row.delta)
 	}
-	return item.Get()
+	cells = append(cells, row.updated)
+	grid := container.NewGridWithColumns(len(cells), cells...)
+	return widget.NewSimpleRenderer(grid)
 }
 
 func valueOrDash(value string) string {
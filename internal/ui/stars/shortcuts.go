@@ -0,0 +1,218 @@
+package stars
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/driver/desktop"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+)
+
+// keyBindings runs the keymap actions of the stars window. Combinations with
+// a modifier are canvas shortcuts, which Fyne only delivers while the focused
+// widget does not take shortcuts itself, so the filter entry and the repo list
+// pass them on. Plain keys such as Enter, and Up and Down to move the
+// selection, are handled the same way.
+type keyBindings struct {
+	w       fyne.Window
+	vm      *VM
+	actions map[keymap.Action]func()
+
+	installed []fyne.Shortcut
+	byName    map[string]func()
+	byKey     map[fyne.KeyName]func()
+}
+
+func newKeyBindings(w fyne.Window, vm *VM, actions map[keymap.Action]func()) *keyBindings {
+	kb := &keyBindings{w: w, vm: vm, actions: actions}
+	kb.install()
+	w.Canvas().SetOnTypedKey(func(ev *fyne.KeyEvent) { kb.typedKey(ev) })
+	vm.KeymapVersion.AddListener(binding.NewDataListener(kb.install))
+	return kb
+}
+
+// install (re)registers the current bindings on the window canvas.
+func (kb *keyBindings) install() {
+	c := kb.w.Canvas()
+	for _, s := range kb.installed {
+		c.RemoveShortcut(s)
+	}
+	kb.installed = nil
+	kb.byName = map[string]func(){}
+	kb.byKey = map[fyne.KeyName]func(){}
+
+	for _, b := range kb.vm.Keymap().Bindings() {
+		run := kb.actions[b.Action]
+		parsed, err := keymap.Parse(b.Keys)
+		if run == nil || err != nil {
+			continue
+		}
+		shortcut := toShortcut(parsed)
+		if shortcut == nil {
+			kb.byKey[fyne.KeyName(parsed.Key)] = run
+			continue
+		}
+		kb.byName[shortcut.ShortcutName()] = run
+		c.AddShortcut(shortcut, func(fyne.Shortcut) { run() })
+		kb.installed = append(kb.installed, shortcut)
+	}
+}
+
+// typedShortcut runs the action bound to a shortcut a focused widget passed
+// on. Built-in shortcuts such as Copy are left to the widget.
+func (kb *keyBindings) typedShortcut(s fyne.Shortcut) bool {
+	if _, ok := s.(*desktop.CustomShortcut); !ok {
+		return false
+	}
+	run, ok := kb.byName[s.ShortcutName()]
+	if ok {
+		run()
+	}
+	return ok
+}
+
+func (kb *keyBindings) typedKey(ev *fyne.KeyEvent) bool {
+	name := ev.Name
+	if name == fyne.KeyEnter {
+		name = fyne.KeyReturn
+	}
+	if run, ok := kb.byKey[name]; ok {
+		run()
+		return true
+	}
+	switch name {
+	case fyne.KeyUp:
+		kb.vm.MoveSelection(-1)
+	case fyne.KeyDown:
+		kb.vm.MoveSelection(1)
+	default:
+		return false
+	}
+	return true
+}
+
+// toShortcut converts s for the canvas; nil means s has no modifier and is
+// handled as a typed key. Ctrl+C and friends are reported by Fyne as its
+// built-in shortcuts, so they are matched as those.
+func toShortcut(s keymap.Shortcut) fyne.Shortcut {
+	if !s.HasModifier() {
+		return nil
+	}
+	if s.Ctrl && !s.Shift && !s.Alt && !s.Super {
+		switch fyne.KeyName(s.Key) {
+		case fyne.KeyC:
+			return &fyne.ShortcutCopy{}
+		case fyne.KeyV:
+			return &fyne.ShortcutPaste{}
+		case fyne.KeyX:
+			return &fyne.ShortcutCut{}
+		case fyne.KeyA:
+			return &fyne.ShortcutSelectAll{}
+		case fyne.KeyZ:
+			return &fyne.ShortcutUndo{}
+		case fyne.KeyY:
+			return &fyne.ShortcutRedo{}
+		}
+	}
+	var mod fyne.KeyModifier
+	if s.Ctrl {
+		mod |= fyne.KeyModifierControl
+	}
+	if s.Shift {
+		mod |= fyne.KeyModifierShift
+	}
+	if s.Alt {
+		mod |= fyne.KeyModifierAlt
+	}
+	if s.Super {
+		mod |= fyne.KeyModifierSuper
+	}
+	return &desktop.CustomShortcut{KeyName: fyne.KeyName(s.Key), Modifier: mod}
+}
+
+// keyedEntry is an entry that lets the window's bindings run while it has
+// focus.
+type keyedEntry struct {
+	widget.Entry
+	keys *keyBindings
+}
+
+func newKeyedEntry(data binding.String) *keyedEntry {
+	e := &keyedEntry{}
+	e.ExtendBaseWidget(e)
+	e.Bind(data)
+	return e
+}
+
+func (e *keyedEntry) TypedShortcut(s fyne.Shortcut) {
+	if e.keys != nil && e.keys.typedShortcut(s) {
+		return
+	}
+	e.Entry.TypedShortcut(s)
+}
+
+func (e *keyedEntry) TypedKey(ev *fyne.KeyEvent) {
+	if e.keys != nil && e.keys.typedKey(ev) {
+		return
+	}
+	e.Entry.TypedKey(ev)
+}
+
+// keyedList is a list that moves the VM selection with Up and Down and runs
+// the window's plain-key bindings while it has focus.
+type keyedList struct {
+	widget.List
+	keys *keyBindings
+}
+
+func (l *keyedList) TypedKey(ev *fyne.KeyEvent) {
+	if l.keys != nil && l.keys.typedKey(ev) {
+		return
+	}
+	l.List.TypedKey(ev)
+}
+
+// showShortcutsDialog lets the user rebind the keymap actions. Clearing a
+// field restores its default.
+func showShortcutsDialog(w fyne.Window, store *keymap.Store) {
+	bindings := store.Bindings()
+	entries := make(map[keymap.Action]*widget.Entry, len(bindings))
+	items := make([]*widget.FormItem, len(bindings))
+	for i, b := range bindings {
+		entry := widget.NewEntry()
+		entry.SetText(b.Keys)
+		entry.SetPlaceHolder(keymap.Defaults[i].Keys)
+		entries[b.Action] = entry
+		items[i] = widget.NewFormItem(b.Title, entry)
+	}
+	hint := widget.NewLabel("Combine Ctrl, Alt, Shift or Super with a key, for example Ctrl+Shift+K. Leave a field empty for its default.")
+	hint.Wrapping = fyne.TextWrapWord
+	hint.Importance = widget.LowImportance
+	items = append(items, widget.NewFormItem("", hint))
+
+	d := dialog.NewForm("Keyboard Shortcuts", "Save", "Cancel", items, func(ok bool) {
+		if !ok {
+			return
+		}
+		keys := make(map[keymap.Action]string, len(entries))
+		for action, entry := range entries {
+			keys[action] = entry.Text
+		}
+		if err := store.SetAll(keys); err != nil {
+			dialog.ShowError(err, w)
+		}
+	}, w)
+	d.Resize(fyne.NewSize(480, 0).Max(d.MinSize()))
+	d.Show()
+}
+
+// shortcutHint formats the keys bound to action for a command list.
+func shortcutHint(store *keymap.Store, action keymap.Action) string {
+	s, err := store.Shortcut(action)
+	if err != nil {
+		return ""
+	}
+	return s.String()
+}
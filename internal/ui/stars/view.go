@@ -1,6 +1,9 @@
 package stars
 
 import (
+	"fmt"
+	"time"
+
 	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/canvas"
 	"fyne.io/fyne/v2/container"
@@ -9,14 +12,66 @@ import (
 	"fyne.io/fyne/v2/theme"
 	"fyne.io/fyne/v2/widget"
 
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
+	appstars "github.com/tbxark/gh-stars/internal/app/stars"
 	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/ui/credentials"
+	"github.com/tbxark/gh-stars/internal/ui/palette"
 	"github.com/tbxark/gh-stars/internal/ui/route"
 	"github.com/tbxark/gh-stars/internal/ui/widgets"
 )
 
 func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
+	showChanges := func() {
+		if router == nil {
+			return
+		}
+		username, _ := vm.Username.Get()
+		tokenStr, _ := vm.Token.Get()
+		router.ShowChanges(username, tokenStr)
+	}
+	showUpdates := func() {
+		if router != nil {
+			router.ShowUpdates()
+		}
+	}
+	showActivity := func() {
+		if router != nil {
+			router.ShowActivity()
+		}
+	}
+	showCompare := func() {
+		if router == nil {
+			return
+		}
+		username, _ := vm.Username.Get()
+		tokenStr, _ := vm.Token.Get()
+		var usernames []string
+		if username != "" {
+			usernames = append(usernames, username)
+		}
+		router.ShowCompare(usernames, tokenStr)
+	}
+	showSettings := func() {
+		if router != nil {
+			router.ShowSettings()
+		}
+	}
+	showTeam := func() {
+		if router != nil {
+			tokenStr, _ := vm.Token.Get()
+			router.ShowTeam(tokenStr)
+		}
+	}
+
 	loadBtn := widget.NewButtonWithIcon("Load Stars", theme.DownloadIcon(), vm.Load)
 	clearBtn := widget.NewButtonWithIcon("Clear", theme.ContentClearIcon(), vm.Clear)
+	changesBtn := widget.NewButtonWithIcon("Changes", theme.HistoryIcon(), showChanges)
+	updatesBtn := widget.NewButtonWithIcon("Updates", theme.MailComposeIcon(), showUpdates)
+	activityBtn := widget.NewButtonWithIcon("Activity", theme.ListIcon(), showActivity)
+	compareBtn := widget.NewButtonWithIcon("Compare", theme.AccountIcon(), showCompare)
+	teamBtn := widget.NewButtonWithIcon("Team", theme.GridIcon(), showTeam)
 
 	vm.Loading.AddListener(binding.NewDataListener(func() {
 		loading, _ := vm.Loading.Get()
@@ -27,13 +82,14 @@ func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
 		}
 	}))
 
-	form := widgets.NewCredentialsForm(vm.Username, vm.Token, vm.PerPage)
-	credentialsCard := widget.NewCard(
-		"",
-		"Token is optional but improves rate limits.",
-		form,
-	)
-	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Loading)
+	credentialsCard := credentials.NewCard(w, vm.Credentials)
+	var statusExtra []fyne.CanvasObject
+	if vm.HasScheduler() {
+		autoRefresh := widget.NewLabelWithData(vm.AutoRefresh)
+		autoRefresh.Importance = widget.LowImportance
+		statusExtra = append(statusExtra, autoRefresh)
+	}
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Loading, statusExtra...)
 
 	title := canvas.NewText("GitHub Stars", theme.ForegroundColor())
 	title.TextStyle = fyne.TextStyle{Bold: true}
@@ -42,8 +98,20 @@ func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
 	subtitle := canvas.NewText("Browse and open your starred repositories.", theme.DisabledColor())
 	subtitle.TextSize = theme.TextSubHeadingSize()
 
-	actionBar := container.NewHBox(layout.NewSpacer(), loadBtn, clearBtn)
-	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+	actions := []fyne.CanvasObject{layout.NewSpacer(), loadBtn}
+	if vm.CanEnrich() {
+		actions = append(actions, newEnrichButton(vm))
+	}
+	actions = append(actions, changesBtn, updatesBtn, compareBtn, teamBtn, activityBtn, clearBtn)
+	actionBar := container.NewHBox(actions...)
+	headerText := container.NewVBox(title, subtitle)
+	if vm.Credentials.HasProfiles() {
+		headerText.Add(credentials.NewProfileBar(w, vm.Credentials))
+	}
+	if vm.HasScheduler() {
+		headerText.Add(newScheduleBar(vm))
+	}
+	header := container.NewBorder(nil, nil, nil, actionBar, headerText)
 
 	onOpen := func(repo domain.Repo) {
 		if router == nil {
@@ -53,13 +121,115 @@ func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
 		router.ShowRepoDetails(repo.FullName, tokenStr)
 	}
 
-	list := NewRepoList(vm, onOpen)
+	filter := newKeyedEntry(vm.Query)
+	filter.SetPlaceHolder("Filter: words, lang:go, license:mit, topic:cli, owner:golang, is:private")
+	vm.Query.AddListener(binding.NewDataListener(vm.ApplyFilter))
+
+	// syncing is set while the widgets follow the VM, so a restored sort
+	// or privacy setting is not applied twice.
+	syncing := false
+	hidePrivate := widget.NewCheck("Hide private", func(hide bool) {
+		if !syncing {
+			vm.SetHidePrivate(hide)
+		}
+	})
+	vm.HidePrivate.AddListener(binding.NewDataListener(func() {
+		hide, _ := vm.HidePrivate.Get()
+		syncing = true
+		hidePrivate.SetChecked(hide)
+		syncing = false
+	}))
+	sortOptions := make([]string, len(appstars.SortKeys))
+	for i, key := range appstars.SortKeys {
+		sortOptions[i] = "Sort: " + key.String()
+	}
+	sortSelect := widget.NewSelect(sortOptions, nil)
+	sortSelect.OnChanged = func(string) {
+		if !syncing {
+			vm.SetSort(sortSelect.SelectedIndex())
+		}
+	}
+	vm.Sort.AddListener(binding.NewDataListener(func() {
+		index, _ := vm.Sort.Get()
+		syncing = true
+		sortSelect.SetSelectedIndex(index)
+		syncing = false
+	}))
+
+	list, repoList := newRepoList(vm, onOpen)
+	listTop := container.NewVBox(container.NewBorder(nil, nil, nil, container.NewHBox(sortSelect, hidePrivate), filter))
+	if vm.CanEnrich() {
+		listTop.Add(newEnrichProgress(vm))
+	}
 	listCard := widget.NewCard(
 		"",
 		"Select a repo to open details.",
-		list,
+		container.NewBorder(listTop, nil, nil, nil, list),
 	)
 
+	reload := func() {
+		if loading, _ := vm.Loading.Get(); !loading {
+			vm.Load()
+		}
+	}
+	openSelected := func() {
+		if repo, ok := vm.SelectedRepo(); ok {
+			onOpen(repo)
+		}
+	}
+	openInBrowser := func() {
+		vm.OpenSelectedInBrowser(fyne.CurrentApp().OpenURL)
+	}
+	copyURL := func() {
+		vm.CopySelectedURL(w.Clipboard().SetContent)
+	}
+	var showPalette func()
+	keys := newKeyBindings(w, vm, map[keymap.Action]func(){
+		keymap.FocusSearch:    func() { w.Canvas().Focus(filter) },
+		keymap.Reload:         reload,
+		keymap.OpenSelected:   openSelected,
+		keymap.OpenInBrowser:  openInBrowser,
+		keymap.CopyURL:        copyURL,
+		keymap.CommandPalette: func() { showPalette() },
+	})
+	filter.keys = keys
+	repoList.keys = keys
+
+	showPalette = func() {
+		store := vm.Keymap()
+		commands := []palette.Command{
+			{Title: "Focus Search", Keys: shortcutHint(store, keymap.FocusSearch), Run: func() { w.Canvas().Focus(filter) }},
+			{Title: "Reload Stars", Keys: shortcutHint(store, keymap.Reload), Run: reload},
+			{Title: "Open Selected Repo", Keys: shortcutHint(store, keymap.OpenSelected), Run: openSelected},
+			{Title: "Open Selected in Browser", Keys: shortcutHint(store, keymap.OpenInBrowser), Run: openInBrowser},
+			{Title: "Copy Selected Repo URL", Keys: shortcutHint(store, keymap.CopyURL), Run: copyURL},
+			{Title: "Show Changes", Run: showChanges},
+			{Title: "Show Updates", Run: showUpdates},
+			{Title: "Compare Stars", Run: showCompare},
+			{Title: "Team Overview", Run: showTeam},
+			{Title: "Show Activity", Run: showActivity},
+			{Title: "Settings", Run: showSettings},
+			{Title: "Clear List", Run: vm.Clear},
+		}
+		if vm.CanEnrich() {
+			commands = append(commands, palette.Command{Title: "Enrich Repos", Run: vm.Enrich})
+		}
+		if store != nil {
+			commands = append(commands, palette.Command{Title: "Keyboard Shortcuts...", Run: func() { showShortcutsDialog(w, store) }})
+		}
+		palette.Show(w, palette.NewVM(commands, vm.Catalog, func(fullName string) {
+			tokenStr, _ := vm.Token.Get()
+			if router != nil {
+				router.ShowRepoDetails(fullName, tokenStr)
+			}
+		}, fyne.Do))
+	}
+	actionBar.Add(widget.NewButtonWithIcon("Commands", theme.SearchIcon(), showPalette))
+	actionBar.Add(widget.NewButtonWithIcon("", theme.SettingsIcon(), showSettings))
+	vm.KeymapVersion.AddListener(binding.NewDataListener(func() {
+		listCard.SetSubTitle(fmt.Sprintf("Select a repo to open details, or press %s for commands.", shortcutHint(vm.Keymap(), keymap.CommandPalette)))
+	}))
+
 	top := container.NewVBox(header, widget.NewSeparator(), credentialsCard)
 	return container.NewBorder(
 		container.NewPadded(top),
@@ -69,3 +239,79 @@ func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
 		container.NewPadded(listCard),
 	)
 }
+
+// newScheduleBar picks how often the stars list and open details windows
+// refresh on their own.
+func newScheduleBar(vm *VM) fyne.CanvasObject {
+	options := make([]string, len(schedule.Intervals))
+	for i, every := range schedule.Intervals {
+		options[i] = schedule.DescribeInterval(every)
+	}
+	indexOf := func(every time.Duration) int {
+		for i, option := range schedule.Intervals {
+			if option == every {
+				return i
+			}
+		}
+		return 0
+	}
+
+	current := vm.Schedule()
+	starsSelect := widget.NewSelect(options, nil)
+	starsSelect.SetSelectedIndex(indexOf(current.Stars))
+	detailsSelect := widget.NewSelect(options, nil)
+	detailsSelect.SetSelectedIndex(indexOf(current.Details))
+	apply := func(string) {
+		vm.SetSchedule(schedule.Settings{
+			Stars:   schedule.Intervals[max(starsSelect.SelectedIndex(), 0)],
+			Details: schedule.Intervals[max(detailsSelect.SelectedIndex(), 0)],
+		})
+	}
+	starsSelect.OnChanged = apply
+	detailsSelect.OnChanged = apply
+
+	return container.NewHBox(
+		widget.NewLabel("Refresh stars:"), starsSelect,
+		widget.NewLabel("Open repos:"), detailsSelect,
+	)
+}
+
+func newEnrichButton(vm *VM) *widget.Button {
+	btn := widget.NewButtonWithIcon("Enrich", theme.SearchReplaceIcon(), nil)
+	btn.OnTapped = func() {
+		if enriching, _ := vm.Enriching.Get(); enriching {
+			vm.StopEnrich()
+			return
+		}
+		vm.Enrich()
+	}
+	vm.Enriching.AddListener(binding.NewDataListener(func() {
+		enriching, _ := vm.Enriching.Get()
+		if enriching {
+			btn.SetText("Stop")
+			btn.SetIcon(theme.MediaStopIcon())
+		} else {
+			btn.SetText("Enrich")
+			btn.SetIcon(theme.SearchReplaceIcon())
+		}
+	}))
+	return btn
+}
+
+func newEnrichProgress(vm *VM) fyne.CanvasObject {
+	bar := widget.NewProgressBarWithData(vm.EnrichProgress)
+	status := widget.NewLabelWithData(vm.EnrichStatus)
+	status.Importance = widget.LowImportance
+	row := container.NewBorder(nil, nil, nil, status, bar)
+
+	vm.Enriching.AddListener(binding.NewDataListener(func() {
+		enriching, _ := vm.Enriching.Get()
+		text, _ := vm.EnrichStatus.Get()
+		if enriching || text != "" {
+			row.Show()
+		} else {
+			row.Hide()
+		}
+	}))
+	return row
+}
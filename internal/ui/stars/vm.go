@@ -3,18 +3,31 @@ package stars
 import (
 	"context"
 	"errors"
-	"strconv"
+	"fmt"
+	"net/url"
 	"strings"
 	"sync"
 	"time"
 
 	"fyne.io/fyne/v2/data/binding"
 
+	"github.com/tbxark/gh-stars/internal/app/enrich"
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
 	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/trends"
 	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/ui/credentials"
 )
 
 type VM struct {
+	// Credentials is the account the list loads with. Username, Token,
+	// PerPage, Status and Error are its bindings, shared with the list.
+	Credentials *credentials.VM
+
 	Username binding.String
 	Token    binding.String
 	PerPage  binding.String
@@ -23,45 +36,182 @@ type VM struct {
 	Status  binding.String
 	Error   binding.String
 
-	Repos binding.List[domain.Repo]
+	Query binding.String
+	// ListVersion changes whenever the visible repos change; read them with
+	// Len and RepoAt. A plain counter keeps updates O(1) on the main thread,
+	// where a bound list would create and compare one item per repo.
+	ListVersion binding.Int
+	// Selected is the list position picked by click or arrow keys, or -1.
+	// It resets whenever the list changes.
+	Selected binding.Int
+	// Sort is the position in stars.SortKeys the list is ordered by, and
+	// HidePrivate whether private repos are hidden.
+	Sort        binding.Int
+	HidePrivate binding.Bool
+
+	Enriching      binding.Bool
+	EnrichProgress binding.Float
+	EnrichStatus   binding.String
+
+	// AutoRefresh describes the last scheduled refresh.
+	AutoRefresh binding.String
+
+	// KeymapVersion changes whenever shortcuts are rebound while
+	// WatchKeymap is on.
+	KeymapVersion binding.Int
 
 	svc       stars.Loader
+	enricher  enrich.Runner
+	growth    *trends.Store
+	catalogs  CatalogCache
+	jobs      *jobs.Manager
+	scheduler *schedule.Scheduler
+	keys      *keymap.Store
 	runOnMain func(func())
+	// defaultPerPage is the page size WithListDefaults hands to Credentials.
+	defaultPerPage int
+
+	mu           sync.Mutex
+	cancel       context.CancelFunc
+	loadJob      *jobs.Handle
+	enrichCancel context.CancelFunc
+	removeTask   func()
+	unwatchKeys  func()
+	// pendingSelect is a restored selection waiting for its repo to be
+	// listed.
+	pendingSelect string
+
+	// index is the loaded catalog and visible the positions in it that the
+	// list shows, after filtering and sorting.
+	reposMu     sync.RWMutex
+	index       *stars.Index
+	visible     []int
+	version     int
+	sortKey     stars.SortKey
+	hidePrivate bool
+}
+
+// Option configures optional VM collaborators.
+type Option func(*VM)
 
-	mu     sync.Mutex
-	cancel context.CancelFunc
+// WithEnricher enables the opt-in background enrichment job.
+func WithEnricher(e enrich.Runner) Option {
+	return func(vm *VM) {
+		vm.enricher = e
+	}
+}
+
+// WithTrends enables the star growth column backed by recorded samples.
+func WithTrends(store *trends.Store) Option {
+	return func(vm *VM) {
+		vm.growth = store
+	}
+}
+
+// CatalogCache returns the catalog saved by the last sync of a user;
+// history.Store implements it.
+type CatalogCache interface {
+	Latest(username string) (history.Snapshot, bool, error)
+}
 
-	reposMu sync.RWMutex
-	repos   []domain.Repo
+// WithCredentials loads with the account in creds, whose view the stars
+// window embeds. Without it the VM keeps a bare credentials.VM.
+func WithCredentials(creds *credentials.VM) Option {
+	return func(vm *VM) {
+		vm.Credentials = creds
+	}
 }
 
-func NewVM(svc stars.Loader, runOnMain func(func())) *VM {
+// WithCatalogCache shows the last synced catalog when switching profiles,
+// before anything is loaded.
+func WithCatalogCache(cache CatalogCache) Option {
+	return func(vm *VM) {
+		vm.catalogs = cache
+	}
+}
+
+// WithScheduler re-syncs the list on the scheduler's stars interval while
+// the window is open.
+func WithScheduler(scheduler *schedule.Scheduler) Option {
+	return func(vm *VM) {
+		vm.scheduler = scheduler
+	}
+}
+
+// WithJobs lists syncs and enrichment runs in the shared activity panel,
+// where they can also be canceled.
+func WithJobs(manager *jobs.Manager) Option {
+	return func(vm *VM) {
+		vm.jobs = manager
+	}
+}
+
+// WithKeymap uses the rebindable shortcuts in store instead of the defaults.
+func WithKeymap(store *keymap.Store) Option {
+	return func(vm *VM) {
+		vm.keys = store
+	}
+}
+
+// WithListDefaults orders a new list by stars.SortKeys[sort] and loads
+// perPage repos per request unless a profile or the form says otherwise.
+func WithListDefaults(sort, perPage int) Option {
+	return func(vm *VM) {
+		if sort >= 0 && sort < len(stars.SortKeys) {
+			vm.sortKey = stars.SortKeys[sort]
+			_ = vm.Sort.Set(sort)
+		}
+		vm.defaultPerPage = perPage
+	}
+}
+
+func NewVM(svc stars.Loader, runOnMain func(func()), opts ...Option) *VM {
 	vm := &VM{
-		Username:  binding.NewString(),
-		Token:     binding.NewString(),
-		PerPage:   binding.NewString(),
-		Loading:   binding.NewBool(),
-		Status:    binding.NewString(),
-		Error:     binding.NewString(),
-		Repos:     binding.NewList(func(a, b domain.Repo) bool { return a == b }),
-		svc:       svc,
-		runOnMain: runOnMain,
+		Loading:        binding.NewBool(),
+		Query:          binding.NewString(),
+		ListVersion:    binding.NewInt(),
+		Selected:       binding.NewInt(),
+		Sort:           binding.NewInt(),
+		HidePrivate:    binding.NewBool(),
+		Enriching:      binding.NewBool(),
+		EnrichProgress: binding.NewFloat(),
+		EnrichStatus:   binding.NewString(),
+		AutoRefresh:    binding.NewString(),
+		KeymapVersion:  binding.NewInt(),
+		svc:            svc,
+		runOnMain:      runOnMain,
 	}
 	if vm.runOnMain == nil {
 		vm.runOnMain = func(f func()) { f() }
 	}
-	_ = vm.PerPage.Set("100")
+	for _, opt := range opts {
+		opt(vm)
+	}
+	if vm.Credentials == nil {
+		vm.Credentials = credentials.NewVM(vm.runOnMain)
+	}
+	vm.Credentials.SetDefaultPerPage(vm.defaultPerPage)
+	vm.Credentials.OnProfileSwitch(vm.switchProfile)
+	vm.Username = vm.Credentials.Username
+	vm.Token = vm.Credentials.Token
+	vm.PerPage = vm.Credentials.PerPage
+	vm.Status = vm.Credentials.Status
+	vm.Error = vm.Credentials.Error
 	_ = vm.Status.Set("Ready")
+	_ = vm.Selected.Set(-1)
 	return vm
 }
 
 func (vm *VM) Load() {
+	username, _ := vm.Username.Get()
 	vm.mu.Lock()
 	if vm.cancel != nil {
 		vm.cancel()
 	}
 	ctx, cancel := context.WithTimeout(context.Background(), 45*time.Second)
-	vm.cancel = cancel
+	job := vm.jobs.Register(syncJobName(username), cancel)
+	vm.cancel = job.Cancel
+	vm.loadJob = job
 	vm.mu.Unlock()
 
 	vm.runOnMain(func() {
@@ -71,11 +221,10 @@ func (vm *VM) Load() {
 	})
 
 	go func() {
-		username, _ := vm.Username.Get()
 		token, _ := vm.Token.Get()
-		perPageStr, _ := vm.PerPage.Get()
-		perPage, err := parsePerPage(perPageStr)
+		perPage, err := vm.Credentials.PageSize()
 		if err != nil {
+			job.Finish("", err)
 			vm.runOnMain(func() {
 				_ = vm.Loading.Set(false)
 				_ = vm.Error.Set(err.Error())
@@ -84,7 +233,37 @@ func (vm *VM) Load() {
 			return
 		}
 
+		vm.Credentials.CheckToken(token)
+
+		if strings.TrimSpace(username) == "" && token != "" {
+			login, err := vm.Credentials.ResolveUsername(ctx, token)
+			if errors.Is(ctx.Err(), context.Canceled) {
+				vm.loadCanceled(job)
+				return
+			}
+			if err != nil {
+				job.Finish("", err)
+				vm.runOnMain(func() {
+					_ = vm.Loading.Set(false)
+					_ = vm.Error.Set("could not resolve username from token: " + err.Error())
+					_ = vm.Status.Set("Load failed")
+				})
+				return
+			}
+			if login != "" {
+				username = login
+				vm.runOnMain(func() {
+					_ = vm.Username.Set(login)
+				})
+			}
+		}
+
 		repos, err := vm.svc.LoadStarred(ctx, username, token, perPage)
+		if errors.Is(ctx.Err(), context.Canceled) {
+			vm.loadCanceled(job)
+			return
+		}
+		job.Finish(fmt.Sprintf("Loaded %d repos", len(repos)), err)
 		if err != nil {
 			vm.runOnMain(func() {
 				_ = vm.Loading.Set(false)
@@ -94,8 +273,11 @@ func (vm *VM) Load() {
 			return
 		}
 
+		// Index and filter here so the main thread only swaps in the result.
+		index := stars.NewIndex(repos)
+		visible := vm.filter(index)
 		vm.runOnMain(func() {
-			vm.setRepos(repos)
+			vm.show(index, visible)
 			_ = vm.Loading.Set(false)
 			_ = vm.Status.Set("Loaded")
 		})
@@ -108,51 +290,572 @@ func (vm *VM) Cleanup() {
 		vm.cancel()
 		vm.cancel = nil
 	}
+	vm.loadJob = nil
+	vm.mu.Unlock()
+	vm.StopEnrich()
+	vm.Credentials.CancelSignIn()
+}
+
+// loadCanceled resets the loading state when job was canceled from the
+// activity panel. Loads superseded by another load, a profile switch or
+// Cleanup are no longer current and leave the state to their successor.
+func (vm *VM) loadCanceled(job *jobs.Handle) {
+	vm.mu.Lock()
+	current := vm.loadJob == job
+	if current {
+		vm.loadJob = nil
+	}
+	vm.mu.Unlock()
+	if !current {
+		return
+	}
+	vm.runOnMain(func() {
+		_ = vm.Loading.Set(false)
+		_ = vm.Status.Set("Load canceled")
+	})
+}
+
+// HasScheduler reports whether scheduled refreshes are available.
+func (vm *VM) HasScheduler() bool {
+	return vm.scheduler != nil
+}
+
+// Schedule returns the current refresh intervals.
+func (vm *VM) Schedule() schedule.Settings {
+	if vm.scheduler == nil {
+		return schedule.Settings{}
+	}
+	return vm.scheduler.Settings()
+}
+
+// SetSchedule changes the refresh intervals of every window.
+func (vm *VM) SetSchedule(settings schedule.Settings) {
+	if vm.scheduler == nil {
+		return
+	}
+	vm.scheduler.SetSettings(settings)
+	if settings.Stars <= 0 {
+		vm.runOnMain(func() {
+			_ = vm.AutoRefresh.Set("")
+		})
+	}
+}
+
+// StartAutoRefresh adds the stars refresh to the scheduler. It lives until
+// StopAutoRefresh, across loads and profile switches.
+func (vm *VM) StartAutoRefresh() {
+	if vm.scheduler == nil {
+		return
+	}
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if vm.removeTask != nil {
+		return
+	}
+	vm.removeTask = vm.scheduler.Add(schedule.Task{
+		Kind: schedule.StarsRefresh,
+		Name: "Sync stars",
+		Run:  vm.refreshScheduled,
+		OnResult: func(r schedule.Result) {
+			vm.runOnMain(func() {
+				_ = vm.AutoRefresh.Set(r.Describe())
+			})
+		},
+	})
+}
+
+// StopAutoRefresh removes the stars refresh and cancels a run in progress.
+func (vm *VM) StopAutoRefresh() {
+	vm.mu.Lock()
+	remove := vm.removeTask
+	vm.removeTask = nil
+	vm.mu.Unlock()
+	if remove != nil {
+		remove()
+	}
+}
+
+// refreshScheduled syncs the current account through the stars.Loader like
+// Load does, but leaves Loading, Status and Error to interactive loads.
+func (vm *VM) refreshScheduled(ctx context.Context) (string, error) {
+	if loading, _ := vm.Loading.Get(); loading {
+		return "a load was already running", nil
+	}
+	username, _ := vm.Username.Get()
+	token, _ := vm.Token.Get()
+	if strings.TrimSpace(username) == "" && token == "" {
+		return "nothing loaded yet", nil
+	}
+	perPage, err := vm.Credentials.PageSize()
+	if err != nil {
+		return "", err
+	}
+
+	repos, err := vm.svc.LoadStarred(ctx, username, token, perPage)
+	if err != nil {
+		return "", err
+	}
+	index := stars.NewIndex(repos)
+	visible := vm.filter(index)
+	vm.runOnMain(func() {
+		// The account may have changed while the request ran.
+		if current, _ := vm.Username.Get(); current != username {
+			return
+		}
+		vm.show(index, visible)
+	})
+	return fmt.Sprintf("%d repos", len(repos)), nil
+}
+
+// CanEnrich reports whether an enrichment job is configured.
+func (vm *VM) CanEnrich() bool {
+	return vm.enricher != nil
+}
+
+// Enrich fetches full details for every loaded repo in the background so
+// filters such as license:mit work across the whole catalog. Repos enriched
+// by an earlier run are skipped, so calling it again resumes.
+func (vm *VM) Enrich() {
+	if vm.enricher == nil {
+		return
+	}
+	vm.reposMu.RLock()
+	catalog := vm.index.All()
+	vm.reposMu.RUnlock()
+	if len(catalog) == 0 {
+		vm.runOnMain(func() {
+			_ = vm.EnrichStatus.Set("Load stars before enriching")
+		})
+		return
+	}
+
+	vm.mu.Lock()
+	if vm.enrichCancel != nil {
+		vm.enrichCancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	job := vm.jobs.Register("Enrich stars", cancel)
+	vm.enrichCancel = job.Cancel
+	vm.mu.Unlock()
+
+	vm.runOnMain(func() {
+		_ = vm.Enriching.Set(true)
+		_ = vm.EnrichStatus.Set("Enriching...")
+	})
+
+	token, _ := vm.Token.Get()
+	go func() {
+		var last enrich.Progress
+		err := vm.enricher.Run(ctx, catalog, token, func(p enrich.Progress) {
+			last = p
+			job.Progress(p.Done+p.Failed, p.Total, describeProgress(p))
+			vm.runOnMain(func() {
+				_ = vm.EnrichProgress.Set(progressFraction(p))
+				_ = vm.EnrichStatus.Set(describeProgress(p))
+			})
+		})
+		job.Finish(describeProgress(last), err)
+
+		vm.runOnMain(func() {
+			_ = vm.Enriching.Set(false)
+			switch {
+			case errors.Is(err, context.Canceled):
+				_ = vm.EnrichStatus.Set("Enrichment paused at " + describeProgress(last))
+			case err != nil:
+				_ = vm.EnrichStatus.Set("Enrichment failed: " + err.Error())
+			default:
+				_ = vm.EnrichStatus.Set(describeProgress(last))
+			}
+			vm.ApplyFilter()
+		})
+	}()
+}
+
+// StopEnrich cancels a running enrichment job; progress made so far is kept.
+func (vm *VM) StopEnrich() {
+	vm.mu.Lock()
+	if vm.enrichCancel != nil {
+		vm.enrichCancel()
+		vm.enrichCancel = nil
+	}
+	vm.mu.Unlock()
+}
+
+// switchProfile cancels in-flight work for the previous account and shows
+// the cached catalog of p, if any, until its stars are loaded.
+func (vm *VM) switchProfile(p profile.Profile) {
+	vm.Cleanup()
+	vm.runOnMain(func() {
+		_ = vm.Loading.Set(false)
+		_ = vm.EnrichStatus.Set("")
+		vm.setRepos(nil)
+		_ = vm.Status.Set("Switched to " + p.Name)
+	})
+
+	if vm.catalogs == nil || strings.TrimSpace(p.Username) == "" {
+		return
+	}
+	vm.mu.Lock()
+	ctx, cancel := context.WithCancel(context.Background())
+	vm.cancel = cancel
 	vm.mu.Unlock()
+
+	go func() {
+		snapshot, ok, err := vm.catalogs.Latest(p.Username)
+		if err != nil || !ok || ctx.Err() != nil {
+			return
+		}
+		index := stars.NewIndex(snapshot.Repos)
+		visible := vm.filter(index)
+		vm.runOnMain(func() {
+			if ctx.Err() != nil {
+				return
+			}
+			vm.show(index, visible)
+			_ = vm.Status.Set("Showing " + p.Name + " as of " + snapshot.TakenAt.Local().Format("2006-01-02 15:04"))
+		})
+	}()
 }
 
 func (vm *VM) Clear() {
+	// Cleanup leaves the state of a canceled load to its caller.
 	vm.Cleanup()
 
 	vm.runOnMain(func() {
 		vm.setRepos(nil)
+		_ = vm.Loading.Set(false)
 		_ = vm.Error.Set("")
 		_ = vm.Status.Set("Cleared")
 	})
 }
 
+// Len returns the number of visible repos.
+func (vm *VM) Len() int {
+	vm.reposMu.RLock()
+	defer vm.reposMu.RUnlock()
+	return len(vm.visible)
+}
+
+// VisibleRepos returns a copy of the visible repos in list order.
+func (vm *VM) VisibleRepos() []domain.Repo {
+	vm.reposMu.RLock()
+	defer vm.reposMu.RUnlock()
+	if vm.index == nil {
+		return nil
+	}
+	return vm.index.Repos(vm.visible)
+}
+
 func (vm *VM) RepoAt(index int) (domain.Repo, bool) {
 	vm.reposMu.RLock()
 	defer vm.reposMu.RUnlock()
-	if index < 0 || index >= len(vm.repos) {
+	if index < 0 || index >= len(vm.visible) {
 		return domain.Repo{}, false
 	}
-	return vm.repos[index], true
+	return vm.index.Repo(vm.visible[index]), true
+}
+
+// Catalog returns every loaded repo, whatever the filter.
+func (vm *VM) Catalog() []domain.Repo {
+	vm.reposMu.RLock()
+	defer vm.reposMu.RUnlock()
+	return vm.index.All()
+}
+
+// Keymap returns the shortcut bindings; nil serves the defaults.
+func (vm *VM) Keymap() *keymap.Store {
+	return vm.keys
+}
+
+// WatchKeymap bumps KeymapVersion on every rebinding until UnwatchKeymap.
+func (vm *VM) WatchKeymap() {
+	vm.mu.Lock()
+	defer vm.mu.Unlock()
+	if vm.unwatchKeys != nil {
+		return
+	}
+	version := 0
+	vm.unwatchKeys = vm.keys.Subscribe(func() {
+		vm.runOnMain(func() {
+			version++
+			_ = vm.KeymapVersion.Set(version)
+		})
+	})
+}
+
+func (vm *VM) UnwatchKeymap() {
+	vm.mu.Lock()
+	unwatch := vm.unwatchKeys
+	vm.unwatchKeys = nil
+	vm.mu.Unlock()
+	if unwatch != nil {
+		unwatch()
+	}
+}
+
+// Select picks the repo at list position index; an out of range index
+// clears the selection.
+func (vm *VM) Select(index int) {
+	if index < 0 || index >= vm.Len() {
+		index = -1
+	}
+	_ = vm.Selected.Set(index)
+}
+
+// MoveSelection moves the selection by delta rows, starting from the top
+// when nothing is selected.
+func (vm *VM) MoveSelection(delta int) {
+	n := vm.Len()
+	if n == 0 {
+		return
+	}
+	current, _ := vm.Selected.Get()
+	next := current + delta
+	if current < 0 {
+		next = 0
+	}
+	_ = vm.Selected.Set(min(max(next, 0), n-1))
+}
+
+// SelectedRepo returns the selected repo. When there is none it says so in
+// Status, since it is asked for by a key press with nothing else to show.
+func (vm *VM) SelectedRepo() (domain.Repo, bool) {
+	index, _ := vm.Selected.Get()
+	repo, ok := vm.RepoAt(index)
+	if !ok {
+		_ = vm.Status.Set("Select a repo first")
+	}
+	return repo, ok
+}
+
+// OpenSelectedInBrowser hands the selected repo's page to open.
+func (vm *VM) OpenSelectedInBrowser(open func(*url.URL) error) {
+	repo, ok := vm.SelectedRepo()
+	if !ok {
+		return
+	}
+	link, err := url.Parse(repoURL(repo))
+	if err == nil {
+		err = open(link)
+	}
+	if err != nil {
+		_ = vm.Error.Set("could not open browser: " + err.Error())
+		return
+	}
+	_ = vm.Status.Set("Opened " + repo.FullName + " in the browser")
+}
+
+// CopySelectedURL hands the selected repo's URL to copy.
+func (vm *VM) CopySelectedURL(copy func(string)) {
+	repo, ok := vm.SelectedRepo()
+	if !ok {
+		return
+	}
+	copy(repoURL(repo))
+	_ = vm.Status.Set("Copied the URL of " + repo.FullName)
+}
+
+// Search returns up to limit loaded repos matching query, in list order. It
+// ignores Query, so it can back a search outside this window.
+func (vm *VM) Search(query string, limit int) []domain.Repo {
+	vm.reposMu.RLock()
+	index, hidePrivate, sortKey := vm.index, vm.hidePrivate, vm.sortKey
+	vm.reposMu.RUnlock()
+	if index.Len() == 0 {
+		return nil
+	}
+
+	var lookup func(string) (domain.RepoDetails, bool)
+	if vm.enricher != nil {
+		lookup = vm.enricher.Lookup
+	}
+	matches := index.Filter(stars.ParseQuery(query), hidePrivate, lookup)
+	index.Sort(matches, sortKey)
+	if limit > 0 && len(matches) > limit {
+		matches = matches[:limit]
+	}
+	return index.Repos(matches)
+}
+
+// HasTrends reports whether star growth samples are available.
+func (vm *VM) HasTrends() bool {
+	return vm.growth != nil
+}
+
+// StarDelta formats the star change of repo over the last 30 days, or "-"
+// while fewer than two samples exist.
+func (vm *VM) StarDelta(repo domain.Repo) string {
+	if vm.growth == nil {
+		return "-"
+	}
+	delta, _, ok := vm.growth.Delta(repo.FullName, 30*24*time.Hour, time.Now())
+	if !ok {
+		return "-"
+	}
+	return fmt.Sprintf("%+d", delta)
+}
+
+// ApplyFilter narrows the visible list to repos matching Query.
+func (vm *VM) ApplyFilter() {
+	vm.reposMu.RLock()
+	index := vm.index
+	vm.reposMu.RUnlock()
+	vm.show(index, vm.filter(index))
+}
+
+// SetHidePrivate hides private repos from the list, for screen sharing.
+func (vm *VM) SetHidePrivate(hide bool) {
+	vm.reposMu.Lock()
+	vm.hidePrivate = hide
+	vm.reposMu.Unlock()
+	_ = vm.HidePrivate.Set(hide)
+	vm.ApplyFilter()
+}
+
+// SetSort orders the list by stars.SortKeys[index].
+func (vm *VM) SetSort(index int) {
+	if index < 0 || index >= len(stars.SortKeys) {
+		return
+	}
+	vm.reposMu.Lock()
+	vm.sortKey = stars.SortKeys[index]
+	vm.reposMu.Unlock()
+	_ = vm.Sort.Set(index)
+	vm.ApplyFilter()
+}
+
+// ViewState is how the list is filtered, ordered and selected, for saving
+// it across launches.
+type ViewState struct {
+	Query       string
+	Sort        int
+	HidePrivate bool
+	// Selected is the full name of the selected repo, or "".
+	Selected string
+}
+
+func (vm *VM) ViewState() ViewState {
+	query, _ := vm.Query.Get()
+	sort, _ := vm.Sort.Get()
+	hidePrivate, _ := vm.HidePrivate.Get()
+	state := ViewState{Query: query, Sort: sort, HidePrivate: hidePrivate}
+	index, _ := vm.Selected.Get()
+	if repo, ok := vm.RepoAt(index); ok {
+		state.Selected = repo.FullName
+	}
+	return state
+}
+
+// RestoreView applies a saved ViewState. The selection is applied once its
+// repo is listed, which may be after the next load; it is dropped if a
+// loaded list does not show it.
+func (vm *VM) RestoreView(state ViewState) {
+	vm.mu.Lock()
+	vm.pendingSelect = state.Selected
+	vm.mu.Unlock()
+
+	vm.reposMu.Lock()
+	vm.hidePrivate = state.HidePrivate
+	if state.Sort >= 0 && state.Sort < len(stars.SortKeys) {
+		vm.sortKey = stars.SortKeys[state.Sort]
+		_ = vm.Sort.Set(state.Sort)
+	}
+	vm.reposMu.Unlock()
+	_ = vm.HidePrivate.Set(state.HidePrivate)
+	_ = vm.Query.Set(state.Query)
+	vm.ApplyFilter()
+}
+
+// filter returns the positions in index to show for the current query, sort
+// and privacy setting. It does not touch bindings, so it can run off the main
+// thread.
+func (vm *VM) filter(index *stars.Index) []int {
+	query, _ := vm.Query.Get()
+	vm.reposMu.RLock()
+	hidePrivate, sortKey := vm.hidePrivate, vm.sortKey
+	vm.reposMu.RUnlock()
+
+	var lookup func(string) (domain.RepoDetails, bool)
+	if vm.enricher != nil {
+		lookup = vm.enricher.Lookup
+	}
+	visible := index.Filter(stars.ParseQuery(query), hidePrivate, lookup)
+	index.Sort(visible, sortKey)
+	return visible
 }
 
 func (vm *VM) setRepos(repos []domain.Repo) {
+	index := stars.NewIndex(repos)
+	vm.show(index, vm.filter(index))
+}
+
+// show swaps in visible and bumps ListVersion once.
+func (vm *VM) show(index *stars.Index, visible []int) {
 	vm.reposMu.Lock()
-	vm.repos = make([]domain.Repo, len(repos))
-	copy(vm.repos, repos)
+	vm.index = index
+	vm.visible = visible
+	vm.version++
+	version := vm.version
 	vm.reposMu.Unlock()
 
-	_ = vm.Repos.Set(nil)
-	for _, repo := range repos {
-		_ = vm.Repos.Append(repo)
+	_ = vm.ListVersion.Set(version)
+	_ = vm.Selected.Set(-1)
+	vm.selectPending()
+}
+
+// selectPending selects the repo RestoreView asked for once a non-empty
+// list has been shown.
+func (vm *VM) selectPending() {
+	vm.mu.Lock()
+	fullName := vm.pendingSelect
+	vm.mu.Unlock()
+	if fullName == "" {
+		return
+	}
+	vm.reposMu.RLock()
+	index, visible := vm.index, vm.visible
+	vm.reposMu.RUnlock()
+	if index.Len() == 0 {
+		return
+	}
+
+	vm.mu.Lock()
+	vm.pendingSelect = ""
+	vm.mu.Unlock()
+	for i, id := range visible {
+		if strings.EqualFold(index.Repo(id).FullName, fullName) {
+			_ = vm.Selected.Set(i)
+			return
+		}
 	}
 }
 
-func parsePerPage(value string) (int, error) {
-	value = strings.TrimSpace(value)
-	if value == "" {
-		return 100, nil
+// repoURL is the repo's page, built from its name when the API left it out.
+func repoURL(repo domain.Repo) string {
+	if repo.HTMLURL != "" {
+		return repo.HTMLURL
 	}
-	perPage, err := strconv.Atoi(value)
-	if err != nil {
-		return 0, errors.New("per page must be a number")
+	return "https://github.com/" + repo.FullName
+}
+
+func syncJobName(username string) string {
+	if username = strings.TrimSpace(username); username != "" {
+		return "Sync stars of " + username
 	}
-	if perPage < 1 || perPage > 100 {
-		return 0, errors.New("per page must be between 1 and 100")
+	return "Sync stars"
+}
+
+func progressFraction(p enrich.Progress) float64 {
+	if p.Total == 0 {
+		return 0
+	}
+	return float64(p.Done+p.Failed) / float64(p.Total)
+}
+
+func describeProgress(p enrich.Progress) string {
+	msg := fmt.Sprintf("Enriched %d of %d", p.Done, p.Total)
+	if p.Failed > 0 {
+		msg += fmt.Sprintf(" (%d failed)", p.Failed)
 	}
-	return perPage, nil
+	return msg
 }
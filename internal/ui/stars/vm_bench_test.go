@@ -0,0 +1,132 @@
+package stars_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	uistars "github.com/tbxark/gh-stars/internal/ui/stars"
+)
+
+const benchSize = 50_000
+
+// mainThread runs callbacks inline like the real main thread, counts them
+// and adds up the time spent in them, which is what the UI would stall for.
+type mainThread struct {
+	calls int
+	busy  time.Duration
+	done  chan struct{}
+	vm    *uistars.VM
+}
+
+func (m *mainThread) run(f func()) {
+	start := time.Now()
+	f()
+	m.calls++
+	m.busy += time.Since(start)
+	if m.vm == nil || m.done == nil {
+		return
+	}
+	if loading, _ := m.vm.Loading.Get(); !loading {
+		select {
+		case m.done <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func BenchmarkVM_Load_50k(b *testing.B) {
+	repos := testdata.LargeRepoList(benchSize)
+	svc := stars.NewMockService()
+	svc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return repos, nil
+	}
+	main := &mainThread{done: make(chan struct{}, 1)}
+	vm := uistars.NewVM(svc, main.run)
+	main.vm = vm
+	_ = vm.Username.Set("octocat")
+
+	b.ResetTimer()
+	for b.Loop() {
+		vm.Load()
+		<-main.done
+	}
+	b.ReportMetric(float64(main.busy.Nanoseconds())/float64(b.N), "main-ns/op")
+}
+
+func TestVM_Load_LargeCatalogKeepsMainThreadFree(t *testing.T) {
+	repos := testdata.LargeRepoList(benchSize)
+	svc := stars.NewMockService()
+	svc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return repos, nil
+	}
+	main := &mainThread{done: make(chan struct{}, 1)}
+	vm := uistars.NewVM(svc, main.run)
+	main.vm = vm
+	_ = vm.Username.Set("octocat")
+	before, _ := vm.ListVersion.Get()
+	main.calls = 0
+
+	vm.Load()
+	select {
+	case <-main.done:
+	case <-time.After(10 * time.Second):
+		t.Fatal("load did not finish")
+	}
+
+	testutil.AssertEqual(t, benchSize, vm.Len())
+	// Indexing and filtering happen off the main thread; what is left is a
+	// handful of binding updates, not one per repo. The time they take is
+	// reported by BenchmarkVM_Load_50k.
+	after, _ := vm.ListVersion.Get()
+	testutil.AssertEqual(t, before+1, after)
+	testutil.AssertTrue(t, main.calls <= 10, fmt.Sprintf("load ran %d callbacks on the main thread", main.calls))
+}
+
+func BenchmarkVM_ApplyFilter_50k(b *testing.B) {
+	svc := stars.NewMockService()
+	main := &mainThread{}
+	vm := uistars.NewVM(svc, main.run)
+	loadDirect(b, vm, svc, testdata.LargeRepoList(benchSize))
+
+	queries := []string{"c", "co", "con", "cont", "lang:go cont", ""}
+	b.ResetTimer()
+	for i := 0; b.Loop(); i++ {
+		_ = vm.Query.Set(queries[i%len(queries)])
+		vm.ApplyFilter()
+	}
+}
+
+func BenchmarkVM_SetSort_50k(b *testing.B) {
+	svc := stars.NewMockService()
+	vm := uistars.NewVM(svc, func(f func()) { f() })
+	loadDirect(b, vm, svc, testdata.LargeRepoList(benchSize))
+
+	b.ResetTimer()
+	for i := 0; b.Loop(); i++ {
+		vm.SetSort(i % len(stars.SortKeys))
+	}
+}
+
+// loadDirect loads repos into vm and waits until they are shown.
+func loadDirect(b *testing.B, vm *uistars.VM, svc *stars.MockService, repos []domain.Repo) {
+	b.Helper()
+	svc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return repos, nil
+	}
+	_ = vm.Username.Set("octocat")
+	vm.Load()
+	for deadline := time.Now().Add(10 * time.Second); time.Now().Before(deadline); time.Sleep(10 * time.Millisecond) {
+		if loading, _ := vm.Loading.Get(); !loading {
+			if vm.Len() == len(repos) {
+				return
+			}
+		}
+	}
+	b.Fatal("repos were not loaded")
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"fyne.io/fyne/v2/test"
+	"github.com/tbxark/gh-stars/internal/app/jobs"
 	"github.com/tbxark/gh-stars/internal/app/stars"
 	"github.com/tbxark/gh-stars/internal/domain"
 	"github.com/tbxark/gh-stars/internal/domain/testdata"
@@ -86,3 +87,65 @@ func TestVM_ContextCancellation_ConcurrentOperations(t *testing.T) {
 	status, _ := vm.Status.Get()
 	testutil.AssertEqual(t, "Loaded", status)
 }
+
+func TestVM_ConcurrentLoadWithJobs_CleanupDeregisters(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		select {
+		case <-time.After(100 * time.Millisecond):
+			return testdata.SampleRepoList(), nil
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+
+	manager := &jobs.Manager{}
+	unsubscribe := manager.Subscribe(func() { _ = manager.List() })
+	defer unsubscribe()
+
+	runOnMain := func(f func()) { f() }
+	vm := uistars.NewVM(mockSvc, runOnMain, uistars.WithJobs(manager))
+	_ = vm.Username.Set("testuser")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			vm.Load()
+		}()
+	}
+	wg.Wait()
+
+	testutil.AssertEqual(t, 1, manager.Running())
+	vm.Cleanup()
+	testutil.AssertEqual(t, 0, manager.Running())
+
+	time.Sleep(150 * time.Millisecond)
+	for _, job := range manager.List() {
+		testutil.AssertEqual(t, jobs.Canceled, job.State)
+	}
+}
+
+func TestVM_CancelFromActivity_StopsLoading(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+
+	manager := &jobs.Manager{}
+	runOnMain := func(f func()) { f() }
+	vm := uistars.NewVM(mockSvc, runOnMain, uistars.WithJobs(manager))
+	_ = vm.Username.Set("testuser")
+
+	vm.Load()
+	time.Sleep(20 * time.Millisecond)
+	manager.Cancel(manager.List()[0].ID)
+	time.Sleep(20 * time.Millisecond)
+
+	loading, _ := vm.Loading.Get()
+	testutil.AssertFalse(t, loading, "loading should stop when the job is canceled")
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "Load canceled", status)
+}
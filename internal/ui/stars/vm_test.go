@@ -3,13 +3,24 @@ package stars_test
 import (
 	"context"
 	"errors"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
 
+	"github.com/tbxark/gh-stars/internal/app/enrich"
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
 	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/trends"
 	"github.com/tbxark/gh-stars/internal/domain"
 	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/github"
 	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/credentials"
 	uistars "github.com/tbxark/gh-stars/internal/ui/stars"
 )
 
@@ -105,6 +116,30 @@ func TestVM_Clear(t *testing.T) {
 	testutil.AssertEqual(t, "", errorMsg)
 }
 
+func TestVM_Clear_StopsLoad(t *testing.T) {
+	started := make(chan struct{})
+	returned := make(chan struct{})
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		defer close(returned)
+		close(started)
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() })
+	_ = vm.Username.Set("alice")
+
+	vm.Load()
+	<-started
+	vm.Clear()
+	<-returned
+
+	loading, _ := vm.Loading.Get()
+	status, _ := vm.Status.Get()
+	testutil.AssertFalse(t, loading, "clearing should end the load")
+	testutil.AssertEqual(t, "Cleared", status)
+}
+
 func TestVM_RepoAt_ValidIndex(t *testing.T) {
 	mockSvc := stars.NewMockService()
 	expectedRepos := testdata.SampleRepoList()
@@ -152,3 +187,394 @@ func TestVM_DefaultValues(t *testing.T) {
 	testutil.AssertEqual(t, "Ready", status)
 	testutil.AssertFalse(t, loading, "loading should be false by default")
 }
+
+func TestVM_ApplyFilter(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	runOnMain := func(f func()) { f() }
+	vm := uistars.NewVM(mockSvc, runOnMain)
+	_ = vm.Username.Set("testuser")
+
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+	_ = vm.Query.Set("lang:typescript")
+	vm.ApplyFilter()
+
+	repo, ok := vm.RepoAt(0)
+	testutil.AssertTrue(t, ok, "filtered list should have a first repo")
+	testutil.AssertEqual(t, "microsoft/vscode", repo.FullName)
+	_, ok = vm.RepoAt(1)
+	testutil.AssertFalse(t, ok, "filtered list should have one repo")
+}
+
+func TestVM_Enrich_EnablesLicenseFilter(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	detailsSvc := repos.NewMockService()
+	detailsSvc.LoadDetailsFunc = func(ctx context.Context, fullName, token string) (domain.RepoDetails, error) {
+		details := testdata.SampleRepoDetailsMinimal()
+		details.FullName = fullName
+		if fullName == "kubernetes/kubernetes" {
+			details.License = "Apache License 2.0"
+		}
+		return details, nil
+	}
+	job := &enrich.Job{Details: detailsSvc, Store: enrich.NewStore("")}
+
+	runOnMain := func(f func()) { f() }
+	vm := uistars.NewVM(mockSvc, runOnMain, uistars.WithEnricher(job))
+	_ = vm.Username.Set("testuser")
+	_ = vm.Query.Set("license:apache")
+
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+	_, ok := vm.RepoAt(0)
+	testutil.AssertFalse(t, ok, "license filter should match nothing before enrichment")
+
+	vm.Enrich()
+	time.Sleep(100 * time.Millisecond)
+
+	repo, ok := vm.RepoAt(0)
+	enriching, _ := vm.Enriching.Get()
+	progress, _ := vm.EnrichProgress.Get()
+	testutil.AssertTrue(t, ok, "license filter should match after enrichment")
+	testutil.AssertEqual(t, "kubernetes/kubernetes", repo.FullName)
+	testutil.AssertFalse(t, enriching, "enrichment should have finished")
+	testutil.AssertEqual(t, 1.0, progress)
+	testutil.AssertEqual(t, 3, detailsSvc.GetLoadDetailsCount())
+}
+
+func TestVM_StarDelta(t *testing.T) {
+	store := trends.NewStore("")
+	repo := testdata.SampleRepo()
+	now := time.Now()
+	store.Record([]domain.Repo{repo}, now.AddDate(0, 0, -40))
+	repo.Stars += 25
+	store.Record([]domain.Repo{repo}, now)
+
+	vm := uistars.NewVM(stars.NewMockService(), func(f func()) { f() }, uistars.WithTrends(store))
+
+	testutil.AssertTrue(t, vm.HasTrends(), "trends should be enabled")
+	testutil.AssertEqual(t, "+25", vm.StarDelta(repo))
+	testutil.AssertEqual(t, "-", vm.StarDelta(testdata.SampleRepoPrivate()))
+}
+
+func TestVM_SwitchProfile_RestoresCachedCatalog(t *testing.T) {
+	profiles := profile.NewStore(testutil.NewPrefs())
+	tokens := &profile.MemoryTokens{}
+	cache := history.NewStore(t.TempDir())
+	testutil.AssertNoError(t, cache.Save(history.Snapshot{
+		Username: "alice",
+		TakenAt:  time.Now(),
+		Repos:    testdata.SampleRepoList(),
+	}))
+
+	creds := credentials.NewVM(func(f func()) { f() },
+		credentials.WithProfiles(profiles), credentials.WithTokens(tokens))
+	vm := uistars.NewVM(stars.NewMockService(), func(f func()) { f() },
+		uistars.WithCredentials(creds), uistars.WithCatalogCache(cache))
+	testutil.AssertTrue(t, creds.HasProfiles(), "profiles should be enabled")
+
+	_ = vm.Username.Set("alice")
+	_ = vm.Token.Set("ghp_work")
+	_ = vm.PerPage.Set("50")
+	testutil.AssertNoError(t, creds.SaveProfile("Work", ""))
+	_ = vm.Username.Set("bob")
+	_ = vm.Token.Set("")
+	_ = vm.PerPage.Set("")
+	testutil.AssertNoError(t, creds.SaveProfile("Home", ""))
+
+	names, _ := creds.Profiles.Get()
+	testutil.AssertEqual(t, 2, len(names))
+
+	creds.SwitchProfile("work")
+	time.Sleep(50 * time.Millisecond)
+
+	username, _ := vm.Username.Get()
+	testutil.AssertEqual(t, "alice", username)
+	token, _ := vm.Token.Get()
+	testutil.AssertEqual(t, "ghp_work", token)
+	perPage, _ := vm.PerPage.Get()
+	testutil.AssertEqual(t, "50", perPage)
+	active, _ := creds.ActiveProfile.Get()
+	testutil.AssertEqual(t, "Work", active)
+	testutil.AssertEqual(t, "Work", profiles.Active())
+	items := vm.VisibleRepos()
+	testutil.AssertEqual(t, 3, len(items))
+
+	creds.SwitchProfile("Home")
+	time.Sleep(50 * time.Millisecond)
+
+	items = vm.VisibleRepos()
+	testutil.AssertEqual(t, 0, len(items))
+	token, _ = vm.Token.Get()
+	testutil.AssertEqual(t, "", token)
+}
+
+func TestVM_SwitchProfile_CancelsLoad(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(time.Second):
+			return testdata.SampleRepoList(), nil
+		}
+	}
+	profiles := profile.NewStore(testutil.NewPrefs())
+	testutil.AssertNoError(t, profiles.Save(profile.Profile{Name: "Home", Username: "bob"}))
+
+	creds := credentials.NewVM(func(f func()) { f() }, credentials.WithProfiles(profiles))
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() }, uistars.WithCredentials(creds))
+	_ = vm.Username.Set("alice")
+	vm.Load()
+	time.Sleep(20 * time.Millisecond)
+
+	creds.SwitchProfile("Home")
+	time.Sleep(50 * time.Millisecond)
+
+	loading, _ := vm.Loading.Get()
+	testutil.AssertFalse(t, loading, "switching should stop the load")
+	errMsg, _ := vm.Error.Get()
+	testutil.AssertEqual(t, "", errMsg)
+	items := vm.VisibleRepos()
+	testutil.AssertEqual(t, 0, len(items))
+}
+
+func TestVM_Load_FillsUsernameFromToken(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	gotUser := make(chan string, 1)
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		gotUser <- username
+		return testdata.SampleRepoList(), nil
+	}
+	client := github.NewMockClient()
+	client.GetAuthenticatedUserFunc = func(ctx context.Context, token string) (string, error) {
+		return "octocat", nil
+	}
+
+	creds := credentials.NewVM(func(f func()) { f() }, credentials.WithUserLookup(client))
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() }, uistars.WithCredentials(creds))
+	_ = vm.Token.Set("ghp_secret")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	username, _ := vm.Username.Get()
+	testutil.AssertEqual(t, "octocat", username)
+	testutil.AssertEqual(t, "octocat", <-gotUser)
+	testutil.AssertEqual(t, 1, client.GetAuthenticatedUserCount())
+}
+
+func TestVM_SetHidePrivate(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return append(testdata.SampleRepoList(), testdata.SampleRepoPrivate()), nil
+	}
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() })
+	_ = vm.Username.Set("testuser")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	vm.SetHidePrivate(true)
+	repos := vm.VisibleRepos()
+	testutil.AssertEqual(t, 3, len(repos))
+	for _, repo := range repos {
+		testutil.AssertFalse(t, repo.Private, "private repos should be hidden")
+	}
+
+	vm.SetHidePrivate(false)
+	repos = vm.VisibleRepos()
+	testutil.AssertEqual(t, 4, len(repos))
+}
+
+func TestVM_Load_ChecksTokenOnce(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	client := github.NewMockClient()
+	client.CheckTokenFunc = func(ctx context.Context, token string) (domain.TokenInfo, error) {
+		return domain.TokenInfo{
+			Login:     "octocat",
+			Kind:      domain.TokenClassic,
+			Scopes:    []string{"read:org"},
+			ExpiresAt: time.Now().Add(48 * time.Hour),
+		}, nil
+	}
+
+	creds := credentials.NewVM(func(f func()) { f() }, credentials.WithTokenCheck(client))
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() }, uistars.WithCredentials(creds))
+	_ = vm.Username.Set("octocat")
+	_ = vm.Token.Set("ghp_classic")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	testutil.AssertEqual(t, 1, client.GetCheckTokenCount())
+	check, _ := creds.TokenCheck.Get()
+	testutil.AssertTrue(t, strings.HasPrefix(check, "classic token for octocat"), "unexpected check: "+check)
+	warning, _ := creds.TokenWarning.Get()
+	testutil.AssertTrue(t, strings.Contains(warning, "expires in 2 days"), "expiry should be warned about: "+warning)
+	testutil.AssertTrue(t, strings.Contains(warning, "public_repo"), "missing star scope should be warned about: "+warning)
+}
+
+func TestVM_SetSort(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() })
+	_ = vm.Username.Set("testuser")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	vm.SetSort(2) // Name
+	first, _ := vm.RepoAt(0)
+	testutil.AssertEqual(t, "golang/go", first.FullName)
+	last, _ := vm.RepoAt(2)
+	testutil.AssertEqual(t, "microsoft/vscode", last.FullName)
+
+	_ = vm.Query.Set("lang:go")
+	vm.ApplyFilter()
+	repos := vm.VisibleRepos()
+	testutil.AssertEqual(t, 2, len(repos))
+	_, ok := vm.RepoAt(2)
+	testutil.AssertFalse(t, ok, "filtered-out rows should not be addressable")
+}
+
+func TestVM_AutoRefresh_SyncsOnSchedule(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	scheduler := &schedule.Scheduler{}
+	scheduler.SetSettings(schedule.Settings{Stars: 10 * time.Millisecond})
+
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() }, uistars.WithScheduler(scheduler))
+	vm.StartAutoRefresh()
+	time.Sleep(30 * time.Millisecond)
+
+	summary, _ := vm.AutoRefresh.Get()
+	testutil.AssertTrue(t, strings.Contains(summary, "nothing loaded yet"), "refresh without an account should do nothing: "+summary)
+	testutil.AssertEqual(t, 0, vm.Len())
+
+	_ = vm.Username.Set("testuser")
+	time.Sleep(30 * time.Millisecond)
+	vm.StopAutoRefresh()
+
+	summary, _ = vm.AutoRefresh.Get()
+	testutil.AssertTrue(t, strings.Contains(summary, "3 repos"), "summary should count the synced repos: "+summary)
+	testutil.AssertEqual(t, 3, vm.Len())
+	loading, _ := vm.Loading.Get()
+	testutil.AssertFalse(t, loading, "scheduled refreshes should not show as loading")
+}
+
+func TestVM_Search_IgnoresListFilter(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() })
+	testutil.AssertEqual(t, 0, len(vm.Search("go", 5)))
+
+	_ = vm.Username.Set("testuser")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+	_ = vm.Query.Set("vscode")
+	vm.ApplyFilter()
+
+	matches := vm.Search("lang:go", 5)
+	testutil.AssertEqual(t, 2, len(matches))
+	testutil.AssertEqual(t, "golang/go", matches[0].FullName)
+	testutil.AssertEqual(t, 1, len(vm.Search("", 1)))
+}
+
+func TestVM_Selection_ActsOnSelectedRepo(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() })
+	_ = vm.Username.Set("testuser")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	var copied string
+	vm.CopySelectedURL(func(s string) { copied = s })
+	testutil.AssertEqual(t, "", copied)
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "Select a repo first", status)
+
+	vm.MoveSelection(1)
+	vm.MoveSelection(1)
+	vm.MoveSelection(-5)
+	selected, _ := vm.Selected.Get()
+	testutil.AssertEqual(t, 0, selected)
+	vm.MoveSelection(100)
+	selected, _ = vm.Selected.Get()
+	testutil.AssertEqual(t, vm.Len()-1, selected)
+
+	vm.Select(0)
+	first, _ := vm.RepoAt(0)
+	vm.CopySelectedURL(func(s string) { copied = s })
+	testutil.AssertTrue(t, strings.HasSuffix(copied, first.FullName), "copied URL should point at the selected repo")
+
+	var opened string
+	vm.OpenSelectedInBrowser(func(u *url.URL) error {
+		opened = u.String()
+		return nil
+	})
+	testutil.AssertEqual(t, copied, opened)
+
+	_ = vm.Query.Set("vscode")
+	vm.ApplyFilter()
+	selected, _ = vm.Selected.Get()
+	testutil.AssertEqual(t, -1, selected)
+	testutil.AssertEqual(t, len(testdata.SampleRepoList()), len(vm.Catalog()))
+}
+
+func TestVM_WatchKeymap_BumpsVersion(t *testing.T) {
+	prefs := testutil.NewPrefs()
+	store := keymap.NewStore(prefs)
+	vm := uistars.NewVM(stars.NewMockService(), func(f func()) { f() }, uistars.WithKeymap(store))
+
+	vm.WatchKeymap()
+	testutil.AssertNoError(t, store.SetAll(map[keymap.Action]string{keymap.Reload: "F5"}))
+	version, _ := vm.KeymapVersion.Get()
+	testutil.AssertEqual(t, 1, version)
+
+	vm.UnwatchKeymap()
+	testutil.AssertNoError(t, store.SetAll(map[keymap.Action]string{keymap.Reload: ""}))
+	version, _ = vm.KeymapVersion.Get()
+	testutil.AssertEqual(t, 1, version)
+}
+
+func TestVM_RestoreView_SelectsOnceListed(t *testing.T) {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		return testdata.SampleRepoList(), nil
+	}
+	vm := uistars.NewVM(mockSvc, func(f func()) { f() })
+	vm.RestoreView(uistars.ViewState{Query: "lang:go", Sort: 2, Selected: "kubernetes/kubernetes"})
+	selected, _ := vm.Selected.Get()
+	testutil.AssertEqual(t, -1, selected)
+
+	_ = vm.Username.Set("testuser")
+	vm.Load()
+	time.Sleep(50 * time.Millisecond)
+
+	state := vm.ViewState()
+	testutil.AssertEqual(t, uistars.ViewState{Query: "lang:go", Sort: 2, Selected: "kubernetes/kubernetes"}, state)
+	selected, _ = vm.Selected.Get()
+	testutil.AssertEqual(t, 1, selected)
+
+	vm.RestoreView(uistars.ViewState{Selected: "gone/repo"})
+	selected, _ = vm.Selected.Get()
+	testutil.AssertEqual(t, -1, selected)
+	testutil.AssertEqual(t, 3, vm.Len())
+}
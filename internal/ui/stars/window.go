@@ -4,18 +4,28 @@ import (
 	"fyne.io/fyne/v2"
 
 	appstars "github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/ui/credentials"
 	"github.com/tbxark/gh-stars/internal/ui/route"
 )
 
-func NewStarsWindow(app fyne.App, svc appstars.Loader, router route.Router) fyne.Window {
+// NewStarsWindow also returns the window's VM so the app can sync and search
+// the list from outside it, such as from the tray menu.
+func NewStarsWindow(app fyne.App, svc appstars.Loader, router route.Router, opts ...Option) (fyne.Window, *VM) {
 	w := app.NewWindow("GitHub Stars")
 	w.Resize(fyne.NewSize(1100, 700))
 
-	vm := NewVM(svc, fyne.Do)
+	vm := NewVM(svc, fyne.Do, opts...)
 	w.SetContent(NewView(w, vm, router))
+	vm.Credentials.LoadProfiles()
+	vm.Credentials.DiscoverToken()
+	credentials.AskToUnlock(w, vm.Credentials)
+	vm.StartAutoRefresh()
+	vm.WatchKeymap()
 	w.SetOnClosed(func() {
+		vm.StopAutoRefresh()
+		vm.UnwatchKeymap()
 		vm.Cleanup()
 	})
 
-	return w
+	return w, vm
 }
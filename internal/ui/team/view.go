@@ -0,0 +1,150 @@
+package team
+
+import (
+	"fmt"
+	"strings"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/dialog"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/team"
+	"github.com/tbxark/gh-stars/internal/ui/route"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+func NewView(w fyne.Window, vm *VM, router route.Router) fyne.CanvasObject {
+	title := canvas.NewText("Team Catalog", theme.ForegroundColor())
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = theme.TextHeadingSize()
+
+	subtitle := canvas.NewText("Merged stars of a team, ranked by how many teammates starred each repo.", theme.DisabledColor())
+	subtitle.TextSize = theme.TextSubHeadingSize()
+
+	teamSelect := widget.NewSelect(nil, nil)
+	teamSelect.PlaceHolder = "Saved teams"
+	teamSelect.OnChanged = func(string) {
+		if index := teamSelect.SelectedIndex(); index >= 0 {
+			vm.SelectTeam(index)
+		}
+	}
+	vm.Teams.AddListener(binding.NewDataListener(func() {
+		names, _ := vm.Teams.Get()
+		teamSelect.SetOptions(names)
+	}))
+
+	name := widget.NewEntryWithData(vm.Name)
+	name.SetPlaceHolder("Platform team")
+	org := widget.NewEntryWithData(vm.Org)
+	org.SetPlaceHolder("Optional GitHub org, e.g. golang")
+	usernames := widget.NewEntryWithData(vm.Usernames)
+	usernames.SetPlaceHolder("alice, bob, carol")
+	editor := widget.NewForm(
+		widget.NewFormItem("Team", container.NewBorder(nil, nil, nil, teamSelect, name)),
+		widget.NewFormItem("Org", org),
+		widget.NewFormItem("Users", usernames),
+	)
+
+	buildBtn := widget.NewButtonWithIcon("Build", theme.DownloadIcon(), nil)
+	buildBtn.OnTapped = func() {
+		if building, _ := vm.Building.Get(); building {
+			vm.StopBuild()
+			return
+		}
+		vm.Build()
+	}
+	vm.Building.AddListener(binding.NewDataListener(func() {
+		building, _ := vm.Building.Get()
+		if building {
+			buildBtn.SetText("Stop")
+			buildBtn.SetIcon(theme.MediaStopIcon())
+		} else {
+			buildBtn.SetText("Build")
+			buildBtn.SetIcon(theme.DownloadIcon())
+		}
+	}))
+	saveBtn := widget.NewButtonWithIcon("Save", theme.DocumentSaveIcon(), vm.SaveTeam)
+	deleteBtn := widget.NewButtonWithIcon("Delete", theme.DeleteIcon(), func() {
+		name, _ := vm.Name.Get()
+		if name == "" {
+			return
+		}
+		dialog.ShowConfirm("Delete team", fmt.Sprintf("Delete %q and its saved catalog?", name), func(ok bool) {
+			if ok {
+				vm.DeleteTeam()
+			}
+		}, w)
+	})
+
+	actionBar := container.NewHBox(layout.NewSpacer(), buildBtn, saveBtn, deleteBtn)
+	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+
+	sortOptions := make([]string, len(team.SortKeys))
+	for i, key := range team.SortKeys {
+		sortOptions[i] = "Sort: " + key.String()
+	}
+	sortSelect := widget.NewSelect(sortOptions, nil)
+	sortSelect.SetSelectedIndex(0)
+	sortSelect.OnChanged = func(string) { vm.SetSort(sortSelect.SelectedIndex()) }
+
+	filter := widget.NewEntryWithData(vm.Query)
+	filter.SetPlaceHolder("Filter: words, lang:go, topic:cli, owner:golang")
+	vm.Query.AddListener(binding.NewDataListener(vm.ApplyFilter))
+
+	summary := widget.NewLabelWithData(vm.Summary)
+	summary.Importance = widget.LowImportance
+	summary.Wrapping = fyne.TextWrapWord
+
+	list := widget.NewListWithData(vm.Entries, func() fyne.CanvasObject {
+		name := widget.NewLabel("")
+		name.Wrapping = fyne.TextTruncate
+		count := widget.NewLabel("")
+		count.Alignment = fyne.TextAlignTrailing
+		who := widget.NewLabel("")
+		who.Wrapping = fyne.TextTruncate
+		who.Importance = widget.LowImportance
+		return container.NewGridWithColumns(3, name, who, count)
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[compare.Entry])
+		if !ok {
+			return
+		}
+		entry, err := item.Get()
+		if err != nil {
+			return
+		}
+		row := obj.(*fyne.Container)
+		row.Objects[0].(*widget.Label).SetText(entry.Repo.FullName)
+		row.Objects[1].(*widget.Label).SetText(strings.Join(entry.StarredBy, ", "))
+		row.Objects[2].(*widget.Label).SetText(fmt.Sprintf("%d teammates · %d ★", len(entry.StarredBy), entry.Repo.Stars))
+	})
+	list.OnSelected = func(id widget.ListItemID) {
+		if entry, err := vm.Entries.GetValue(id); err == nil && router != nil {
+			router.ShowRepoDetails(entry.Repo.FullName, vm.Token)
+		}
+		list.Unselect(id)
+	}
+
+	progress := widget.NewProgressBarWithData(vm.Progress)
+	progress.Hide()
+	vm.Building.AddListener(binding.NewDataListener(func() {
+		if building, _ := vm.Building.Get(); building {
+			progress.Show()
+		} else {
+			progress.Hide()
+		}
+	}))
+
+	listTop := container.NewVBox(container.NewGridWithColumns(2, sortSelect, filter), summary, progress)
+	listCard := widget.NewCard("", "Select a repo to open details.", container.NewBorder(listTop, nil, nil, nil, list))
+
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Building)
+	top := container.NewPadded(container.NewVBox(header, widget.NewSeparator(), editor))
+	return container.NewBorder(top, container.NewPadded(statusBar), nil, nil, container.NewPadded(listCard))
+}
@@ -0,0 +1,319 @@
+package team
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/team"
+)
+
+type VM struct {
+	Token string
+
+	Teams binding.StringList
+
+	Name      binding.String
+	Org       binding.String
+	Usernames binding.String
+
+	Building binding.Bool
+	Progress binding.Float
+	Status   binding.String
+	Error    binding.String
+
+	SortIndex binding.Int
+	Query     binding.String
+	Entries   binding.List[compare.Entry]
+	Summary   binding.String
+
+	builder   *team.Builder
+	jobs      *jobs.Manager
+	runOnMain func(func())
+
+	mu      sync.Mutex
+	cancel  context.CancelFunc
+	teams   []team.Team
+	catalog team.Catalog
+}
+
+// Option configures optional VM collaborators.
+type Option func(*VM)
+
+// WithJobs lists catalog builds in the shared activity panel.
+func WithJobs(manager *jobs.Manager) Option {
+	return func(vm *VM) {
+		vm.jobs = manager
+	}
+}
+
+func NewVM(builder *team.Builder, token string, runOnMain func(func()), opts ...Option) *VM {
+	vm := &VM{
+		Token:     token,
+		Teams:     binding.NewStringList(),
+		Name:      binding.NewString(),
+		Org:       binding.NewString(),
+		Usernames: binding.NewString(),
+		Building:  binding.NewBool(),
+		Progress:  binding.NewFloat(),
+		Status:    binding.NewString(),
+		Error:     binding.NewString(),
+		SortIndex: binding.NewInt(),
+		Query:     binding.NewString(),
+		Entries: binding.NewList(func(a, b compare.Entry) bool {
+			return a.Repo == b.Repo && slices.Equal(a.StarredBy, b.StarredBy)
+		}),
+		Summary:   binding.NewString(),
+		builder:   builder,
+		runOnMain: runOnMain,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	for _, opt := range opts {
+		opt(vm)
+	}
+	_ = vm.Status.Set("Ready")
+	return vm
+}
+
+// Load lists the saved teams and opens the first one.
+func (vm *VM) Load() {
+	teams, err := vm.builder.Store.Teams()
+	if err != nil {
+		vm.fail(err)
+		return
+	}
+	vm.setTeams(teams)
+	if len(teams) > 0 {
+		vm.SelectTeam(0)
+	}
+}
+
+// SelectTeam fills the editor with the team at index and shows its last
+// built catalog, if any.
+func (vm *VM) SelectTeam(index int) {
+	vm.mu.Lock()
+	if index < 0 || index >= len(vm.teams) {
+		vm.mu.Unlock()
+		return
+	}
+	t := vm.teams[index]
+	vm.mu.Unlock()
+
+	catalog, ok, err := vm.builder.Store.LoadCatalog(t.Name)
+	vm.runOnMain(func() {
+		_ = vm.Name.Set(t.Name)
+		_ = vm.Org.Set(t.Org)
+		_ = vm.Usernames.Set(strings.Join(t.Usernames, ", "))
+		_ = vm.Error.Set("")
+		switch {
+		case err != nil:
+			_ = vm.Error.Set(err.Error())
+			vm.showCatalog(team.Catalog{Team: t})
+		case ok:
+			vm.showCatalog(catalog)
+			_ = vm.Status.Set("Loaded saved catalog")
+		default:
+			vm.showCatalog(team.Catalog{Team: t})
+			_ = vm.Status.Set("Not built yet")
+		}
+	})
+}
+
+// SaveTeam stores the team being edited.
+func (vm *VM) SaveTeam() {
+	t := vm.editedTeam()
+	if err := vm.builder.Store.SaveTeam(t); err != nil {
+		vm.fail(err)
+		return
+	}
+	teams, err := vm.builder.Store.Teams()
+	if err != nil {
+		vm.fail(err)
+		return
+	}
+	vm.setTeams(teams)
+	vm.runOnMain(func() {
+		_ = vm.Error.Set("")
+		_ = vm.Status.Set("Saved team " + t.Name)
+	})
+}
+
+// DeleteTeam removes the team being edited and its saved catalog.
+func (vm *VM) DeleteTeam() {
+	name, _ := vm.Name.Get()
+	if err := vm.builder.Store.DeleteTeam(name); err != nil {
+		vm.fail(err)
+		return
+	}
+	teams, err := vm.builder.Store.Teams()
+	if err != nil {
+		vm.fail(err)
+		return
+	}
+	vm.setTeams(teams)
+	vm.runOnMain(func() {
+		_ = vm.Name.Set("")
+		_ = vm.Org.Set("")
+		_ = vm.Usernames.Set("")
+		vm.showCatalog(team.Catalog{})
+		_ = vm.Status.Set("Deleted team " + name)
+	})
+}
+
+// Build saves the team being edited and builds its catalog in the
+// background.
+func (vm *VM) Build() {
+	t := vm.editedTeam()
+	if t.Name == "" {
+		vm.fail(errors.New("team name is required"))
+		return
+	}
+	vm.SaveTeam()
+
+	vm.mu.Lock()
+	if vm.cancel != nil {
+		vm.cancel()
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	job := vm.jobs.Register("Build team "+t.Name, cancel)
+	vm.cancel = job.Cancel
+	vm.mu.Unlock()
+
+	vm.runOnMain(func() {
+		_ = vm.Building.Set(true)
+		_ = vm.Progress.Set(0)
+		_ = vm.Error.Set("")
+		_ = vm.Status.Set("Building catalog...")
+	})
+
+	go func() {
+		catalog, err := vm.builder.Build(ctx, t, vm.Token, func(p team.Progress) {
+			status := fmt.Sprintf("Loaded %d of %d members", p.Done+p.Failed, p.Total)
+			job.Progress(p.Done+p.Failed, p.Total, status)
+			vm.runOnMain(func() {
+				if p.Total > 0 {
+					_ = vm.Progress.Set(float64(p.Done+p.Failed) / float64(p.Total))
+				}
+				_ = vm.Status.Set(status)
+			})
+		})
+		job.Finish(fmt.Sprintf("%d repos", len(catalog.Entries)), err)
+		vm.runOnMain(func() {
+			_ = vm.Building.Set(false)
+			switch {
+			case errors.Is(err, context.Canceled):
+				_ = vm.Status.Set("Build stopped")
+			case err != nil:
+				_ = vm.Error.Set(err.Error())
+				_ = vm.Status.Set("Build failed")
+			default:
+				vm.showCatalog(catalog)
+				_ = vm.Status.Set("Catalog built")
+			}
+		})
+	}()
+}
+
+// StopBuild cancels a running build.
+func (vm *VM) StopBuild() {
+	vm.mu.Lock()
+	if vm.cancel != nil {
+		vm.cancel()
+		vm.cancel = nil
+	}
+	vm.mu.Unlock()
+}
+
+// SetSort reorders the catalog by the key at index of team.SortKeys.
+func (vm *VM) SetSort(index int) {
+	_ = vm.SortIndex.Set(index)
+	vm.ApplyFilter()
+}
+
+// ApplyFilter shows the catalog sorted and narrowed by Query, which accepts
+// the same syntax as the stars list filter.
+func (vm *VM) ApplyFilter() {
+	index, _ := vm.SortIndex.Get()
+	query, _ := vm.Query.Get()
+
+	vm.mu.Lock()
+	entries := slices.Clone(vm.catalog.Entries)
+	vm.mu.Unlock()
+
+	q := stars.ParseQuery(query)
+	if !q.IsEmpty() {
+		entries = slices.DeleteFunc(entries, func(e compare.Entry) bool { return !q.Match(e.Repo, nil) })
+	}
+	key := team.SortTeamStars
+	if index >= 0 && index < len(team.SortKeys) {
+		key = team.SortKeys[index]
+	}
+	team.SortEntries(entries, key)
+	_ = vm.Entries.Set(entries)
+}
+
+func (vm *VM) Cleanup() {
+	vm.StopBuild()
+}
+
+func (vm *VM) showCatalog(catalog team.Catalog) {
+	vm.mu.Lock()
+	vm.catalog = catalog
+	vm.mu.Unlock()
+
+	_ = vm.Summary.Set(describeCatalog(catalog))
+	vm.ApplyFilter()
+}
+
+func (vm *VM) setTeams(teams []team.Team) {
+	names := make([]string, len(teams))
+	for i, t := range teams {
+		names[i] = t.Name
+	}
+	vm.mu.Lock()
+	vm.teams = teams
+	vm.mu.Unlock()
+	vm.runOnMain(func() {
+		_ = vm.Teams.Set(names)
+	})
+}
+
+func (vm *VM) editedTeam() team.Team {
+	name, _ := vm.Name.Get()
+	org, _ := vm.Org.Get()
+	usernames, _ := vm.Usernames.Get()
+	return team.Team{
+		Name:      strings.TrimSpace(name),
+		Org:       strings.TrimSpace(org),
+		Usernames: compare.UniqueNames(strings.Split(usernames, ",")),
+	}
+}
+
+func (vm *VM) fail(err error) {
+	vm.runOnMain(func() {
+		_ = vm.Error.Set(err.Error())
+		_ = vm.Status.Set("Failed")
+	})
+}
+
+func describeCatalog(catalog team.Catalog) string {
+	if catalog.BuiltAt.IsZero() {
+		return ""
+	}
+	summary := fmt.Sprintf("%d members, %d repos, built %s",
+		len(catalog.Members), len(catalog.Entries), catalog.BuiltAt.Local().Format("2006-01-02 15:04"))
+	if len(catalog.Failed) > 0 {
+		summary += fmt.Sprintf("; could not load %s", strings.Join(catalog.Failed, ", "))
+	}
+	return summary
+}
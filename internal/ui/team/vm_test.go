@@ -0,0 +1,77 @@
+package team_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/team"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/domain/testdata"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	uiteam "github.com/tbxark/gh-stars/internal/ui/team"
+)
+
+func newBuilder(t *testing.T) *team.Builder {
+	mockSvc := stars.NewMockService()
+	mockSvc.LoadStarredFunc = func(ctx context.Context, username, token string, perPage int) ([]domain.Repo, error) {
+		list := testdata.SampleRepoList()
+		if username == "bob" {
+			return list[:1], nil
+		}
+		return list, nil
+	}
+	return &team.Builder{Stars: mockSvc, Store: team.NewStore(t.TempDir())}
+}
+
+func TestVM_Build_SavesTeamAndShowsCatalog(t *testing.T) {
+	_ = test.NewApp()
+	builder := newBuilder(t)
+	vm := uiteam.NewVM(builder, "", func(f func()) { f() })
+	_ = vm.Name.Set("Core")
+	_ = vm.Usernames.Set("alice, bob")
+
+	vm.Build()
+	time.Sleep(50 * time.Millisecond)
+
+	names, _ := vm.Teams.Get()
+	testutil.AssertEqual(t, 1, len(names))
+	entries, _ := vm.Entries.Get()
+	testutil.AssertEqual(t, 3, len(entries))
+	testutil.AssertEqual(t, "golang/go", entries[0].Repo.FullName)
+	summary, _ := vm.Summary.Get()
+	testutil.AssertTrue(t, summary != "", "summary should describe the catalog")
+
+	vm.SetSort(1)
+	entries, _ = vm.Entries.Get()
+	testutil.AssertEqual(t, "microsoft/vscode", entries[0].Repo.FullName)
+}
+
+func TestVM_Load_RestoresSavedCatalog(t *testing.T) {
+	_ = test.NewApp()
+	builder := newBuilder(t)
+	_, err := builder.Build(context.Background(), team.Team{Name: "Core", Usernames: []string{"alice"}}, "", nil)
+	testutil.AssertNoError(t, err)
+	testutil.AssertNoError(t, builder.Store.SaveTeam(team.Team{Name: "Core", Usernames: []string{"alice"}}))
+
+	vm := uiteam.NewVM(builder, "", func(f func()) { f() })
+	vm.Load()
+
+	name, _ := vm.Name.Get()
+	testutil.AssertEqual(t, "Core", name)
+	entries, _ := vm.Entries.Get()
+	testutil.AssertEqual(t, 3, len(entries))
+}
+
+func TestVM_Build_RequiresName(t *testing.T) {
+	_ = test.NewApp()
+	vm := uiteam.NewVM(newBuilder(t), "", func(f func()) { f() })
+
+	vm.Build()
+
+	errMsg, _ := vm.Error.Get()
+	testutil.AssertEqual(t, "team name is required", errMsg)
+}
@@ -0,0 +1,22 @@
+package team
+
+import (
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/team"
+	"github.com/tbxark/gh-stars/internal/ui/route"
+)
+
+func NewTeamWindow(app fyne.App, builder *team.Builder, token string, router route.Router, opts ...Option) fyne.Window {
+	w := app.NewWindow("Team Catalog")
+	w.Resize(fyne.NewSize(1000, 700))
+
+	vm := NewVM(builder, token, fyne.Do, opts...)
+	w.SetContent(NewView(w, vm, router))
+	w.SetOnClosed(func() {
+		vm.Cleanup()
+	})
+	vm.Load()
+
+	return w
+}
@@ -0,0 +1,114 @@
+package updates
+
+import (
+	"net/url"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/container"
+	"fyne.io/fyne/v2/data/binding"
+	"fyne.io/fyne/v2/layout"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+
+	"github.com/tbxark/gh-stars/internal/app/releases"
+	"github.com/tbxark/gh-stars/internal/ui/widgets"
+)
+
+func NewView(w fyne.Window, vm *VM) fyne.CanvasObject {
+	title := canvas.NewText("Updates", theme.ForegroundColor())
+	title.TextStyle = fyne.TextStyle{Bold: true}
+	title.TextSize = theme.TextHeadingSize()
+
+	subtitle := canvas.NewText("New releases of your starred repositories.", theme.DisabledColor())
+	subtitle.TextSize = theme.TextSubHeadingSize()
+
+	checkBtn := widget.NewButtonWithIcon("Check Now", theme.ViewRefreshIcon(), vm.CheckNow)
+	seenBtn := widget.NewButtonWithIcon("Mark Seen", theme.ConfirmIcon(), vm.MarkSeen)
+	allSeenBtn := widget.NewButtonWithIcon("Mark All Seen", theme.VisibilityIcon(), vm.MarkAllSeen)
+
+	vm.Checking.AddListener(binding.NewDataListener(func() {
+		checking, _ := vm.Checking.Get()
+		if checking {
+			checkBtn.Disable()
+		} else {
+			checkBtn.Enable()
+		}
+	}))
+
+	actionBar := container.NewHBox(layout.NewSpacer(), checkBtn, seenBtn, allSeenBtn)
+	header := container.NewBorder(nil, nil, nil, actionBar, container.NewVBox(title, subtitle))
+
+	subset := widget.NewEntryWithData(vm.Subset)
+	subset.SetPlaceHolder("Watch only these repos, e.g. golang/go, fyne-io/fyne (empty watches all)")
+	saveSubset := widget.NewButton("Save", vm.SaveSubset)
+	enabled := widget.NewCheck("Check hourly", func(on bool) {
+		if current, _ := vm.Enabled.Get(); current != on {
+			vm.SetEnabled(on)
+		}
+	})
+	vm.Enabled.AddListener(binding.NewDataListener(func() {
+		on, _ := vm.Enabled.Get()
+		enabled.SetChecked(on)
+	}))
+	subsetRow := container.NewBorder(nil, nil, enabled, saveSubset, subset)
+
+	list := widget.NewListWithData(vm.Updates, func() fyne.CanvasObject {
+		name := widget.NewLabel("")
+		name.Wrapping = fyne.TextTruncate
+		tag := widget.NewLabel("")
+		tag.Importance = widget.LowImportance
+		return container.NewBorder(nil, nil, nil, tag, name)
+	}, func(di binding.DataItem, obj fyne.CanvasObject) {
+		item, ok := di.(binding.Item[releases.Update])
+		if !ok {
+			return
+		}
+		update, err := item.Get()
+		if err != nil {
+			return
+		}
+		row := obj.(*fyne.Container)
+		row.Objects[0].(*widget.Label).SetText(update.FullName)
+		row.Objects[1].(*widget.Label).SetText(update.Release.Tag)
+	})
+	list.OnSelected = vm.Select
+
+	notesTitle := widget.NewLabelWithData(vm.SelectedTitle)
+	notesTitle.TextStyle = fyne.TextStyle{Bold: true}
+	notesTitle.Wrapping = fyne.TextWrapWord
+	notes := widget.NewRichTextFromMarkdown("")
+	notes.Wrapping = fyne.TextWrapWord
+	vm.SelectedNotes.AddListener(binding.NewDataListener(func() {
+		text, _ := vm.SelectedNotes.Get()
+		notes.ParseMarkdown(text)
+	}))
+	openBtn := widget.NewButtonWithIcon("Open in Browser", theme.NavigateNextIcon(), func() {
+		urlStr, _ := vm.SelectedURL.Get()
+		if urlStr == "" {
+			return
+		}
+		parsed, err := url.Parse(urlStr)
+		if err != nil {
+			return
+		}
+		_ = fyne.CurrentApp().OpenURL(parsed)
+	})
+	detail := container.NewBorder(
+		notesTitle,
+		container.NewHBox(layout.NewSpacer(), openBtn),
+		nil,
+		nil,
+		container.NewVScroll(notes),
+	)
+
+	split := container.NewHSplit(
+		widget.NewCard("", "Select an update to read its notes.", list),
+		widget.NewCard("", "", detail),
+	)
+	split.Offset = 0.35
+
+	statusBar := widgets.NewStatusPanel(vm.Status, vm.Error, vm.Checking)
+	top := container.NewPadded(container.NewVBox(header, widget.NewSeparator(), subsetRow))
+	return container.NewBorder(top, container.NewPadded(statusBar), nil, nil, container.NewPadded(split))
+}
@@ -0,0 +1,242 @@
+package updates
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"fyne.io/fyne/v2/data/binding"
+
+	"github.com/tbxark/gh-stars/internal/app/releases"
+)
+
+type VM struct {
+	Checking binding.Bool
+	Status   binding.String
+	Error    binding.String
+
+	Updates binding.List[releases.Update]
+
+	SelectedTitle binding.String
+	SelectedNotes binding.String
+	SelectedURL   binding.String
+
+	// Subset is a comma-separated list of owner/name; empty watches every
+	// starred repo.
+	Subset binding.String
+	// Enabled is whether repos are checked in the background.
+	Enabled binding.Bool
+
+	poller    *releases.Poller
+	runOnMain func(func())
+
+	mu          sync.Mutex
+	updates     []releases.Update
+	selected    int
+	unsubscribe func()
+}
+
+func NewVM(poller *releases.Poller, runOnMain func(func())) *VM {
+	vm := &VM{
+		Checking:      binding.NewBool(),
+		Status:        binding.NewString(),
+		Error:         binding.NewString(),
+		Updates:       binding.NewList(func(a, b releases.Update) bool { return a == b }),
+		SelectedTitle: binding.NewString(),
+		SelectedNotes: binding.NewString(),
+		SelectedURL:   binding.NewString(),
+		Subset:        binding.NewString(),
+		Enabled:       binding.NewBool(),
+		poller:        poller,
+		runOnMain:     runOnMain,
+		selected:      -1,
+	}
+	if vm.runOnMain == nil {
+		vm.runOnMain = func(f func()) { f() }
+	}
+	_ = vm.Status.Set("Ready")
+	_ = vm.Subset.Set(strings.Join(poller.Watcher.Store.Subset(), ", "))
+	_ = vm.Enabled.Set(poller.Watcher.Store.Enabled())
+	return vm
+}
+
+// Load shows the pending updates and keeps them current while the poller
+// runs in the background.
+func (vm *VM) Load() {
+	vm.mu.Lock()
+	if vm.unsubscribe == nil {
+		vm.unsubscribe = vm.poller.Subscribe(vm.refresh)
+	}
+	vm.mu.Unlock()
+	vm.refresh()
+}
+
+// CheckNow asks the poller for an immediate pass.
+func (vm *VM) CheckNow() {
+	if !vm.poller.Watcher.Store.Enabled() {
+		vm.runOnMain(func() {
+			_ = vm.Status.Set("Turn on release checks first")
+		})
+		return
+	}
+	if vm.poller.Status().LastAt.IsZero() && !vm.poller.Status().Checking {
+		vm.runOnMain(func() {
+			_ = vm.Status.Set("Load your stars first; checks start after the first sync")
+		})
+	}
+	vm.poller.CheckNow()
+}
+
+// Select shows the release notes of the update at index.
+func (vm *VM) Select(index int) {
+	vm.mu.Lock()
+	if index < 0 || index >= len(vm.updates) {
+		vm.mu.Unlock()
+		return
+	}
+	vm.selected = index
+	update := vm.updates[index]
+	vm.mu.Unlock()
+
+	vm.runOnMain(func() {
+		vm.showSelected(update)
+	})
+}
+
+// MarkSeen acknowledges the selected update.
+func (vm *VM) MarkSeen() {
+	vm.mu.Lock()
+	if vm.selected < 0 || vm.selected >= len(vm.updates) {
+		vm.mu.Unlock()
+		return
+	}
+	fullName := vm.updates[vm.selected].FullName
+	vm.mu.Unlock()
+
+	store := vm.poller.Watcher.Store
+	store.MarkSeen(fullName)
+	vm.save(store)
+	vm.refresh()
+}
+
+func (vm *VM) MarkAllSeen() {
+	store := vm.poller.Watcher.Store
+	store.MarkAllSeen()
+	vm.save(store)
+	vm.refresh()
+}
+
+// SetEnabled turns background release checks on or off.
+func (vm *VM) SetEnabled(enabled bool) {
+	err := vm.poller.SetEnabled(enabled)
+	vm.runOnMain(func() {
+		_ = vm.Enabled.Set(enabled)
+		if err != nil {
+			_ = vm.Error.Set(err.Error())
+		}
+		if !enabled {
+			_ = vm.Status.Set("Release checks are off")
+		}
+	})
+}
+
+// SaveSubset stores the watch list typed into Subset. It applies from the
+// next pass.
+func (vm *VM) SaveSubset() {
+	value, _ := vm.Subset.Get()
+	store := vm.poller.Watcher.Store
+	store.SetSubset(strings.Split(value, ","))
+	vm.save(store)
+	vm.runOnMain(func() {
+		if len(store.Subset()) == 0 {
+			_ = vm.Status.Set("Watching every starred repo")
+		} else {
+			_ = vm.Status.Set(fmt.Sprintf("Watching %d repo(s)", len(store.Subset())))
+		}
+	})
+}
+
+func (vm *VM) Cleanup() {
+	vm.mu.Lock()
+	if vm.unsubscribe != nil {
+		vm.unsubscribe()
+		vm.unsubscribe = nil
+	}
+	vm.mu.Unlock()
+}
+
+func (vm *VM) refresh() {
+	updates := vm.poller.Watcher.Store.Updates()
+	status := vm.poller.Status()
+
+	vm.mu.Lock()
+	vm.updates = updates
+	if vm.selected >= len(updates) {
+		vm.selected = len(updates) - 1
+	}
+	selected := vm.selected
+	vm.mu.Unlock()
+
+	vm.runOnMain(func() {
+		_ = vm.Updates.Set(updates)
+		_ = vm.Checking.Set(status.Checking)
+		_ = vm.Status.Set(describeStatus(status, len(updates)))
+		if status.Err != nil {
+			_ = vm.Error.Set(status.Err.Error())
+		} else {
+			_ = vm.Error.Set("")
+		}
+		if selected >= 0 {
+			vm.showSelected(updates[selected])
+		} else {
+			vm.showSelected(releases.Update{})
+		}
+	})
+}
+
+func (vm *VM) showSelected(update releases.Update) {
+	if update.FullName == "" {
+		_ = vm.SelectedTitle.Set("")
+		_ = vm.SelectedNotes.Set("")
+		_ = vm.SelectedURL.Set("")
+		return
+	}
+	_ = vm.SelectedTitle.Set(describeUpdate(update))
+	notes := update.Release.Notes
+	if strings.TrimSpace(notes) == "" {
+		notes = "_No release notes._"
+	}
+	_ = vm.SelectedNotes.Set(notes)
+	_ = vm.SelectedURL.Set(update.Release.HTMLURL)
+}
+
+func (vm *VM) save(store *releases.Store) {
+	if err := store.Save(); err != nil {
+		vm.runOnMain(func() {
+			_ = vm.Error.Set(err.Error())
+		})
+	}
+}
+
+func describeUpdate(update releases.Update) string {
+	title := update.FullName + " " + update.Release.Tag
+	if update.Previous != "" {
+		title += " (was " + update.Previous + ")"
+	}
+	return title
+}
+
+func describeStatus(status releases.Status, pending int) string {
+	switch {
+	case status.Checking:
+		return "Checking for new releases..."
+	case status.LastAt.IsZero():
+		return fmt.Sprintf("%d new release(s)", pending)
+	}
+	msg := fmt.Sprintf("%d new release(s); last checked %s (%d repos", pending, status.LastAt.Format(time.Kitchen), status.Last.Checked)
+	if status.Last.Failed > 0 {
+		msg += fmt.Sprintf(", %d failed", status.Last.Failed)
+	}
+	return msg + ")"
+}
@@ -0,0 +1,102 @@
+package updates_test
+
+import (
+	"testing"
+	"time"
+
+	"fyne.io/fyne/v2/test"
+
+	"github.com/tbxark/gh-stars/internal/app/releases"
+	"github.com/tbxark/gh-stars/internal/domain"
+	"github.com/tbxark/gh-stars/internal/github"
+	"github.com/tbxark/gh-stars/internal/testutil"
+	"github.com/tbxark/gh-stars/internal/ui/updates"
+)
+
+func newPoller(entries ...releases.Entry) *releases.Poller {
+	store := releases.NewStore("")
+	for _, entry := range entries {
+		store.Put(entry)
+	}
+	return &releases.Poller{Watcher: &releases.Watcher{GH: github.NewMockClient(), Store: store}}
+}
+
+func TestVM_Load_ListsUnseenReleases(t *testing.T) {
+	_ = test.NewApp()
+	poller := newPoller(
+		releases.Entry{
+			FullName: "golang/go",
+			Latest:   domain.Release{Tag: "go1.22.1", Notes: "Security fixes", PublishedAt: time.Date(2024, 3, 5, 0, 0, 0, 0, time.UTC)},
+			Seen:     "go1.22.0",
+		},
+		releases.Entry{FullName: "microsoft/vscode", Latest: domain.Release{Tag: "1.87.0"}, Seen: "1.87.0"},
+	)
+	vm := updates.NewVM(poller, func(f func()) { f() })
+	defer vm.Cleanup()
+
+	vm.Load()
+	vm.Select(0)
+
+	items, _ := vm.Updates.Get()
+	testutil.AssertEqual(t, 1, len(items))
+	title, _ := vm.SelectedTitle.Get()
+	testutil.AssertEqual(t, "golang/go go1.22.1 (was go1.22.0)", title)
+	notes, _ := vm.SelectedNotes.Get()
+	testutil.AssertEqual(t, "Security fixes", notes)
+}
+
+func TestVM_MarkSeen_RemovesUpdate(t *testing.T) {
+	_ = test.NewApp()
+	poller := newPoller(
+		releases.Entry{FullName: "golang/go", Latest: domain.Release{Tag: "go1.22.1"}, Seen: "go1.22.0"},
+		releases.Entry{FullName: "fyne-io/fyne", Latest: domain.Release{Tag: "v2.5.0"}, Seen: "v2.4.5"},
+	)
+	vm := updates.NewVM(poller, func(f func()) { f() })
+	defer vm.Cleanup()
+
+	vm.Load()
+	vm.Select(0)
+	vm.MarkSeen()
+
+	items, _ := vm.Updates.Get()
+	testutil.AssertEqual(t, 1, len(items))
+
+	vm.MarkAllSeen()
+
+	items, _ = vm.Updates.Get()
+	testutil.AssertEqual(t, 0, len(items))
+	title, _ := vm.SelectedTitle.Get()
+	testutil.AssertEqual(t, "", title)
+}
+
+func TestVM_SaveSubset(t *testing.T) {
+	_ = test.NewApp()
+	poller := newPoller()
+	vm := updates.NewVM(poller, func(f func()) { f() })
+
+	_ = vm.Subset.Set("golang/go, fyne-io/fyne,")
+	vm.SaveSubset()
+
+	testutil.AssertEqual(t, 2, len(poller.Watcher.Store.Subset()))
+	testutil.AssertTrue(t, poller.Watcher.Store.Watched("FYNE-IO/fyne"), "subset should be case-insensitive")
+	testutil.AssertFalse(t, poller.Watcher.Store.Watched("microsoft/vscode"), "other repos should not be watched")
+}
+
+func TestVM_SetEnabled(t *testing.T) {
+	_ = test.NewApp()
+	poller := newPoller()
+	defer poller.Stop()
+	vm := updates.NewVM(poller, func(f func()) { f() })
+
+	vm.CheckNow()
+	status, _ := vm.Status.Get()
+	testutil.AssertEqual(t, "Turn on release checks first", status)
+
+	vm.SetEnabled(true)
+	enabled, _ := vm.Enabled.Get()
+	testutil.AssertTrue(t, enabled, "checks should be on")
+	testutil.AssertTrue(t, poller.Watcher.Store.Enabled(), "the store should keep the choice")
+
+	vm.SetEnabled(false)
+	testutil.AssertFalse(t, poller.Watcher.Store.Enabled(), "checks should be off")
+}
@@ -0,0 +1,21 @@
+package updates
+
+import (
+	"fyne.io/fyne/v2"
+
+	"github.com/tbxark/gh-stars/internal/app/releases"
+)
+
+func NewUpdatesWindow(app fyne.App, poller *releases.Poller) fyne.Window {
+	w := app.NewWindow("Updates")
+	w.Resize(fyne.NewSize(1000, 650))
+
+	vm := NewVM(poller, fyne.Do)
+	w.SetContent(NewView(w, vm))
+	w.SetOnClosed(func() {
+		vm.Cleanup()
+	})
+	vm.Load()
+
+	return w
+}
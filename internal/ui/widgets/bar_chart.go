@@ -0,0 +1,147 @@
+package widgets
+
+import (
+	"image/color"
+
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// BarChart is a compact chart drawn with canvas rectangles.
+// Values in the up series grow above a shared baseline and values in the
+// optional down series grow below it, so the same widget works as a plain
+// sparkline and as an additions/deletions chart.
+type BarChart struct {
+	widget.BaseWidget
+
+	UpColor   fyne.ThemeColorName
+	DownColor fyne.ThemeColorName
+	Height    float32
+
+	up   []float64
+	down []float64
+}
+
+// NewBarChart creates an empty chart using the given theme colors.
+func NewBarChart(upColor, downColor fyne.ThemeColorName) *BarChart {
+	c := &BarChart{UpColor: upColor, DownColor: downColor, Height: 60}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// SetData replaces the plotted series. Pass nil for down to draw a sparkline.
+// Must be called on the main thread.
+func (c *BarChart) SetData(up, down []float64) {
+	c.up = up
+	c.down = down
+	c.Refresh()
+}
+
+func (c *BarChart) CreateRenderer() fyne.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+	baseline := canvas.NewLine(theme.Color(theme.ColorNameSeparator))
+	empty := canvas.NewText("No data", theme.Color(theme.ColorNameDisabled))
+	empty.Alignment = fyne.TextAlignCenter
+	r := &barChartRenderer{chart: c, baseline: baseline, empty: empty}
+	r.Refresh()
+	return r
+}
+
+type barChartRenderer struct {
+	chart    *BarChart
+	baseline *canvas.Line
+	empty    *canvas.Text
+	up       []*canvas.Rectangle
+	down     []*canvas.Rectangle
+	objects  []fyne.CanvasObject
+}
+
+func (r *barChartRenderer) Destroy() {}
+
+func (r *barChartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(120, r.chart.Height)
+}
+
+func (r *barChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *barChartRenderer) Refresh() {
+	r.up = syncBars(r.up, len(r.chart.up), theme.Color(r.chart.UpColor))
+	r.down = syncBars(r.down, len(r.chart.down), theme.Color(r.chart.DownColor))
+	r.baseline.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	r.empty.Color = theme.Color(theme.ColorNameDisabled)
+	r.empty.Hidden = len(r.chart.up) > 0 || len(r.chart.down) > 0
+
+	r.objects = r.objects[:0]
+	r.objects = append(r.objects, r.baseline, r.empty)
+	for _, bar := range r.up {
+		r.objects = append(r.objects, bar)
+	}
+	for _, bar := range r.down {
+		r.objects = append(r.objects, bar)
+	}
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *barChartRenderer) Layout(size fyne.Size) {
+	r.empty.Resize(size)
+	r.empty.Move(fyne.NewPos(0, (size.Height-r.empty.MinSize().Height)/2))
+
+	maxUp := maxValue(r.chart.up)
+	maxDown := maxValue(r.chart.down)
+	baseY := size.Height
+	if maxUp+maxDown > 0 && maxDown > 0 {
+		baseY = size.Height * float32(maxUp/(maxUp+maxDown))
+	}
+	r.baseline.Position1 = fyne.NewPos(0, baseY)
+	r.baseline.Position2 = fyne.NewPos(size.Width, baseY)
+
+	count := max(len(r.chart.up), len(r.chart.down))
+	if count == 0 {
+		return
+	}
+	slot := size.Width / float32(count)
+	gap := slot * 0.2
+	if gap > 2 {
+		gap = 2
+	}
+	scale := float32(0)
+	if maxUp+maxDown > 0 {
+		scale = size.Height / float32(maxUp+maxDown)
+	}
+	for i, bar := range r.up {
+		h := float32(max(r.chart.up[i], 0)) * scale
+		bar.Resize(fyne.NewSize(slot-gap, h))
+		bar.Move(fyne.NewPos(float32(i)*slot, baseY-h))
+	}
+	for i, bar := range r.down {
+		h := float32(max(r.chart.down[i], 0)) * scale
+		bar.Resize(fyne.NewSize(slot-gap, h))
+		bar.Move(fyne.NewPos(float32(i)*slot, baseY))
+	}
+}
+
+func syncBars(bars []*canvas.Rectangle, n int, fill color.Color) []*canvas.Rectangle {
+	for len(bars) < n {
+		bars = append(bars, canvas.NewRectangle(fill))
+	}
+	bars = bars[:n]
+	for _, bar := range bars {
+		bar.FillColor = fill
+	}
+	return bars
+}
+
+func maxValue(values []float64) float64 {
+	highest := 0.0
+	for _, v := range values {
+		if v > highest {
+			highest = v
+		}
+	}
+	return highest
+}
@@ -1,6 +1,8 @@
 package widgets
 
 import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/container"
 	"fyne.io/fyne/v2/data/binding"
 	"fyne.io/fyne/v2/widget"
 )
@@ -12,8 +14,9 @@ import (
 //   - PerPage: Results per page (1-100)
 //
 // All fields are bound to provided data bindings for automatic synchronization
-// with the underlying ViewModel.
-func NewCredentialsForm(username, token, perPage binding.String) *widget.Form {
+// with the underlying ViewModel. tokenActions, if any, are placed after the
+// token entry.
+func NewCredentialsForm(username, token, perPage binding.String, tokenActions ...fyne.CanvasObject) *widget.Form {
 	usernameEntry := widget.NewEntryWithData(username)
 	usernameEntry.SetPlaceHolder("octocat")
 
@@ -24,9 +27,14 @@ func NewCredentialsForm(username, token, perPage binding.String) *widget.Form {
 	perPageEntry := widget.NewEntryWithData(perPage)
 	perPageEntry.SetPlaceHolder("1-100 (default 100)")
 
+	var tokenRow fyne.CanvasObject = tokenEntry
+	if len(tokenActions) > 0 {
+		tokenRow = container.NewBorder(nil, nil, nil, container.NewHBox(tokenActions...), tokenEntry)
+	}
+
 	return widget.NewForm(
 		widget.NewFormItem("Username", usernameEntry),
-		widget.NewFormItem("Token", tokenEntry),
+		widget.NewFormItem("Token", tokenRow),
 		widget.NewFormItem("Per Page", perPageEntry),
 	)
 }
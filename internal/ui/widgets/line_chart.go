@@ -0,0 +1,127 @@
+package widgets
+
+import (
+	"fyne.io/fyne/v2"
+	"fyne.io/fyne/v2/canvas"
+	"fyne.io/fyne/v2/theme"
+	"fyne.io/fyne/v2/widget"
+)
+
+// LineChart plots a single series as connected segments. X values need not be
+// evenly spaced, so gaps between samples show up as longer segments.
+type LineChart struct {
+	widget.BaseWidget
+
+	LineColor fyne.ThemeColorName
+	Height    float32
+
+	xs []float64
+	ys []float64
+}
+
+// NewLineChart creates an empty chart drawn in the given theme color.
+func NewLineChart(lineColor fyne.ThemeColorName) *LineChart {
+	c := &LineChart{LineColor: lineColor, Height: 80}
+	c.ExtendBaseWidget(c)
+	return c
+}
+
+// SetData replaces the plotted points; xs and ys must have the same length.
+// Must be called on the main thread.
+func (c *LineChart) SetData(xs, ys []float64) {
+	n := min(len(xs), len(ys))
+	c.xs = xs[:n]
+	c.ys = ys[:n]
+	c.Refresh()
+}
+
+func (c *LineChart) CreateRenderer() fyne.WidgetRenderer {
+	c.ExtendBaseWidget(c)
+	empty := canvas.NewText("Not enough data", theme.Color(theme.ColorNameDisabled))
+	empty.Alignment = fyne.TextAlignCenter
+	r := &lineChartRenderer{chart: c, axis: canvas.NewLine(theme.Color(theme.ColorNameSeparator)), empty: empty}
+	r.Refresh()
+	return r
+}
+
+type lineChartRenderer struct {
+	chart    *LineChart
+	axis     *canvas.Line
+	empty    *canvas.Text
+	segments []*canvas.Line
+	objects  []fyne.CanvasObject
+}
+
+func (r *lineChartRenderer) Destroy() {}
+
+func (r *lineChartRenderer) MinSize() fyne.Size {
+	return fyne.NewSize(120, r.chart.Height)
+}
+
+func (r *lineChartRenderer) Objects() []fyne.CanvasObject {
+	return r.objects
+}
+
+func (r *lineChartRenderer) Refresh() {
+	n := max(len(r.chart.xs)-1, 0)
+	stroke := theme.Color(r.chart.LineColor)
+	for len(r.segments) < n {
+		r.segments = append(r.segments, canvas.NewLine(stroke))
+	}
+	r.segments = r.segments[:n]
+	for _, segment := range r.segments {
+		segment.StrokeColor = stroke
+		segment.StrokeWidth = 2
+	}
+	r.axis.StrokeColor = theme.Color(theme.ColorNameSeparator)
+	r.empty.Color = theme.Color(theme.ColorNameDisabled)
+	r.empty.Hidden = n > 0
+
+	r.objects = r.objects[:0]
+	r.objects = append(r.objects, r.axis, r.empty)
+	for _, segment := range r.segments {
+		r.objects = append(r.objects, segment)
+	}
+	r.Layout(r.chart.Size())
+	canvas.Refresh(r.chart)
+}
+
+func (r *lineChartRenderer) Layout(size fyne.Size) {
+	r.empty.Resize(size)
+	r.empty.Move(fyne.NewPos(0, (size.Height-r.empty.MinSize().Height)/2))
+	r.axis.Position1 = fyne.NewPos(0, size.Height)
+	r.axis.Position2 = fyne.NewPos(size.Width, size.Height)
+
+	xs, ys := r.chart.xs, r.chart.ys
+	if len(xs) < 2 {
+		return
+	}
+	minX, maxX := bounds(xs)
+	minY, maxY := bounds(ys)
+	point := func(i int) fyne.Position {
+		x, y := float32(0), size.Height/2
+		if maxX > minX {
+			x = size.Width * float32((xs[i]-minX)/(maxX-minX))
+		}
+		if maxY > minY {
+			y = size.Height * (1 - float32((ys[i]-minY)/(maxY-minY)))
+		}
+		return fyne.NewPos(x, y)
+	}
+	for i, segment := range r.segments {
+		segment.Position1 = point(i)
+		segment.Position2 = point(i + 1)
+	}
+}
+
+func bounds(values []float64) (lowest, highest float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+	lowest, highest = values[0], values[0]
+	for _, v := range values[1:] {
+		lowest = min(lowest, v)
+		highest = max(highest, v)
+	}
+	return lowest, highest
+}
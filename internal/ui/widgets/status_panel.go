@@ -15,14 +15,19 @@ import (
 
 // StatusPanel creates a compact status bar with a colored dot and message.
 // The dot reflects state: green for success, blinking yellow for loading, red for errors.
-func NewStatusPanel(status, errorMsg binding.String, loading binding.Bool) fyne.CanvasObject {
+// Any extra objects are shown at the trailing edge.
+func NewStatusPanel(status, errorMsg binding.String, loading binding.Bool, extra ...fyne.CanvasObject) fyne.CanvasObject {
 	message := widget.NewLabel("")
 	message.Wrapping = fyne.TextWrapWord
 
 	dot := canvas.NewCircle(theme.SuccessColor())
 	dot.StrokeWidth = 0
 	dotHolder := container.NewGridWrap(fyne.NewSize(8, 8), dot)
-	bar := container.NewBorder(nil, nil, container.NewCenter(dotHolder), nil, message)
+	var trailing fyne.CanvasObject
+	if len(extra) > 0 {
+		trailing = container.NewHBox(extra...)
+	}
+	bar := container.NewBorder(nil, nil, container.NewCenter(dotHolder), trailing, message)
 
 	warningOpaque := dotColor(theme.WarningColor(), true)
 	warningTransparent := dotColor(theme.WarningColor(), false)
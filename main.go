@@ -1,22 +1,214 @@
 package main
 
 import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"fyne.io/fyne/v2"
 	"fyne.io/fyne/v2/app"
 
+	"github.com/tbxark/gh-stars/internal/app/compare"
+	"github.com/tbxark/gh-stars/internal/app/credstore"
+	"github.com/tbxark/gh-stars/internal/app/deviceflow"
+	"github.com/tbxark/gh-stars/internal/app/enrich"
+	"github.com/tbxark/gh-stars/internal/app/history"
+	"github.com/tbxark/gh-stars/internal/app/instance"
+	"github.com/tbxark/gh-stars/internal/app/jobs"
+	"github.com/tbxark/gh-stars/internal/app/keymap"
+	"github.com/tbxark/gh-stars/internal/app/profile"
+	"github.com/tbxark/gh-stars/internal/app/ratelimit"
+	"github.com/tbxark/gh-stars/internal/app/recent"
+	"github.com/tbxark/gh-stars/internal/app/releases"
 	"github.com/tbxark/gh-stars/internal/app/repos"
+	"github.com/tbxark/gh-stars/internal/app/schedule"
+	"github.com/tbxark/gh-stars/internal/app/session"
+	"github.com/tbxark/gh-stars/internal/app/settings"
 	"github.com/tbxark/gh-stars/internal/app/stars"
+	"github.com/tbxark/gh-stars/internal/app/team"
+	"github.com/tbxark/gh-stars/internal/app/tokensource"
+	"github.com/tbxark/gh-stars/internal/app/trends"
+	"github.com/tbxark/gh-stars/internal/app/viewmode"
 	"github.com/tbxark/gh-stars/internal/github"
 	"github.com/tbxark/gh-stars/internal/ui/nav"
+	settingsui "github.com/tbxark/gh-stars/internal/ui/settings"
 )
 
+// oauthClientID is the OAuth app "Sign in with GitHub" uses. It is empty
+// unless packagers build with -ldflags "-X main.oauthClientID=..."; users can
+// set GH_STARS_OAUTH_CLIENT_ID instead. Without either the button is hidden.
+var oauthClientID = ""
+
 func main() {
-	fyneApp := app.New()
+	req, err := instance.ParseArgs(os.Args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "gh-stars: %v\n%s\n", err, instance.Usage)
+		os.Exit(2)
+	}
+	// A running instance takes over this launch. If it does not answer,
+	// this one runs on its own.
+	server, err := instance.Listen(instance.SocketPath())
+	if errors.Is(err, instance.ErrRunning) && instance.Forward(instance.SocketPath(), req) == nil {
+		return
+	}
+
+	// The ID matches FyneApp.toml; preferences (and so profiles) only
+	// persist when the app has one.
+	fyneApp := app.NewWithID("gh_stars")
+
+	settingsStore := settings.NewStore(fyneApp.Preferences())
+	current := settingsStore.Load()
+	cacheRoot := current.CacheRoot()
 
 	client := github.NewClient(nil)
-	starsSvc := stars.Service{GH: client}
-	repoSvc := repos.Service{GH: client}
+	// A profile on a GitHub Enterprise host takes its API root from the
+	// host; github.com profiles use the base URL from the settings.
+	var (
+		baseMu       sync.Mutex
+		profileBase  string
+		settingsBase = current.BaseURL
+	)
+	applyBaseURL := func() {
+		if profileBase != "" {
+			client.SetBaseURL(profileBase)
+		} else {
+			client.SetBaseURL(settingsBase)
+		}
+	}
+	switchHost := func(host string) {
+		baseMu.Lock()
+		defer baseMu.Unlock()
+		profileBase = profile.APIBaseURL(host)
+		applyBaseURL()
+	}
+	applySettings := func(s settings.Settings) {
+		baseMu.Lock()
+		settingsBase = s.BaseURL
+		applyBaseURL()
+		baseMu.Unlock()
+		client.SetTimeout(s.Timeout)
+		// Saved settings were validated, so the proxy parses.
+		_ = client.SetProxy(s.Proxy)
+		settingsui.ApplyTheme(fyneApp, s.Theme)
+	}
+	applySettings(current)
+	settingsStore.Subscribe(applySettings)
+	historyStore := history.NewStore(dataPath("snapshots"))
+	trendStore := trends.NewStore(dataPath("trends.json"))
+	_ = trendStore.Load()
+	releaseStore := releases.NewStore(dataPath("releases.json"))
+	_ = releaseStore.Load()
+	gate := &ratelimit.Gate{Status: client.RateLimit, Reserve: 100}
+	poller := &releases.Poller{
+		Watcher: &releases.Watcher{GH: client, Store: releaseStore, Gate: gate},
+	}
+	baseStars := stars.NewCoalescingLoader(stars.Service{GH: client})
+	var starsSvc stars.Loader = &trends.Recorder{Next: baseStars, Store: trendStore}
+	starsSvc = &releases.Tracker{Next: starsSvc, Poller: poller}
+	starsSvc = &history.Recorder{Next: starsSvc, Store: historyStore}
+	detailsSvc := repos.NewCoalescingLoader(repos.Service{GH: client})
+	repoSvc := repos.NewCachedLoader(detailsSvc, cachePath(cacheRoot, "details"), current.CacheTTL)
+	settingsStore.Subscribe(func(s settings.Settings) { repoSvc.SetTTL(s.CacheTTL) })
+
+	enrichStore := enrich.NewStore(cachePath(cacheRoot, "enriched.json"))
+	_ = enrichStore.Load()
+	// Enrichment keeps its own store, so it skips the details cache rather
+	// than pushing the repos the user opened out of it.
+	enricher := &enrich.Job{
+		Details: detailsSvc,
+		Store:   enrichStore,
+		Gate:    gate,
+	}
+
+	teamBuilder := &team.Builder{
+		Stars:   baseStars,
+		Members: client,
+		Gate:    gate,
+		Store:   team.NewStore(dataPath("teams")),
+	}
 
-	router := &nav.AppNavigator{App: fyneApp, RepoSvc: repoSvc, StarsSvc: starsSvc}
-	router.ShowStars()
+	jobManager := &jobs.Manager{}
+	scheduler := &schedule.Scheduler{
+		Gate:   gate,
+		Online: client.Reachable,
+		Jobs:   jobManager,
+		Prefs:  fyneApp.Preferences(),
+	}
+
+	router := &nav.AppNavigator{
+		App:          fyneApp,
+		RepoSvc:      repoSvc,
+		StarsSvc:     starsSvc,
+		Enricher:     enricher,
+		History:      historyStore,
+		Trends:       trendStore,
+		Releases:     poller,
+		CompareSvc:   compare.Service{Stars: baseStars},
+		Team:         teamBuilder,
+		Profiles:     profile.NewStore(fyneApp.Preferences()),
+		Tokens:       credstore.NewStore(dataPath("credentials.json")),
+		TokenFinder:  tokensource.NewFinder(),
+		Users:        client,
+		TokenChecker: client,
+		OnHostChange: switchHost,
+		Jobs:         jobManager,
+		Scheduler:    scheduler,
+		Recent:       recent.NewStore(fyneApp.Preferences()),
+		Keymap:       keymap.NewStore(fyneApp.Preferences()),
+		Layout:       viewmode.NewStore(fyneApp.Preferences()),
+		Session:      session.NewStore(fyneApp.Preferences()),
+		Settings:     settingsStore,
+		Cache: settingsui.Cache{
+			Dir: cacheRoot,
+			Clear: func() error {
+				return errors.Join(repoSvc.Clear(), enrichStore.Clear())
+			},
+		},
+	}
+	clientID := oauthClientID
+	if id := os.Getenv("GH_STARS_OAUTH_CLIENT_ID"); id != "" {
+		clientID = id
+	}
+	if clientID != "" {
+		router.DeviceLogin = deviceflow.Config{
+			ClientID: clientID,
+			Scopes:   []string{"repo", "read:org"},
+			BaseURL:  os.Getenv("GH_STARS_OAUTH_URL"),
+		}
+	}
+	router.InstallTray()
+	router.RestoreSession()
+	router.Handle(req)
+	if server != nil {
+		defer server.Close()
+		go func() {
+			_ = server.Serve(func(req instance.Request) {
+				fyne.Do(func() { router.Handle(req) })
+			})
+		}()
+	}
+	// Quitting from the tray skips the stars window's close handler.
+	fyneApp.Lifecycle().SetOnStopped(router.SaveSession)
 	fyneApp.Run()
 }
+
+// cachePath returns name inside root, the cache dir from the settings, or
+// "" when there is none, which keeps the corresponding cache in memory.
+func cachePath(root, name string) string {
+	if root == "" {
+		return ""
+	}
+	return filepath.Join(root, name)
+}
+
+// dataPath returns name inside the per-user config dir. Unlike the cache,
+// data stored here is history that cannot be fetched again.
+func dataPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "gh-stars", name)
+}